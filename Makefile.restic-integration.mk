@@ -3,10 +3,10 @@ include Makefile.restic-integration.vars.mk
 clean_targets += restic-integration-test-clean
 
 .PHONY: restic-integration-test-setup
-restic-integration-test-setup: minio-start restic-download ## Prepare to run the integration test for the restic module
+restic-integration-test-setup: minio-start rest-server-start restic-download ## Prepare to run the integration test for the restic module
 
 .PHONY: restic-clean
-restic-integration-test-clean: minio-stop ## Clean the integration test of the restic module
+restic-integration-test-clean: minio-stop rest-server-stop sshd-stop ## Clean the integration test of the restic module
 
 .PHONY: minio-address
 minio-address: ## Get the address to connect to minio
@@ -64,3 +64,58 @@ $(restic_path): | $(go_bin)
 		bunzip2 > "$@"
 	chmod +x "$@"
 	"$@" version
+
+.PHONY: rest-server-start
+rest-server-start: rest-server-clean $(rest_server_pid) ## Run an append-only rest-server
+
+.PHONY: rest-server-clean
+rest-server-clean: ## Remove the rest-server PID file if the process is not running
+	@./clean.sh "$(rest_server_pid)"
+
+.PHONY: rest-server-stop
+rest-server-stop: ## Stop the rest-server
+	@./kill.sh "$(rest_server_pid)"
+
+rest-server-download: $(rest_server_path) ## Download github.com/restic/rest-server
+
+$(rest_server_pid): rest-server-download
+	@mkdir -p "$(rest_server_data)"
+	@./exec.sh "$(rest_server_pid)" \
+		"$(rest_server_path)" \
+			--append-only \
+			--no-auth \
+			--path "$(rest_server_data)" \
+			--listen "$(rest_server_address)"
+	@while ! curl --silent "http://$(rest_server_address)" > /dev/null; do echo "Waiting for server http://$(rest_server_address) to become ready"; sleep 0.5; done
+
+$(rest_server_path): | $(go_bin)
+	curl $(curl_args) "$(rest_server_url)" | \
+		tar -xzO --wildcards '*/rest-server' > "$@"
+	chmod +x "$@"
+	"$@" --version
+
+.PHONY: sshd-start
+sshd-start: $(sshd_private_key) ## Run an SSH server in a container to test SFTP repositories
+	@docker run --detach --rm --name "$(sshd_container)" --publish "$(sshd_port):22" \
+		--volume "$(sshd_private_key).pub:/home/$(sshd_user)/.ssh/keys/id.pub:ro" \
+		"$(sshd_image)" "$(sshd_user)::1001::upload"
+	@while ! ssh-keyscan -p "$(sshd_port)" localhost > "$(sshd_known_hosts)" 2> /dev/null; do echo "Waiting for the SSH server on port $(sshd_port) to become ready"; sleep 0.5; done
+
+.PHONY: sshd-stop
+sshd-stop: ## Stop the SSH server container
+	@docker rm --force "$(sshd_container)" > /dev/null 2>&1 || true
+
+.PHONY: sftp-integration-test
+sftp-integration-test: export RESTIC_BINARY = $(restic_path)
+sftp-integration-test: export RESTIC_PASSWORD = $(restic_password)
+sftp-integration-test: export BACKUP_DIR = $(backup_dir)
+sftp-integration-test: export SFTP_REPOSITORY = $(sshd_repository)
+sftp-integration-test: export SFTP_PRIVATE_KEY_FILE = $(sshd_private_key)
+sftp-integration-test: export SFTP_KNOWN_HOSTS_FILE = $(sshd_known_hosts)
+sftp-integration-test: restic-download sshd-start ## Run the integration test of SFTP repositories against a local SSH server
+	$(GO_EXEC) test -tags=integration -run TestSFTPBackup ./cmd/restic/...
+	@$(MAKE) sshd-stop
+
+$(sshd_private_key):
+	@mkdir -p "$(sshd_dir)"
+	ssh-keygen -q -t ed25519 -N "" -f "$@"
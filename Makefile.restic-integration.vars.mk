@@ -29,3 +29,20 @@ minio_root_user ?= accesskey
 minio_root_password ?= secretkey
 minio_pid ?= $(integrationtest_dir)/minio.pid
 minio_url ?= https://dl.min.io/server/minio/release/$(os)-$(arch)/minio
+
+rest_server_version ?= 0.14.0
+rest_server_port ?= 8010
+rest_server_address = localhost:$(rest_server_port)
+rest_server_path ?= $(go_bin)/rest-server
+rest_server_data ?= $(integrationtest_dir)/rest-server.d
+rest_server_pid ?= $(integrationtest_dir)/rest-server.pid
+rest_server_url ?= https://github.com/restic/rest-server/releases/download/v$(rest_server_version)/rest-server_$(rest_server_version)_$(os)_$(arch).tar.gz
+
+sshd_container ?= k8up-integration-sshd
+sshd_image ?= docker.io/atmoz/sftp:alpine
+sshd_port ?= 2222
+sshd_user ?= k8up
+sshd_dir ?= $(integrationtest_dir)/sshd.d
+sshd_private_key ?= $(sshd_dir)/id_ed25519
+sshd_known_hosts ?= $(sshd_dir)/known_hosts
+sshd_repository ?= sftp://$(sshd_user)@localhost:$(sshd_port)/upload/restic
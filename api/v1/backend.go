@@ -2,6 +2,7 @@ package v1
 
 import (
 	"fmt"
+	"net/url"
 	"reflect"
 	"strings"
 
@@ -16,6 +17,14 @@ type (
 	Backend struct {
 		// RepoPasswordSecretRef references a secret key to look up the restic repository password
 		RepoPasswordSecretRef *corev1.SecretKeySelector `json:"repoPasswordSecretRef,omitempty"`
+		// RepoPasswordFileSecretRef references a secret key with the restic repository password that is mounted as a file,
+		// so that the password isn't part of the environment of the job.
+		// +optional
+		RepoPasswordFileSecretRef *corev1.SecretKeySelector `json:"repoPasswordFileSecretRef,omitempty"`
+		// RepoPasswordCommand configures a command that prints the restic repository password,
+		// e.g. to get it from Vault or a KMS without storing it in a Secret.
+		// +optional
+		RepoPasswordCommand *PasswordCommandSpec `json:"repoPasswordCommand,omitempty"`
 		// EnvFrom adds all environment variables from a an external source to the Restic job.
 		EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
 		Local   *LocalSpec             `json:"local,omitempty"`
@@ -25,9 +34,51 @@ type (
 		Swift   *SwiftSpec             `json:"swift,omitempty"`
 		B2      *B2Spec                `json:"b2,omitempty"`
 		Rest    *RestServerSpec        `json:"rest,omitempty"`
+		SFTP    *SFTPSpec              `json:"sftp,omitempty"`
+		Rclone  *RcloneSpec            `json:"rclone,omitempty"`
 
 		TLSOptions   *TLSOptions           `json:"tlsOptions,omitempty"`
 		VolumeMounts *[]corev1.VolumeMount `json:"volumeMounts,omitempty"`
+
+		// Credentials replace the credentials of this backend for some kinds of jobs,
+		// e.g. to give backup jobs only credentials that can't delete data.
+		// +optional
+		Credentials *OperationCredentials `json:"credentials,omitempty"`
+
+		// ServiceAccount configures the ServiceAccount the jobs of this backend run as,
+		// e.g. one that is bound to a cloud identity with access to the storage.
+		// +optional
+		ServiceAccount *BackendServiceAccount `json:"serviceAccount,omitempty"`
+	}
+
+	// BackendServiceAccount configures the ServiceAccount of the jobs of a backend.
+	BackendServiceAccount struct {
+		// Name of the ServiceAccount.
+		// It's created in the namespace of the job if it doesn't exist.
+		Name string `json:"name"`
+		// Annotations are added to the ServiceAccount, e.g. 'eks.amazonaws.com/role-arn',
+		// 'iam.gke.io/gcp-service-account' or 'azure.workload.identity/client-id' to bind it to a cloud identity.
+		// +optional
+		Annotations map[string]string `json:"annotations,omitempty"`
+	}
+
+	// PasswordCommandSpec configures the command that restic runs to get the repository password.
+	PasswordCommandSpec struct {
+		// Command is run by restic and has to print the repository password to stdout.
+		// It's split into arguments at spaces, it isn't run in a shell.
+		Command string `json:"command"`
+		// Image of an init container that provides tools the command needs and aren't part of the k8up image.
+		// The init container has an empty directory mounted at '/opt/k8up/password-command', which is mounted at the same path for the command.
+		// +optional
+		Image string `json:"image,omitempty"`
+		// InitCommand is run in the init container, e.g. to copy the tools to '/opt/k8up/password-command'.
+		// The entrypoint of the image is run if it's empty.
+		// +optional
+		InitCommand []string `json:"initCommand,omitempty"`
+		// Volumes are mounted read-only for the command at '/etc/k8up/password-command/<name>',
+		// e.g. the token or configuration of a KMS client.
+		// +optional
+		Volumes []RunnableVolumeSpec `json:"volumes,omitempty"`
 	}
 
 	// +k8s:deepcopy-gen=false
@@ -40,6 +91,8 @@ type (
 )
 
 // GetCredentialEnv will return a map containing the credentials for the given backend.
+// The repository password is only part of it if it's referenced by RepoPasswordSecretRef,
+// the password file and command are attached to the pod by the executor.
 func (in *Backend) GetCredentialEnv() map[string]*corev1.EnvVarSource {
 	vars := make(map[string]*corev1.EnvVarSource)
 
@@ -59,6 +112,19 @@ func (in *Backend) GetCredentialEnv() map[string]*corev1.EnvVarSource {
 	return nil
 }
 
+// ResticOptions returns the restic options for the settings of the backend's storage, in the form 'key=value'.
+func (in *Backend) ResticOptions() []string {
+	if in == nil || in.S3 == nil {
+		return nil
+	}
+	return in.S3.ResticOptions()
+}
+
+// HasRepoPassword returns true if any source of the restic repository password is configured.
+func (in *Backend) HasRepoPassword() bool {
+	return in.RepoPasswordSecretRef != nil || in.RepoPasswordFileSecretRef != nil || in.RepoPasswordCommand != nil
+}
+
 // String returns the string representation of the repository. If no repo is
 // defined it'll return empty string.
 func (in *Backend) String() string {
@@ -81,7 +147,7 @@ func (in *Backend) IsBackendEqualTo(other *Backend) bool {
 }
 
 func (in *Backend) getSupportedBackends() []BackendInterface {
-	return []BackendInterface{in.Azure, in.B2, in.GCS, in.Local, in.Rest, in.S3, in.Swift}
+	return []BackendInterface{in.Azure, in.B2, in.GCS, in.Local, in.Rest, in.S3, in.Swift, in.SFTP, in.Rclone}
 }
 
 // IsNil returns true if the given value is nil using reflect.
@@ -117,6 +183,31 @@ type S3Spec struct {
 	Bucket                   string                    `json:"bucket,omitempty"`
 	AccessKeyIDSecretRef     *corev1.SecretKeySelector `json:"accessKeyIDSecretRef,omitempty"`
 	SecretAccessKeySecretRef *corev1.SecretKeySelector `json:"secretAccessKeySecretRef,omitempty"`
+
+	// RoleARN is the ARN of the IAM role the job assumes with a projected ServiceAccount token (web identity),
+	// instead of using access keys.
+	// The trust policy of the role has to allow the ServiceAccount of the job.
+	// +optional
+	RoleARN string `json:"roleARN,omitempty"`
+	// WebIdentityTokenAudience is the audience of the projected ServiceAccount token, 'sts.amazonaws.com' by default.
+	// +optional
+	WebIdentityTokenAudience string `json:"webIdentityTokenAudience,omitempty"`
+
+	// Region of the bucket, restic detects it if it's empty.
+	// +optional
+	Region string `json:"region,omitempty"`
+	// BucketLookup selects how the bucket is addressed: 'dns' for virtual-host style, 'path' for path style.
+	// 'auto' uses virtual-host style if the endpoint supports it.
+	// +kubebuilder:validation:Enum=auto;dns;path
+	// +optional
+	BucketLookup string `json:"bucketLookup,omitempty"`
+	// StorageClass of the objects restic uploads, e.g. 'STANDARD_IA'.
+	// Metadata is always stored in the STANDARD class, restic has to read it regularly.
+	// +optional
+	StorageClass string `json:"storageClass,omitempty"`
+	// ListObjectsV1 uses version 1 of the list objects API, for S3 compatible servers that don't support version 2.
+	// +optional
+	ListObjectsV1 bool `json:"listObjectsV1,omitempty"`
 }
 
 // EnvVars returns the env vars for this backend.
@@ -126,24 +217,32 @@ func (in *S3Spec) EnvVars(vars map[string]*corev1.EnvVarSource) map[string]*core
 	return vars
 }
 
-// String returns "s3:endpoint/bucket".
-// If endpoint or bucket are empty, it uses their global setting accordingly.
-func (in *S3Spec) String() string {
-	endpoint := cfg.Config.GlobalS3Endpoint
-	if in.Endpoint != "" {
-		endpoint = in.Endpoint
+// ResticOptions returns the restic options for the settings of this backend.
+func (in *S3Spec) ResticOptions() []string {
+	var options []string
+	if in.Region != "" {
+		options = append(options, "s3.region="+in.Region)
 	}
-	endpoint = strings.TrimRight(endpoint, "/")
-
-	bucket := cfg.Config.GlobalS3Bucket
-	if in.Bucket != "" {
-		bucket = in.Bucket
+	if in.BucketLookup != "" {
+		options = append(options, "s3.bucket-lookup="+in.BucketLookup)
 	}
+	if in.StorageClass != "" {
+		options = append(options, "s3.storage-class="+in.StorageClass)
+	}
+	if in.ListObjectsV1 {
+		options = append(options, "s3.list-objects-v1=true")
+	}
+	return options
+}
 
-	return fmt.Sprintf("s3:%v/%v", endpoint, bucket)
+// String returns "s3:endpoint/bucket".
+// Empty endpoints or buckets are completed from the ClusterRepository before the job runs.
+func (in *S3Spec) String() string {
+	return fmt.Sprintf("s3:%v/%v", strings.TrimRight(in.Endpoint, "/"), in.Bucket)
 }
 
 // RestoreEnvVars returns the env vars for this backend when using Restore jobs.
+// Credentials that aren't referenced are completed from the ClusterRepository before the job runs.
 func (in *S3Spec) RestoreEnvVars() map[string]*corev1.EnvVar {
 	if in == nil {
 		return nil
@@ -155,35 +254,17 @@ func (in *S3Spec) RestoreEnvVars() map[string]*corev1.EnvVar {
 				SecretKeyRef: in.AccessKeyIDSecretRef,
 			},
 		}
-	} else {
-		vars[cfg.RestoreS3AccessKeyIDEnvName] = &corev1.EnvVar{
-			Value: cfg.Config.GlobalRestoreS3AccessKey,
-		}
 	}
-
 	if in.SecretAccessKeySecretRef != nil {
 		vars[cfg.RestoreS3SecretAccessKeyEnvName] = &corev1.EnvVar{
 			ValueFrom: &corev1.EnvVarSource{
 				SecretKeyRef: in.SecretAccessKeySecretRef,
 			},
 		}
-	} else {
-		vars[cfg.RestoreS3SecretAccessKeyEnvName] = &corev1.EnvVar{
-			Value: cfg.Config.GlobalRestoreS3SecretAccessKey,
-		}
-	}
-
-	bucket := in.Bucket
-	endpoint := in.Endpoint
-	if bucket == "" {
-		bucket = cfg.Config.GlobalRestoreS3Bucket
-	}
-	if endpoint == "" {
-		endpoint = cfg.Config.GlobalRestoreS3Endpoint
 	}
 
 	vars[cfg.RestoreS3EndpointEnvName] = &corev1.EnvVar{
-		Value: fmt.Sprintf("%v/%v", endpoint, bucket),
+		Value: fmt.Sprintf("%v/%v", in.Endpoint, in.Bucket),
 	}
 
 	return vars
@@ -193,6 +274,12 @@ type GCSSpec struct {
 	Bucket               string                    `json:"bucket,omitempty"`
 	ProjectIDSecretRef   *corev1.SecretKeySelector `json:"projectIDSecretRef,omitempty"`
 	AccessTokenSecretRef *corev1.SecretKeySelector `json:"accessTokenSecretRef,omitempty"`
+
+	// CredentialsSecretRef references the JSON key of a Google service account, or a credential configuration for workload identity federation.
+	// It's mounted into the job's pod.
+	// Without credentials and access token, the job uses the identity of its ServiceAccount, e.g. by GKE workload identity.
+	// +optional
+	CredentialsSecretRef *corev1.SecretKeySelector `json:"credentialsSecretRef,omitempty"`
 }
 
 // EnvVars returns the env vars for this backend.
@@ -212,11 +299,21 @@ type AzureSpec struct {
 	Path                 string                    `json:"path,omitempty"`
 	AccountNameSecretRef *corev1.SecretKeySelector `json:"accountNameSecretRef,omitempty"`
 	AccountKeySecretRef  *corev1.SecretKeySelector `json:"accountKeySecretRef,omitempty"`
+
+	// SASTokenSecretRef references a shared access signature that is used instead of the account key.
+	// +optional
+	SASTokenSecretRef *corev1.SecretKeySelector `json:"sasTokenSecretRef,omitempty"`
+	// ManagedIdentityClientID is the client ID of the user-assigned managed identity the job authenticates as.
+	// Without account key and SAS token, the job authenticates with a managed identity or Azure workload identity.
+	// The client ID is only needed if several identities are available.
+	// +optional
+	ManagedIdentityClientID string `json:"managedIdentityClientID,omitempty"`
 }
 
 // EnvVars returns the env vars for this backend.
 func (in *AzureSpec) EnvVars(vars map[string]*corev1.EnvVarSource) map[string]*corev1.EnvVarSource {
 	addEnvVarFromSecret(vars, cfg.AzureAccountKeyEnvName, in.AccountKeySecretRef)
+	addEnvVarFromSecret(vars, cfg.AzureAccountSASEnvName, in.SASTokenSecretRef)
 	addEnvVarFromSecret(vars, cfg.AzureAccountEnvName, in.AccountNameSecretRef)
 	return vars
 }
@@ -234,10 +331,62 @@ func (in *AzureSpec) String() string {
 type SwiftSpec struct {
 	Container string `json:"container,omitempty"`
 	Path      string `json:"path,omitempty"`
+
+	// AuthURLSecretRef references the URL of the Keystone identity service.
+	// It's required if any of the other credentials are given.
+	// Without credentials, the OS_* variables have to be passed by envFrom.
+	// +optional
+	AuthURLSecretRef *corev1.SecretKeySelector `json:"authURLSecretRef,omitempty"`
+	// RegionNameSecretRef references the name of the region.
+	// +optional
+	RegionNameSecretRef *corev1.SecretKeySelector `json:"regionNameSecretRef,omitempty"`
+
+	// UsernameSecretRef references the user name for Keystone v2 and v3 authentication.
+	// +optional
+	UsernameSecretRef *corev1.SecretKeySelector `json:"usernameSecretRef,omitempty"`
+	// PasswordSecretRef references the password for Keystone v2 and v3 authentication.
+	// +optional
+	PasswordSecretRef *corev1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+
+	// TenantIDSecretRef references the ID of the tenant for Keystone v2 authentication.
+	// +optional
+	TenantIDSecretRef *corev1.SecretKeySelector `json:"tenantIDSecretRef,omitempty"`
+	// TenantNameSecretRef references the name of the tenant for Keystone v2 authentication.
+	// +optional
+	TenantNameSecretRef *corev1.SecretKeySelector `json:"tenantNameSecretRef,omitempty"`
+
+	// UserDomainNameSecretRef references the name of the user's domain for Keystone v3 authentication.
+	// +optional
+	UserDomainNameSecretRef *corev1.SecretKeySelector `json:"userDomainNameSecretRef,omitempty"`
+	// ProjectNameSecretRef references the name of the project for Keystone v3 authentication.
+	// +optional
+	ProjectNameSecretRef *corev1.SecretKeySelector `json:"projectNameSecretRef,omitempty"`
+	// ProjectDomainNameSecretRef references the name of the project's domain for Keystone v3 authentication.
+	// +optional
+	ProjectDomainNameSecretRef *corev1.SecretKeySelector `json:"projectDomainNameSecretRef,omitempty"`
+
+	// ApplicationCredentialIDSecretRef references the ID of an application credential.
+	// Application credentials replace the user name and password.
+	// +optional
+	ApplicationCredentialIDSecretRef *corev1.SecretKeySelector `json:"applicationCredentialIDSecretRef,omitempty"`
+	// ApplicationCredentialSecretRef references the secret of the application credential.
+	// +optional
+	ApplicationCredentialSecretRef *corev1.SecretKeySelector `json:"applicationCredentialSecretRef,omitempty"`
 }
 
 // EnvVars returns the env vars for this backend.
 func (in *SwiftSpec) EnvVars(vars map[string]*corev1.EnvVarSource) map[string]*corev1.EnvVarSource {
+	addEnvVarFromSecret(vars, cfg.SwiftAuthURLEnvName, in.AuthURLSecretRef)
+	addEnvVarFromSecret(vars, cfg.SwiftRegionNameEnvName, in.RegionNameSecretRef)
+	addEnvVarFromSecret(vars, cfg.SwiftUsernameEnvName, in.UsernameSecretRef)
+	addEnvVarFromSecret(vars, cfg.SwiftPasswordEnvName, in.PasswordSecretRef)
+	addEnvVarFromSecret(vars, cfg.SwiftTenantIDEnvName, in.TenantIDSecretRef)
+	addEnvVarFromSecret(vars, cfg.SwiftTenantNameEnvName, in.TenantNameSecretRef)
+	addEnvVarFromSecret(vars, cfg.SwiftUserDomainNameEnvName, in.UserDomainNameSecretRef)
+	addEnvVarFromSecret(vars, cfg.SwiftProjectNameEnvName, in.ProjectNameSecretRef)
+	addEnvVarFromSecret(vars, cfg.SwiftProjectDomainNameEnvName, in.ProjectDomainNameSecretRef)
+	addEnvVarFromSecret(vars, cfg.SwiftApplicationCredentialIDEnvName, in.ApplicationCredentialIDSecretRef)
+	addEnvVarFromSecret(vars, cfg.SwiftApplicationCredentialSecretEnvName, in.ApplicationCredentialSecretRef)
 	return vars
 }
 
@@ -265,27 +414,164 @@ func (in *B2Spec) String() string {
 	return fmt.Sprintf("b2:%s:%s", in.Bucket, in.Path)
 }
 
+// RestServerSpec configures a repository on a rest-server.
 type RestServerSpec struct {
-	URL               string                    `json:"url,omitempty"`
-	UserSecretRef     *corev1.SecretKeySelector `json:"userSecretRef,omitempty"`
+	// URL of the rest-server, e.g. 'https://rest.example.com:8000/repo'.
+	// Credentials that are part of the URL take precedence over the ones referenced by userSecretRef and passwordSecretRef.
+	URL string `json:"url,omitempty"`
+	// Path of the repository on the rest-server, it's appended to the path of the URL.
+	// +optional
+	Path string `json:"path,omitempty"`
+	// NamespacedPath appends the namespace of the job to the path, so that every namespace gets its own repository.
+	// It's only supported in the backend of a ClusterRepository.
+	// +optional
+	NamespacedPath bool `json:"namespacedPath,omitempty"`
+	// UserSecretRef references the name of the user on the rest-server.
+	// +optional
+	UserSecretRef *corev1.SecretKeySelector `json:"userSecretRef,omitempty"`
+	// PasswordSecretRef references the password of the user on the rest-server.
+	// +optional
+	PasswordSecretRef *corev1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+	// Deprecated: Use PasswordSecretRef instead.
+	// +optional
 	PasswordSecretReg *corev1.SecretKeySelector `json:"passwordSecretReg,omitempty"`
 }
 
 // EnvVars returns the env vars for this backend.
+// restic adds the credentials to the URL of the rest-server, unless the URL contains credentials itself.
 func (in *RestServerSpec) EnvVars(vars map[string]*corev1.EnvVarSource) map[string]*corev1.EnvVarSource {
-	addEnvVarFromSecret(vars, cfg.RestPasswordEnvName, in.PasswordSecretReg)
+	addEnvVarFromSecret(vars, cfg.RestPasswordEnvName, in.passwordSecretRef())
 	addEnvVarFromSecret(vars, cfg.RestUserEnvName, in.UserSecretRef)
 	return vars
 }
 
-// String returns "rest:URL"
+// passwordSecretRef returns PasswordSecretRef, or the deprecated PasswordSecretReg if it isn't set.
+func (in *RestServerSpec) passwordSecretRef() *corev1.SecretKeySelector {
+	if in.PasswordSecretRef != nil {
+		return in.PasswordSecretRef
+	}
+	return in.PasswordSecretReg
+}
+
+// String returns "rest:URL" with the Path appended to the path of the URL.
+// A URL that can't be parsed is returned as it is.
 func (in *RestServerSpec) String() string {
-	protocol, url, _ := strings.Cut(in.URL, "://")
-	return fmt.Sprintf("rest:%s://%s:%s@%s", protocol, "$(USER)", "$(PASSWORD)", url)
+	u, err := url.Parse(in.URL)
+	if err != nil {
+		return "rest:" + in.URL
+	}
+	if in.Path != "" {
+		u = u.JoinPath(in.Path)
+	}
+	return "rest:" + u.String()
+}
+
+// WithNamespacedPath returns a copy of the spec with the given namespace appended to the path if NamespacedPath is set.
+// Otherwise it returns the spec itself.
+func (in *RestServerSpec) WithNamespacedPath(namespace string) *RestServerSpec {
+	if in == nil || !in.NamespacedPath {
+		return in
+	}
+	spec := in.DeepCopy()
+	spec.Path = strings.TrimSuffix(spec.Path, "/") + "/" + namespace
+	spec.NamespacedPath = false
+	return spec
+}
+
+// SFTPSpec configures a repository on an SSH server.
+// The SSH key material is mounted into the job's pod.
+type SFTPSpec struct {
+	// Host is the name or address of the SSH server.
+	Host string `json:"host,omitempty"`
+	// Port of the SSH server, 22 by default.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port int32 `json:"port,omitempty"`
+	// User is the user to log in as.
+	// +optional
+	User string `json:"user,omitempty"`
+	// Path of the repository on the SSH server.
+	// Relative paths are relative to the home directory of the user.
+	Path string `json:"path,omitempty"`
+	// PrivateKeySecretRef references the private SSH key used to log in.
+	// +optional
+	PrivateKeySecretRef *corev1.SecretKeySelector `json:"privateKeySecretRef,omitempty"`
+	// KnownHostsSecretRef references a known_hosts file that contains the host key of the SSH server.
+	// ssh refuses to connect to servers whose host key is unknown.
+	// +optional
+	KnownHostsSecretRef *corev1.SecretKeySelector `json:"knownHostsSecretRef,omitempty"`
+	// Command replaces the command restic runs to connect to the server, see the 'sftp.command' option of restic.
+	// The mounted private key and known_hosts files aren't passed to it, they have to be referenced in the command.
+	// +optional
+	Command string `json:"command,omitempty"`
+}
+
+// EnvVars returns the env vars for this backend.
+// The key material isn't passed as env vars, it's mounted into the job's pod.
+func (in *SFTPSpec) EnvVars(vars map[string]*corev1.EnvVarSource) map[string]*corev1.EnvVarSource {
+	return vars
+}
+
+// String returns "sftp:user@host:path", or "sftp://user@host:port/path" if a port is set.
+func (in *SFTPSpec) String() string {
+	host := in.Host
+	if in.User != "" {
+		host = in.User + "@" + host
+	}
+	if in.Port == 0 {
+		return fmt.Sprintf("sftp:%s:%s", host, in.Path)
+	}
+	return fmt.Sprintf("sftp://%s:%d/%s", host, in.Port, in.Path)
+}
+
+// RcloneSpec configures a repository on any storage rclone supports.
+// The rclone configuration is mounted into the job's pod.
+type RcloneSpec struct {
+	// Remote is the name of the rclone remote as configured in the rclone configuration.
+	Remote string `json:"remote,omitempty"`
+	// Path of the repository on the remote.
+	// +optional
+	Path string `json:"path,omitempty"`
+	// ConfigSecretRef references the rclone configuration, the content of a rclone.conf file that defines the remote.
+	ConfigSecretRef *corev1.SecretKeySelector `json:"configSecretRef,omitempty"`
+}
+
+// EnvVars returns the env vars for this backend.
+// The rclone configuration isn't passed as env var, it's mounted into the job's pod.
+func (in *RcloneSpec) EnvVars(vars map[string]*corev1.EnvVarSource) map[string]*corev1.EnvVarSource {
+	return vars
+}
+
+// String returns "rclone:remote:path".
+func (in *RcloneSpec) String() string {
+	return fmt.Sprintf("rclone:%s:%s", in.Remote, in.Path)
 }
 
 type TLSOptions struct {
 	CACert     string `json:"caCert,omitempty"`
 	ClientCert string `json:"clientCert,omitempty"`
 	ClientKey  string `json:"clientKey,omitempty"`
+
+	// CACertSecretRef references the certificate authority in a Secret.
+	// It's mounted into the job's pod, so caCert and volumeMounts aren't needed.
+	// +optional
+	CACertSecretRef *corev1.SecretKeySelector `json:"caCertSecretRef,omitempty"`
+	// CACertConfigMapRef references the certificate authority in a ConfigMap.
+	// It's mounted into the job's pod, so caCert and volumeMounts aren't needed.
+	// +optional
+	CACertConfigMapRef *corev1.ConfigMapKeySelector `json:"caCertConfigMapRef,omitempty"`
+	// ClientCertSecretRef references the client certificate in a Secret, e.g. the 'tls.crt' of a TLS Secret.
+	// It's mounted into the job's pod, so clientCert and volumeMounts aren't needed.
+	// +optional
+	ClientCertSecretRef *corev1.SecretKeySelector `json:"clientCertSecretRef,omitempty"`
+	// ClientKeySecretRef references the client private key in a Secret, e.g. the 'tls.key' of a TLS Secret.
+	// It's mounted into the job's pod, so clientKey and volumeMounts aren't needed.
+	// +optional
+	ClientKeySecretRef *corev1.SecretKeySelector `json:"clientKeySecretRef,omitempty"`
+}
+
+// HasReferences returns true if the options reference any files in Secrets or ConfigMaps.
+func (in *TLSOptions) HasReferences() bool {
+	return in != nil && (in.CACertSecretRef != nil || in.CACertConfigMapRef != nil || in.ClientCertSecretRef != nil || in.ClientKeySecretRef != nil)
 }
@@ -49,6 +49,11 @@ type BackupSpec struct {
 	// When defined, only PVCs and PreBackupPods matching them are backed up.
 	// +optional
 	LabelSelectors []metav1.LabelSelector `json:"labelSelectors,omitempty"`
+
+	// FailOnReadErrors fails the backup if restic couldn't read some of the files.
+	// By default, such a backup completes with the reason PartiallySucceeded, as the snapshots have been created nonetheless.
+	// +optional
+	FailOnReadErrors bool `json:"failOnReadErrors,omitempty"`
 }
 
 type BackupTemplate struct {
@@ -62,6 +67,105 @@ type Env struct {
 	Value string `json:"value,omitempty"`
 }
 
+// BackupStatus defines the observed state of Backup
+type BackupStatus struct {
+	Status        `json:",inline"`
+	BackupResults `json:",inline"`
+}
+
+// BackupResults contains the results of the backups of single volumes and backup commands.
+// The backup container reports them in its termination message.
+type BackupResults struct {
+	// Volumes contains the result of the backup of each PVC.
+	// +optional
+	Volumes []VolumeBackupStatus `json:"volumes,omitempty"`
+	// StdinBackups contains the result of each backup command of annotated Pods.
+	// +optional
+	StdinBackups []StdinBackupStatus `json:"stdinBackups,omitempty"`
+}
+
+// ReadErrors returns the number of errors of the partially succeeded backups and the first of their paths that couldn't be read.
+func (in BackupResults) ReadErrors(maxPaths int) (int, []string) {
+	count := 0
+	var paths []string
+	runs := make([]BackupRunStatus, 0, len(in.Volumes)+len(in.StdinBackups))
+	for _, volume := range in.Volumes {
+		runs = append(runs, volume.BackupRunStatus)
+	}
+	for _, stdinBackup := range in.StdinBackups {
+		runs = append(runs, stdinBackup.BackupRunStatus)
+	}
+	for _, run := range runs {
+		if run.Result != BackupResultPartiallySucceeded {
+			continue
+		}
+		count += run.ErrorCount
+		for _, path := range run.FailedPaths {
+			if len(paths) < maxPaths {
+				paths = append(paths, path)
+			}
+		}
+	}
+	return count, paths
+}
+
+// BackupResult is the outcome of the backup of a single volume or backup command.
+type BackupResult string
+
+const (
+	// BackupResultSucceeded is given when restic created a snapshot without errors.
+	BackupResultSucceeded BackupResult = "Succeeded"
+	// BackupResultPartiallySucceeded is given when restic created a snapshot, but couldn't read some of the files.
+	BackupResultPartiallySucceeded BackupResult = "PartiallySucceeded"
+	// BackupResultFailed is given when restic failed to create a snapshot.
+	BackupResultFailed BackupResult = "Failed"
+)
+
+// BackupRunStatus contains what restic reported for the backup of a single volume or backup command.
+type BackupRunStatus struct {
+	// JobName is the name of the Job that ran the backup.
+	JobName string `json:"jobName,omitempty"`
+	// NodeName is the name of the node the backup ran on.
+	NodeName string `json:"nodeName,omitempty"`
+	// SnapshotID is the ID of the created snapshot.
+	SnapshotID string `json:"snapshotID,omitempty"`
+	// FilesNew is the number of files that haven't been in the parent snapshot.
+	FilesNew int `json:"filesNew,omitempty"`
+	// FilesChanged is the number of files that changed since the parent snapshot.
+	FilesChanged int `json:"filesChanged,omitempty"`
+	// BytesAdded is the amount of data in bytes that has been added to the repository.
+	BytesAdded int64 `json:"bytesAdded,omitempty"`
+	// Duration is how long the backup took.
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// ErrorCount is the number of errors restic reported, for example files that couldn't be read.
+	ErrorCount int `json:"errorCount,omitempty"`
+	// FailedPaths contains the first paths restic couldn't read.
+	// +optional
+	FailedPaths []string `json:"failedPaths,omitempty"`
+	// Result is the outcome of the backup.
+	Result BackupResult `json:"result,omitempty"`
+	// Message contains the error if the backup failed.
+	Message string `json:"message,omitempty"`
+}
+
+// VolumeBackupStatus is the result of the backup of a single PVC.
+type VolumeBackupStatus struct {
+	// Name is the name of the PVC.
+	Name            string `json:"name"`
+	BackupRunStatus `json:",inline"`
+}
+
+// StdinBackupStatus is the result of the backup command of a single Pod.
+type StdinBackupStatus struct {
+	// PodName is the name of the Pod the backup command ran in.
+	PodName string `json:"podName"`
+	// ContainerName is the name of the container the backup command ran in.
+	ContainerName string `json:"containerName,omitempty"`
+	// FileName is the name of the file the output of the command is stored as in the snapshot.
+	FileName        string `json:"fileName,omitempty"`
+	BackupRunStatus `json:",inline"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Schedule Ref",type="string",JSONPath=`.metadata.ownerReferences[?(@.kind == "Schedule")].name`,description="Reference to Schedule"
@@ -74,8 +178,8 @@ type Backup struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec   BackupSpec `json:"spec,omitempty"`
-	Status Status     `json:"status,omitempty"`
+	Spec   BackupSpec   `json:"spec,omitempty"`
+	Status BackupStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -97,12 +201,12 @@ func (*Backup) GetType() JobType {
 
 // GetStatus retrieves the Status property
 func (b *Backup) GetStatus() Status {
-	return b.Status
+	return b.Status.Status
 }
 
 // SetStatus sets the Status property
 func (b *Backup) SetStatus(status Status) {
-	b.Status = status
+	b.Status.Status = status
 }
 
 // GetResources returns the resource requirements
@@ -0,0 +1,40 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+func TestBackupResults_ReadErrors(t *testing.T) {
+	results := BackupResults{
+		Volumes: []VolumeBackupStatus{
+			{Name: "clean", BackupRunStatus: BackupRunStatus{Result: BackupResultSucceeded}},
+			{Name: "failed", BackupRunStatus: BackupRunStatus{Result: BackupResultFailed, ErrorCount: 5}},
+			{Name: "partial", BackupRunStatus: BackupRunStatus{Result: BackupResultPartiallySucceeded, ErrorCount: 2, FailedPaths: []string{"/data/partial/a", "/data/partial/b"}}},
+		},
+		StdinBackups: []StdinBackupStatus{
+			{PodName: "mariadb", BackupRunStatus: BackupRunStatus{Result: BackupResultPartiallySucceeded, ErrorCount: 1, FailedPaths: []string{"/app-mariadb.sql"}}},
+		},
+	}
+
+	count, paths := results.ReadErrors(2)
+
+	assert.Equal(t, 3, count, "only partially succeeded backups count")
+	assert.Equal(t, []string{"/data/partial/a", "/data/partial/b"}, paths)
+}
+
+func TestStatus_SetPartiallySucceeded(t *testing.T) {
+	status := Status{}
+
+	status.SetPartiallySucceeded("2 files could not be read")
+
+	assert.True(t, status.HasSucceeded())
+	assert.False(t, status.HasFailed())
+	assert.True(t, status.HasFinished())
+	condition := meta.FindStatusCondition(status.Conditions, ConditionPartiallySucceeded.String())
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, "2 files could not be read", condition.Message)
+	}
+}
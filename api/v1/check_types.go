@@ -3,6 +3,8 @@ package v1
 import (
 	"context"
 	"reflect"
+	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -22,6 +24,18 @@ type CheckSpec struct {
 	// +optional
 	ClusterName string `json:"clusterName,omitempty"`
 
+	// ReadData reads all data of the repository to verify it, instead of only checking its structure.
+	// It's mutually exclusive with ReadDataSubset.
+	// +optional
+	ReadData bool `json:"readData,omitempty"`
+	// ReadDataSubset reads a subset of the data of the repository to verify it.
+	// It's either the n-th of t parts of the pack files ("1/7"), a percentage of them ("10%") or an amount of data ("500M").
+	// +optional
+	ReadDataSubset string `json:"readDataSubset,omitempty"`
+	// WithCache uses the cache of restic instead of a temporary one.
+	// +optional
+	WithCache bool `json:"withCache,omitempty"`
+
 	// KeepJobs amount of jobs to keep for later analysis.
 	//
 	// Deprecated: Use FailedJobsHistoryLimit and SuccessfulJobsHistoryLimit respectively.
@@ -37,9 +51,50 @@ type CheckSpec struct {
 	SuccessfulJobsHistoryLimit *int `json:"successfulJobsHistoryLimit,omitempty"`
 }
 
+// ReadDataPart returns n and t of a ReadDataSubset of the form "n/t", which reads the n-th of t parts of the pack files.
+// ok is false if the subset has another form.
+func ReadDataPart(subset string) (n, t int, ok bool) {
+	nPart, tPart, found := strings.Cut(subset, "/")
+	if !found {
+		return 0, 0, false
+	}
+	n, nErr := strconv.Atoi(nPart)
+	t, tErr := strconv.Atoi(tPart)
+	return n, t, nErr == nil && tErr == nil
+}
+
+// CheckStatus defines the observed state of Check
+type CheckStatus struct {
+	Status       `json:",inline"`
+	CheckResults `json:",inline"`
+}
+
+// CheckResults contains the results of the check, as restic reported them.
+// The job's container reports them in its termination message.
+type CheckResults struct {
+	// Successful is true if restic found no errors in the repository, and false if it found errors or couldn't check the repository.
+	// +optional
+	Successful *bool `json:"successful,omitempty"`
+	// ErrorCount is the number of errors that restic reported.
+	// +optional
+	ErrorCount int `json:"errorCount,omitempty"`
+	// DamagedPacks are the IDs of the pack files that contain errors, at most 20 are listed.
+	// +optional
+	DamagedPacks []string `json:"damagedPacks,omitempty"`
+	// UnreferencedPacks is the number of pack files that no index refers to.
+	// They aren't errors, the next prune removes them.
+	// +optional
+	UnreferencedPacks int `json:"unreferencedPacks,omitempty"`
+	// FailureReason is the cause of a failed check, if it's known: RepositoryDamaged, RepositoryLocked or AuthenticationFailed.
+	// It's used as the reason of the Completed condition.
+	// +optional
+	FailureReason ConditionReason `json:"failureReason,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Schedule Ref",type="string",JSONPath=`.metadata.ownerReferences[?(@.kind == "Schedule")].name`,description="Reference to Schedule"
+// +kubebuilder:printcolumn:name="Errors",type="integer",JSONPath=`.status.errorCount`,description="Number of errors found"
 // +kubebuilder:printcolumn:name="Completion",type="string",JSONPath=`.status.conditions[?(@.type == "Completed")].reason`,description="Status of Completion"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
@@ -48,8 +103,8 @@ type Check struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec   CheckSpec `json:"spec,omitempty"`
-	Status Status    `json:"status,omitempty"`
+	Spec   CheckSpec   `json:"spec,omitempty"`
+	Status CheckStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -71,12 +126,12 @@ func (c *Check) GetType() JobType {
 
 // GetStatus retrieves the Status property
 func (c *Check) GetStatus() Status {
-	return c.Status
+	return c.Status.Status
 }
 
 // SetStatus sets the Status property
 func (c *Check) SetStatus(status Status) {
-	c.Status = status
+	c.Status.Status = status
 }
 
 // GetResources returns the resource requirements
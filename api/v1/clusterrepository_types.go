@@ -0,0 +1,80 @@
+package v1
+
+import (
+	"reflect"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ClusterRepositorySpec defines a default backend for the jobs of all namespaces it selects.
+type ClusterRepositorySpec struct {
+	// Backend is used by jobs that don't configure a backend or repositoryRef themselves.
+	// If a job configures a backend of the same type, the fields it leaves empty are taken from this backend.
+	// The Secrets referenced by the backend have to be in the namespace of the operator, they are copied into a Secret of the job object
+	// that exists until the job has finished.
+	Backend *Backend `json:"backend"`
+
+	// RestoreS3 is the default S3 target of restores with restoreMethod s3.
+	// The Secrets it references have to be in the namespace of the operator, they are copied into the namespace of the restore.
+	// +optional
+	RestoreS3 *S3Spec `json:"restoreS3,omitempty"`
+
+	// ResticOptions are passed to restic for every job that uses this repository, in the form 'key=value'.
+	// See https://restic.readthedocs.io/en/stable/manual_rest.html?highlight=--option#usage-help
+	// +optional
+	ResticOptions []string `json:"resticOptions,omitempty"`
+
+	// NamespaceSelector selects the namespaces this repository applies to.
+	// A ClusterRepository without selector applies to all namespaces that aren't selected by another ClusterRepository.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ClusterRepository is the Schema for the clusterrepositories API
+type ClusterRepository struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterRepositorySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterRepositoryList contains a list of ClusterRepository
+type ClusterRepositoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterRepository `json:"items"`
+}
+
+var (
+	ClusterRepositoryKind = reflect.TypeOf(ClusterRepository{}).Name()
+)
+
+func init() {
+	SchemeBuilder.Register(&ClusterRepository{}, &ClusterRepositoryList{})
+}
+
+// GetResticOptions returns the restic options in the format 'key=value,key2=value2'.
+func (r *ClusterRepository) GetResticOptions() string {
+	return strings.Join(r.Spec.ResticOptions, ",")
+}
+
+// SelectsNamespace returns true if the repository applies to a namespace with the given labels.
+// It also returns true if the repository has no NamespaceSelector.
+func (r *ClusterRepository) SelectsNamespace(namespaceLabels map[string]string) (bool, error) {
+	if r.Spec.NamespaceSelector == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(r.Spec.NamespaceSelector)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(labels.Set(namespaceLabels)), nil
+}
@@ -36,6 +36,14 @@ const (
 	RestoreType  JobType = "restore"
 	PruneType    JobType = "prune"
 	ScheduleType JobType = "schedule"
+	// RepositoryType is the type of the jobs that initialize a Repository.
+	RepositoryType JobType = "repository"
+	// RepositoryKeyType is the type of the jobs that rotate the password of a repository.
+	RepositoryKeyType JobType = "repositorykey"
+	// CopyType is the type of the jobs that copy snapshots into another repository.
+	CopyType JobType = "copy"
+	// MaintenanceType is the type of the jobs that repair or upgrade a repository.
+	MaintenanceType JobType = "maintenance"
 
 	// ConditionCompleted is given when the resource has completed its main function.
 	ConditionCompleted ConditionType = "Completed"
@@ -47,6 +55,8 @@ const (
 	ConditionProgressing ConditionType = "Progressing"
 	// ConditionPreBackupPodReady is True if Deployments for all Container definitions were created and are ready
 	ConditionPreBackupPodReady ConditionType = "PreBackupPodReady"
+	// ConditionPartiallySucceeded is True if a backup created its snapshots, but couldn't read some of the files.
+	ConditionPartiallySucceeded ConditionType = "PartiallySucceeded"
 
 	// ReasonReady indicates the condition is ready for work
 	ReasonReady ConditionReason = "Ready"
@@ -56,6 +66,8 @@ const (
 	ReasonFinished ConditionReason = "Finished"
 	// ReasonSucceeded indicates the condition is succeeded
 	ReasonSucceeded ConditionReason = "Succeeded"
+	// ReasonPartiallySucceeded indicates the job succeeded, but couldn't process everything, for example unreadable files
+	ReasonPartiallySucceeded ConditionReason = "PartiallySucceeded"
 	// ReasonFailed indicates there was a general failure not further categorized
 	ReasonFailed ConditionReason = "Failed"
 	// ReasonCreationFailed indicates that a dependent resource could not be created
@@ -71,6 +83,18 @@ const (
 	ReasonNoPreBackupPodsFound ConditionReason = "NoPreBackupPodsFound"
 	// ReasonWaiting is given when PreBackupPods are waiting to be started
 	ReasonWaiting ConditionReason = "Waiting"
+	// ReasonRepositoryNotInitialized is given when a job waits for the Repository it refers to to be initialized
+	ReasonRepositoryNotInitialized ConditionReason = "RepositoryNotInitialized"
+	// ReasonSnapshotNotFound is given when a restore refers to a Snapshot that doesn't exist
+	ReasonSnapshotNotFound ConditionReason = "SnapshotNotFound"
+	// ReasonSnapshotRepositoryMismatch is given when a restore refers to a Snapshot of another repository than the one it uses
+	ReasonSnapshotRepositoryMismatch ConditionReason = "SnapshotRepositoryMismatch"
+	// ReasonRepositoryDamaged is given when a check found errors in the repository
+	ReasonRepositoryDamaged ConditionReason = "RepositoryDamaged"
+	// ReasonRepositoryLocked is given when a job couldn't lock the repository, as another process holds a lock
+	ReasonRepositoryLocked ConditionReason = "RepositoryLocked"
+	// ReasonAuthenticationFailed is given when a job couldn't open the repository with its password or credentials
+	ReasonAuthenticationFailed ConditionReason = "AuthenticationFailed"
 
 	// LabelK8upType is the label key that identifies the job type
 	LabelK8upType = "k8up.io/type"
@@ -85,6 +109,14 @@ const (
 	LabelManagedBy = "app.kubernetes.io/managed-by"
 	// LabelRepositoryHash is the label key that identifies the Restic repository
 	LabelRepositoryHash = "k8up.io/repository-hash"
+	// LabelDestinationRepositoryHash is the label key that identifies the Restic repository a copy job writes into
+	LabelDestinationRepositoryHash = "k8up.io/destination-repository-hash"
+	// LabelBackupName is the label key that records which Backup produced a Snapshot.
+	LabelBackupName = "k8up.io/backup-name"
+	// LabelSnapshotSource is the label key that records the PVC or file name a Snapshot was taken from.
+	LabelSnapshotSource = "k8up.io/snapshot-source"
+	// LabelSnapshotHostname is the label key that records the hostname restic recorded for a Snapshot.
+	LabelSnapshotHostname = "k8up.io/snapshot-hostname"
 
 	// AnnotationK8upHostname is an annotation one can set on RWO PVCs to try to back up them on the specified node.
 	AnnotationK8upHostname = "k8up.io/hostname"
@@ -0,0 +1,215 @@
+package v1
+
+import (
+	"context"
+	"maps"
+	"reflect"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/k8up-io/k8up/v2/operator/cfg"
+)
+
+// CopySpec defines the copy of snapshots from one restic repository into another, e.g. to keep an off-site copy.
+// The repository of the job, given by backend, repositoryRef or the ClusterRepository of the namespace, is the source.
+// Snapshots that have been copied before aren't copied again.
+type CopySpec struct {
+	RunnableSpec `json:",inline"`
+
+	// Destination is the repository the snapshots are copied into.
+	// If it doesn't exist yet, it's initialized with the chunker parameters of the source, so that the copied data is deduplicated.
+	// The Snapshot resources of the destination are synchronized after the copy.
+	Destination *Backend `json:"destination"`
+
+	// Tags copies only the snapshots that have one of the given tags.
+	// An entry with comma-separated tags matches snapshots that have all of them.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+	// Hosts copies only the snapshots of the given hosts, which are the namespaces of the backups.
+	// The snapshots of all hosts are copied if it's empty.
+	// +optional
+	Hosts []string `json:"hosts,omitempty"`
+	// Paths copies only the snapshots that contain all of the given paths.
+	// +optional
+	Paths []string `json:"paths,omitempty"`
+
+	// FailedJobsHistoryLimit amount of failed jobs to keep for later analysis.
+	// +optional
+	FailedJobsHistoryLimit *int `json:"failedJobsHistoryLimit,omitempty"`
+	// SuccessfulJobsHistoryLimit amount of successful jobs to keep for later analysis.
+	// +optional
+	SuccessfulJobsHistoryLimit *int `json:"successfulJobsHistoryLimit,omitempty"`
+}
+
+// CopyStatus defines the observed state of Copy
+type CopyStatus struct {
+	Status      `json:",inline"`
+	CopyResults `json:",inline"`
+}
+
+// CopyResults contains the results of the copy.
+// The job's container reports them in its termination message.
+type CopyResults struct {
+	// SnapshotsCopied is the number of snapshots that were copied into the destination.
+	// +optional
+	SnapshotsCopied *int `json:"snapshotsCopied,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Schedule Ref",type="string",JSONPath=`.metadata.ownerReferences[?(@.kind == "Schedule")].name`,description="Reference to Schedule"
+// +kubebuilder:printcolumn:name="Copied",type="integer",JSONPath=`.status.snapshotsCopied`,description="Number of copied snapshots"
+// +kubebuilder:printcolumn:name="Completion",type="string",JSONPath=`.status.conditions[?(@.type == "Completed")].reason`,description="Status of Completion"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Copy is the Schema for the copies API.
+// It copies snapshots from one restic repository into another.
+type Copy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CopySpec   `json:"spec,omitempty"`
+	Status CopyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CopyList contains a list of Copy
+type CopyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Copy `json:"items"`
+}
+
+var (
+	CopyKind = reflect.TypeOf(Copy{}).Name()
+)
+
+func init() {
+	SchemeBuilder.Register(&Copy{}, &CopyList{})
+}
+
+// GetType implements JobObject.
+func (*Copy) GetType() JobType {
+	return CopyType
+}
+
+// GetStatus retrieves the Status property
+func (c *Copy) GetStatus() Status {
+	return c.Status.Status
+}
+
+// SetStatus sets the Status property
+func (c *Copy) SetStatus(status Status) {
+	c.Status.Status = status
+}
+
+// GetResources returns the resource requirements
+func (c *Copy) GetResources() corev1.ResourceRequirements {
+	return c.Spec.Resources
+}
+
+// GetPodSecurityContext returns the pod security context
+func (c *Copy) GetPodSecurityContext() *corev1.PodSecurityContext {
+	return c.Spec.PodSecurityContext
+}
+
+// GetActiveDeadlineSeconds implements JobObject
+func (c *Copy) GetActiveDeadlineSeconds() *int64 {
+	return c.Spec.ActiveDeadlineSeconds
+}
+
+// GetPodConfig implements JobObject
+func (c *Copy) GetPodConfig(ctx context.Context, kube client.Client) (*PodConfig, error) {
+	if c.Spec.PodConfigRef == nil {
+		return nil, nil
+	}
+	return NewPodConfig(ctx, c.Spec.PodConfigRef.Name, c.GetNamespace(), kube)
+}
+
+// GetFailedJobsHistoryLimit returns failed jobs history limit.
+func (c *Copy) GetFailedJobsHistoryLimit() *int {
+	return c.Spec.FailedJobsHistoryLimit
+}
+
+// GetSuccessfulJobsHistoryLimit returns successful jobs history limit.
+func (c *Copy) GetSuccessfulJobsHistoryLimit() *int {
+	return c.Spec.SuccessfulJobsHistoryLimit
+}
+
+// GetJobObjects returns a sortable list of jobs
+func (c *CopyList) GetJobObjects() JobObjectList {
+	items := make(JobObjectList, len(c.Items))
+	for i := range c.Items {
+		items[i] = &c.Items[i]
+	}
+	return items
+}
+
+// GetDeepCopy returns a deep copy
+func (in *CopySchedule) GetDeepCopy() ScheduleSpecInterface {
+	return in.DeepCopy()
+}
+
+// GetRunnableSpec returns a pointer to RunnableSpec
+func (in *CopySchedule) GetRunnableSpec() *RunnableSpec {
+	return &in.RunnableSpec
+}
+
+// GetSchedule returns the schedule definition
+func (in *CopySchedule) GetSchedule() ScheduleDefinition {
+	return in.Schedule
+}
+
+// CopyCredentialConflicts returns the environment variables that the credentials of both the source and the destination
+// set to different values, sorted by name.
+// restic reads the credentials of both repositories from the same variables, e.g. AWS_ACCESS_KEY_ID, so they have to be the same.
+// The repository password isn't included, the password of the source is passed by its own variable.
+func CopyCredentialConflicts(source, destination *Backend) []string {
+	if source == nil || destination == nil {
+		return nil
+	}
+	sourceEnv := source.GetCredentialEnv()
+	destinationEnv := destination.GetCredentialEnv()
+	var conflicts []string
+	for _, name := range slices.Sorted(maps.Keys(destinationEnv)) {
+		sourceValue, ok := sourceEnv[name]
+		if !ok || name == cfg.ResticPasswordEnvName {
+			continue
+		}
+		if !reflect.DeepEqual(sourceValue, destinationEnv[name]) {
+			conflicts = append(conflicts, name)
+		}
+	}
+	return conflicts
+}
+
+// UnsupportedCopySettings returns the settings of the backend that aren't supported for the source of a copy.
+// restic applies them to the repository it writes into only, or the job can't give them to both repositories.
+func (in *Backend) UnsupportedCopySettings() []string {
+	if in == nil {
+		return nil
+	}
+	var unsupported []string
+	for _, setting := range []struct {
+		name       string
+		configured bool
+	}{
+		{"repoPasswordCommand", in.RepoPasswordCommand != nil},
+		{"sftp", in.SFTP != nil},
+		{"rclone", in.Rclone != nil},
+		{"s3.roleARN", in.S3 != nil && in.S3.RoleARN != ""},
+		{"gcs.credentialsSecretRef", in.GCS != nil && in.GCS.CredentialsSecretRef != nil},
+		{"azure.managedIdentityClientID", in.Azure != nil && in.Azure.ManagedIdentityClientID != ""},
+		{"tlsOptions", in.TLSOptions != nil},
+		{"serviceAccount", in.ServiceAccount != nil},
+	} {
+		if setting.configured {
+			unsupported = append(unsupported, setting.name)
+		}
+	}
+	return unsupported
+}
@@ -0,0 +1,114 @@
+package v1
+
+import (
+	"dario.cat/mergo"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// CredentialOperation is a group of jobs that can get their own credentials.
+type CredentialOperation string
+
+const (
+	// CredentialOperationBackup are backup, restore, archive and copy jobs.
+	CredentialOperationBackup CredentialOperation = "backup"
+	// CredentialOperationMaintenance are prune, check and maintenance jobs and the initialization of Repositories.
+	CredentialOperationMaintenance CredentialOperation = "maintenance"
+)
+
+// OperationCredentials configures credentials that replace the ones of the backend for some kinds of jobs.
+// This allows giving backup jobs credentials that can't delete data, e.g. of an append-only rest-server,
+// while only prune, check and maintenance jobs get the credentials to delete data.
+type OperationCredentials struct {
+	// Backup replaces the credentials of backup, restore, archive and copy jobs.
+	// Copy jobs use them for both the source and the destination.
+	// +optional
+	Backup *CredentialSet `json:"backup,omitempty"`
+	// Maintenance replaces the credentials of prune, check and maintenance jobs and of the initialization of Repositories.
+	// +optional
+	Maintenance *CredentialSet `json:"maintenance,omitempty"`
+}
+
+// CredentialSet holds the credentials that replace the ones of a backend.
+// The fields that are set in the storage type the backend uses replace those of the backend.
+// They may only configure credentials, the location of the repository has to stay the same.
+type CredentialSet struct {
+	// RepoPasswordSecretRef references the restic repository password, e.g. of a separate key of the repository.
+	// +optional
+	RepoPasswordSecretRef *corev1.SecretKeySelector `json:"repoPasswordSecretRef,omitempty"`
+	// EnvFrom replaces the environment variables that the backend adds from external sources.
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+
+	S3     *S3Spec         `json:"s3,omitempty"`
+	GCS    *GCSSpec        `json:"gcs,omitempty"`
+	Azure  *AzureSpec      `json:"azure,omitempty"`
+	Swift  *SwiftSpec      `json:"swift,omitempty"`
+	B2     *B2Spec         `json:"b2,omitempty"`
+	Rest   *RestServerSpec `json:"rest,omitempty"`
+	SFTP   *SFTPSpec       `json:"sftp,omitempty"`
+	Rclone *RcloneSpec     `json:"rclone,omitempty"`
+}
+
+// For returns the credentials of the given operation, or nil if there are none.
+func (in *OperationCredentials) For(operation CredentialOperation) *CredentialSet {
+	if in == nil {
+		return nil
+	}
+	switch operation {
+	case CredentialOperationBackup:
+		return in.Backup
+	case CredentialOperationMaintenance:
+		return in.Maintenance
+	}
+	return nil
+}
+
+// WithCredentialsFor returns a copy of the backend that uses the credentials configured for the given operation.
+// It returns the backend itself if there are no credentials for the operation.
+func (in *Backend) WithCredentialsFor(operation CredentialOperation) *Backend {
+	if in.credentialsFor(operation) == nil {
+		return in
+	}
+	backend := in.DeepCopy()
+	set := backend.credentialsFor(operation)
+	if set.RepoPasswordSecretRef != nil {
+		backend.RepoPasswordSecretRef = set.RepoPasswordSecretRef.DeepCopy()
+		// restic prefers the password file and command over the password in the environment.
+		backend.RepoPasswordFileSecretRef = nil
+		backend.RepoPasswordCommand = nil
+	}
+	if len(set.EnvFrom) > 0 {
+		backend.EnvFrom = set.EnvFrom
+	}
+	backend.S3 = overrideCredentials(backend.S3, set.S3)
+	backend.GCS = overrideCredentials(backend.GCS, set.GCS)
+	backend.Azure = overrideCredentials(backend.Azure, set.Azure)
+	backend.Swift = overrideCredentials(backend.Swift, set.Swift)
+	backend.B2 = overrideCredentials(backend.B2, set.B2)
+	if set.Rest != nil && set.Rest.PasswordSecretRef == nil {
+		// The deprecated field of the credentials has to replace the password of the backend as well.
+		set.Rest.PasswordSecretRef, set.Rest.PasswordSecretReg = set.Rest.PasswordSecretReg, nil
+	}
+	backend.Rest = overrideCredentials(backend.Rest, set.Rest)
+	backend.SFTP = overrideCredentials(backend.SFTP, set.SFTP)
+	backend.Rclone = overrideCredentials(backend.Rclone, set.Rclone)
+	return backend
+}
+
+func (in *Backend) credentialsFor(operation CredentialOperation) *CredentialSet {
+	if in == nil {
+		return nil
+	}
+	return in.Credentials.For(operation)
+}
+
+// overrideCredentials returns the given spec with the fields that are set in the override.
+// It returns the spec unchanged if either is nil.
+func overrideCredentials[T any](spec, override *T) *T {
+	if spec == nil || override == nil {
+		return spec
+	}
+	// Merging two values of the same struct type never returns an error.
+	_ = mergo.Merge(spec, override, mergo.WithOverride)
+	return spec
+}
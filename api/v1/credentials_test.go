@@ -0,0 +1,65 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/k8up-io/k8up/v2/operator/cfg"
+)
+
+func TestBackend_WithCredentialsFor(t *testing.T) {
+	backend := &Backend{
+		RepoPasswordSecretRef: newSecretRef("password"),
+		Rest: &RestServerSpec{
+			URL:               "https://rest.example.com/app",
+			UserSecretRef:     newSecretRef("admin-user"),
+			PasswordSecretRef: newSecretRef("admin-password"),
+		},
+		Credentials: &OperationCredentials{
+			Backup: &CredentialSet{
+				Rest: &RestServerSpec{
+					UserSecretRef:     newSecretRef("append-user"),
+					PasswordSecretReg: newSecretRef("append-password"),
+				},
+			},
+		},
+	}
+
+	t.Run("GivenBackupCredentials_ThenExpectThemForBackups", func(t *testing.T) {
+		result := backend.WithCredentialsFor(CredentialOperationBackup)
+		assert.Equal(t, backend.String(), result.String())
+		assert.Equal(t, map[string]*corev1.EnvVarSource{
+			cfg.ResticPasswordEnvName: {SecretKeyRef: newSecretRef("password")},
+			cfg.RestUserEnvName:       {SecretKeyRef: newSecretRef("append-user")},
+			cfg.RestPasswordEnvName:   {SecretKeyRef: newSecretRef("append-password")},
+		}, result.GetCredentialEnv())
+		assert.Equal(t, "admin-user", backend.Rest.UserSecretRef.Name, "the backend isn't changed")
+	})
+	t.Run("GivenNoMaintenanceCredentials_ThenExpectBackendCredentials", func(t *testing.T) {
+		assert.Same(t, backend, backend.WithCredentialsFor(CredentialOperationMaintenance))
+	})
+	t.Run("GivenNilBackend_ThenExpectNil", func(t *testing.T) {
+		var nilBackend *Backend
+		assert.Nil(t, nilBackend.WithCredentialsFor(CredentialOperationBackup))
+	})
+}
+
+func TestBackend_WithCredentialsFor_RepoPassword(t *testing.T) {
+	backend := &Backend{
+		RepoPasswordSecretRef: newSecretRef("password"),
+		S3:                    &S3Spec{Endpoint: "https://s3.example.com", Bucket: "app", AccessKeyIDSecretRef: newSecretRef("id")},
+		Credentials: &OperationCredentials{
+			Maintenance: &CredentialSet{
+				RepoPasswordSecretRef: newSecretRef("maintenance-password"),
+				S3:                    &S3Spec{SecretAccessKeySecretRef: newSecretRef("maintenance-secret")},
+			},
+		},
+	}
+
+	result := backend.WithCredentialsFor(CredentialOperationMaintenance)
+	assert.Equal(t, newSecretRef("maintenance-password"), result.RepoPasswordSecretRef)
+	assert.Equal(t, newSecretRef("id"), result.S3.AccessKeyIDSecretRef, "fields that aren't set are kept")
+	assert.Equal(t, newSecretRef("maintenance-secret"), result.S3.SecretAccessKeySecretRef)
+}
@@ -0,0 +1,169 @@
+package v1
+
+import (
+	"context"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MaintenanceOperation is a restic command that repairs or upgrades a repository.
+// +kubebuilder:validation:Enum=rebuild-index;repair-index;repair-snapshots;repair-packs;migrate-upgrade-repo-v2;unlock-remove-all
+type MaintenanceOperation string
+
+const (
+	// MaintenanceRebuildIndex rebuilds the index of the repository.
+	// It's the former name of MaintenanceRepairIndex, which restic has deprecated, and runs 'restic repair index'.
+	MaintenanceRebuildIndex MaintenanceOperation = "rebuild-index"
+	// MaintenanceRepairIndex rebuilds the index of the repository from its pack files with 'restic repair index'.
+	MaintenanceRepairIndex MaintenanceOperation = "repair-index"
+	// MaintenanceRepairSnapshots replaces the snapshots that refer to missing data with repaired snapshots with 'restic repair snapshots'.
+	MaintenanceRepairSnapshots MaintenanceOperation = "repair-snapshots"
+	// MaintenanceRepairPacks salvages the readable blobs of damaged pack files with 'restic repair packs'.
+	MaintenanceRepairPacks MaintenanceOperation = "repair-packs"
+	// MaintenanceUpgradeRepoV2 upgrades the repository to format version 2 with 'restic migrate upgrade_repo_v2'.
+	MaintenanceUpgradeRepoV2 MaintenanceOperation = "migrate-upgrade-repo-v2"
+	// MaintenanceUnlockRemoveAll removes all locks of the repository, including those of running processes, with 'restic unlock --remove-all'.
+	MaintenanceUnlockRemoveAll MaintenanceOperation = "unlock-remove-all"
+)
+
+// IsIndexRepair returns true if the operation rebuilds the index of the repository.
+func (o MaintenanceOperation) IsIndexRepair() bool {
+	return o == MaintenanceRebuildIndex || o == MaintenanceRepairIndex
+}
+
+// MaintenanceSpec defines an operation that repairs or upgrades a restic repository,
+// e.g. after an interrupted prune or after a Check reported errors.
+// The job doesn't run while other jobs use the repository, and no other jobs are started while it's running.
+type MaintenanceSpec struct {
+	RunnableSpec `json:",inline"`
+
+	// Operation is the restic command that is run on the repository.
+	Operation MaintenanceOperation `json:"operation"`
+
+	// ReadAllPacks reads all pack files to rebuild the index instead of only those that aren't indexed yet.
+	// It applies to the rebuild-index and repair-index operations.
+	// +optional
+	ReadAllPacks bool `json:"readAllPacks,omitempty"`
+	// Forget removes the original snapshots once they're replaced by repaired snapshots.
+	// It applies to the repair-snapshots operation.
+	// +optional
+	Forget bool `json:"forget,omitempty"`
+	// Packs are the IDs of the damaged pack files, as reported by a Check.
+	// They're required for the repair-packs operation.
+	// +optional
+	Packs []string `json:"packs,omitempty"`
+
+	// FailedJobsHistoryLimit amount of failed jobs to keep for later analysis.
+	// +optional
+	FailedJobsHistoryLimit *int `json:"failedJobsHistoryLimit,omitempty"`
+	// SuccessfulJobsHistoryLimit amount of successful jobs to keep for later analysis.
+	// +optional
+	SuccessfulJobsHistoryLimit *int `json:"successfulJobsHistoryLimit,omitempty"`
+}
+
+// MaintenanceStatus defines the observed state of Maintenance
+type MaintenanceStatus struct {
+	Status             `json:",inline"`
+	MaintenanceResults `json:",inline"`
+}
+
+// MaintenanceResults contains the results of the maintenance operation.
+// The job's container reports them in its termination message.
+type MaintenanceResults struct {
+	// Summary contains the last lines that restic printed, which summarize what it has changed in the repository.
+	// +optional
+	Summary string `json:"summary,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Operation",type="string",JSONPath=`.spec.operation`,description="Maintenance operation"
+// +kubebuilder:printcolumn:name="Completion",type="string",JSONPath=`.status.conditions[?(@.type == "Completed")].reason`,description="Status of Completion"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Maintenance is the Schema for the maintenances API.
+// It repairs or upgrades a restic repository.
+type Maintenance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MaintenanceSpec   `json:"spec,omitempty"`
+	Status MaintenanceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MaintenanceList contains a list of Maintenance
+type MaintenanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Maintenance `json:"items"`
+}
+
+var (
+	MaintenanceKind = reflect.TypeOf(Maintenance{}).Name()
+)
+
+func init() {
+	SchemeBuilder.Register(&Maintenance{}, &MaintenanceList{})
+}
+
+// GetType implements JobObject.
+func (*Maintenance) GetType() JobType {
+	return MaintenanceType
+}
+
+// GetStatus retrieves the Status property
+func (m *Maintenance) GetStatus() Status {
+	return m.Status.Status
+}
+
+// SetStatus sets the Status property
+func (m *Maintenance) SetStatus(status Status) {
+	m.Status.Status = status
+}
+
+// GetResources returns the resource requirements
+func (m *Maintenance) GetResources() corev1.ResourceRequirements {
+	return m.Spec.Resources
+}
+
+// GetPodSecurityContext returns the pod security context
+func (m *Maintenance) GetPodSecurityContext() *corev1.PodSecurityContext {
+	return m.Spec.PodSecurityContext
+}
+
+// GetActiveDeadlineSeconds implements JobObject
+func (m *Maintenance) GetActiveDeadlineSeconds() *int64 {
+	return m.Spec.ActiveDeadlineSeconds
+}
+
+// GetPodConfig implements JobObject
+func (m *Maintenance) GetPodConfig(ctx context.Context, c client.Client) (*PodConfig, error) {
+	if m.Spec.PodConfigRef == nil {
+		return nil, nil
+	}
+	return NewPodConfig(ctx, m.Spec.PodConfigRef.Name, m.GetNamespace(), c)
+}
+
+// GetFailedJobsHistoryLimit returns failed jobs history limit.
+func (m *Maintenance) GetFailedJobsHistoryLimit() *int {
+	return m.Spec.FailedJobsHistoryLimit
+}
+
+// GetSuccessfulJobsHistoryLimit returns successful jobs history limit.
+func (m *Maintenance) GetSuccessfulJobsHistoryLimit() *int {
+	return m.Spec.SuccessfulJobsHistoryLimit
+}
+
+// GetJobObjects returns a sortable list of jobs
+func (m *MaintenanceList) GetJobObjects() JobObjectList {
+	items := make(JobObjectList, len(m.Items))
+	for i := range m.Items {
+		items[i] = &m.Items[i]
+	}
+	return items
+}
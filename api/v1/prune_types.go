@@ -3,6 +3,9 @@ package v1
 import (
 	"context"
 	"reflect"
+	"regexp"
+	"slices"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,6 +34,31 @@ type PruneSpec struct {
 	SuccessfulJobsHistoryLimit *int `json:"successfulJobsHistoryLimit,omitempty"`
 }
 
+// DefaultKeepDaily is the amount of daily snapshots that are kept if a RetentionPolicy doesn't set KeepDaily.
+const DefaultKeepDaily = 14
+
+var (
+	// KeepWithinPattern matches the durations restic accepts for the keepWithin arguments, e.g. '2y5m7d3h'.
+	KeepWithinPattern = regexp.MustCompile(`^(?:\d+[ymdh])+$`)
+
+	// GroupByOptions are the values restic accepts to group snapshots by before the retention is applied.
+	GroupByOptions = []string{"host", "paths", "tags"}
+)
+
+// UnknownGroupByOptions returns the options of the given comma separated groupBy list that aren't in GroupByOptions.
+func UnknownGroupByOptions(groupBy string) []string {
+	if groupBy == "" {
+		return nil
+	}
+	var unknown []string
+	for _, option := range strings.Split(groupBy, ",") {
+		if !slices.Contains(GroupByOptions, option) {
+			unknown = append(unknown, option)
+		}
+	}
+	return unknown
+}
+
 type RetentionPolicy struct {
 	KeepLast    int      `json:"keepLast,omitempty"`
 	KeepHourly  int      `json:"keepHourly,omitempty"`
@@ -39,11 +67,31 @@ type RetentionPolicy struct {
 	KeepMonthly int      `json:"keepMonthly,omitempty"`
 	KeepYearly  int      `json:"keepYearly,omitempty"`
 	KeepTags    []string `json:"keepTags,omitempty"`
+	// KeepWithin keeps all snapshots within the given duration of the latest snapshot, e.g. '2y5m7d3h'.
+	// The duration consists of years (y), months (m), days (d) and hours (h).
+	KeepWithin string `json:"keepWithin,omitempty"`
+	// KeepWithinHourly keeps the last snapshot of each hour within the given duration, e.g. '7d'.
+	KeepWithinHourly string `json:"keepWithinHourly,omitempty"`
+	// KeepWithinDaily keeps the last snapshot of each day within the given duration, e.g. '1m'.
+	KeepWithinDaily string `json:"keepWithinDaily,omitempty"`
+	// KeepWithinWeekly keeps the last snapshot of each week within the given duration, e.g. '6m'.
+	KeepWithinWeekly string `json:"keepWithinWeekly,omitempty"`
+	// KeepWithinMonthly keeps the last snapshot of each month within the given duration, e.g. '2y'.
+	KeepWithinMonthly string `json:"keepWithinMonthly,omitempty"`
+	// KeepWithinYearly keeps the last snapshot of each year within the given duration, e.g. '10y'.
+	KeepWithinYearly string `json:"keepWithinYearly,omitempty"`
 	// Tags is a filter on what tags the policy should be applied
 	// DO NOT CONFUSE THIS WITH KeepTags OR YOU'LL have a bad time
 	Tags []string `json:"tags,omitempty"`
-	// Hostnames is a filter on what hostnames the policy should be applied
+	// Hostnames is a filter on what hostnames the policy should be applied.
+	// The hostname of a snapshot is the namespace of its backup.
+	// If it's empty, the policy is applied to the snapshots of the Prune's namespace.
+	// In a repository that is shared with other namespaces, like that of a ClusterRepository, only the Prune's namespace may be given.
 	Hostnames []string `json:"hostnames,omitempty"`
+	// GroupBy sets how the snapshots are grouped before the policy is applied to each group,
+	// a comma separated list of 'host', 'paths' and 'tags'.
+	// restic groups the snapshots by host and paths by default.
+	GroupBy string `json:"groupBy,omitempty"`
 }
 
 // +kubebuilder:object:root=true
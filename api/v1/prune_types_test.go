@@ -0,0 +1,13 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnknownGroupByOptions(t *testing.T) {
+	assert.Empty(t, UnknownGroupByOptions(""))
+	assert.Empty(t, UnknownGroupByOptions("host,paths,tags"))
+	assert.Equal(t, []string{"hosts", ""}, UnknownGroupByOptions("hosts,tags,"))
+}
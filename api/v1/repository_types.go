@@ -0,0 +1,157 @@
+package v1
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/k8up-io/k8up/v2/operator/cfg"
+)
+
+// RepositorySpec defines a restic repository that jobs can refer to by name instead of configuring the backend themselves.
+type RepositorySpec struct {
+	// Backend contains the restic repository, its password and TLS options.
+	Backend *Backend `json:"backend"`
+
+	// ResticOptions are passed to restic for every job that uses this repository, in the form 'key=value'.
+	// They take precedence over the restic options of the operator.
+	// See https://restic.readthedocs.io/en/stable/manual_rest.html?highlight=--option#usage-help
+	// +optional
+	ResticOptions []string `json:"resticOptions,omitempty"`
+
+	// Volumes lists the volumes that are referred to by the backend's VolumeMounts, e.g. Secrets containing TLS certificates.
+	// They are added to every job that uses this repository.
+	// +optional
+	Volumes *[]RunnableVolumeSpec `json:"volumes,omitempty"`
+}
+
+// RepositoryStatus defines the observed state of Repository
+type RepositoryStatus struct {
+	// Initialized is true once the operator has initialized the restic repository.
+	// Jobs referring to the repository are delayed until it's initialized.
+	Initialized bool `json:"initialized,omitempty"`
+	// InitializedRepository is the restic repository the operator has initialized, e.g. 's3:https://s3.example.com/bucket'.
+	// The repository is initialized again if the backend changes to another repository.
+	InitializedRepository string `json:"initializedRepository,omitempty"`
+	// LastCheckTime is the completion time of the last successful Check that referred to the repository.
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+	// SnapshotCount is the number of Snapshots in the namespace that belong to the repository.
+	SnapshotCount int `json:"snapshotCount,omitempty"`
+
+	// Conditions provide a standard mechanism for higher-level status reporting from a controller.
+	// They are an extension mechanism which allows tools and other controllers to collect summary information about
+	// resources without needing to understand resource-specific status details.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Initialized",type="boolean",JSONPath=`.status.initialized`,description="Whether the repository has been initialized"
+// +kubebuilder:printcolumn:name="Snapshots",type="integer",JSONPath=`.status.snapshotCount`,description="Number of snapshots"
+// +kubebuilder:printcolumn:name="Last Check",type="date",JSONPath=`.status.lastCheckTime`,description="Time of the last successful check"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Repository is the Schema for the repositories API
+type Repository struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RepositorySpec   `json:"spec,omitempty"`
+	Status RepositoryStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RepositoryList contains a list of Repository
+type RepositoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Repository `json:"items"`
+}
+
+var (
+	RepositoryKind = reflect.TypeOf(Repository{}).Name()
+)
+
+func init() {
+	SchemeBuilder.Register(&Repository{}, &RepositoryList{})
+}
+
+// GetType implements JobObject.
+// The Repository is a JobObject as the operator runs a job to initialize it.
+func (*Repository) GetType() JobType {
+	return RepositoryType
+}
+
+// GetStatus retrieves the Status property
+func (r *Repository) GetStatus() Status {
+	return Status{Conditions: r.Status.Conditions}
+}
+
+// SetStatus sets the Status.Conditions property
+func (r *Repository) SetStatus(status Status) {
+	r.Status.Conditions = status.Conditions
+}
+
+// GetResources returns the global default resource requirements
+func (r *Repository) GetResources() corev1.ResourceRequirements {
+	return cfg.Config.GetGlobalDefaultResources()
+}
+
+// GetPodSecurityContext implements JobObject
+func (r *Repository) GetPodSecurityContext() *corev1.PodSecurityContext {
+	return nil
+}
+
+// GetActiveDeadlineSeconds implements JobObject
+func (r *Repository) GetActiveDeadlineSeconds() *int64 {
+	return nil
+}
+
+// GetPodConfig implements JobObject
+func (r *Repository) GetPodConfig(_ context.Context, _ client.Client) (*PodConfig, error) {
+	return nil, nil
+}
+
+// GetResticOptions returns the restic options in the format 'key=value,key2=value2'.
+func (r *Repository) GetResticOptions() string {
+	return strings.Join(r.Spec.ResticOptions, ",")
+}
+
+// IsInitialized returns true if the operator has initialized the restic repository of the current backend.
+func (r *Repository) IsInitialized() bool {
+	return r.Status.Initialized && r.Spec.Backend != nil && r.Status.InitializedRepository == r.Spec.Backend.String()
+}
+
+// ApplyTo configures the given spec to use this repository.
+// The backend of the spec is replaced by the repository's backend, and the repository's volumes are added
+// unless the spec already defines a volume with the same name.
+func (r *Repository) ApplyTo(spec *RunnableSpec) {
+	spec.Backend = r.Spec.Backend.DeepCopy()
+	if r.Spec.Volumes == nil {
+		return
+	}
+	volumes := make([]RunnableVolumeSpec, 0)
+	if spec.Volumes != nil {
+		volumes = append(volumes, *spec.Volumes...)
+	}
+	for _, volume := range *r.Spec.Volumes {
+		if !containsVolume(volumes, volume.Name) {
+			volumes = append(volumes, *volume.DeepCopy())
+		}
+	}
+	spec.Volumes = &volumes
+}
+
+func containsVolume(volumes []RunnableVolumeSpec, name string) bool {
+	for _, volume := range volumes {
+		if volume.Name == name {
+			return true
+		}
+	}
+	return false
+}
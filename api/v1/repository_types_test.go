@@ -0,0 +1,65 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestRepository_ApplyTo(t *testing.T) {
+	repository := &Repository{Spec: RepositorySpec{
+		Backend: &Backend{S3: &S3Spec{Endpoint: "https://s3.example.com", Bucket: "bucket"}},
+		Volumes: &[]RunnableVolumeSpec{
+			{Name: "ca", Secret: &corev1.SecretVolumeSource{SecretName: "repository-ca"}},
+			{Name: "client-cert", Secret: &corev1.SecretVolumeSource{SecretName: "repository-client-cert"}},
+		},
+	}}
+	spec := &RunnableSpec{
+		RepositoryRef: &corev1.LocalObjectReference{Name: "repository"},
+		Volumes: &[]RunnableVolumeSpec{
+			{Name: "ca", Secret: &corev1.SecretVolumeSource{SecretName: "job-ca"}},
+		},
+	}
+
+	repository.ApplyTo(spec)
+
+	assert.Equal(t, "s3:https://s3.example.com/bucket", spec.Backend.String())
+	assert.NotSame(t, repository.Spec.Backend, spec.Backend)
+	assert.Len(t, *spec.Volumes, 2)
+	assert.Equal(t, "job-ca", (*spec.Volumes)[0].Secret.SecretName, "the job's own volume takes precedence")
+	assert.Equal(t, "client-cert", (*spec.Volumes)[1].Name)
+}
+
+func TestRepository_IsInitialized(t *testing.T) {
+	tests := map[string]struct {
+		givenStatus    RepositoryStatus
+		expectedResult bool
+	}{
+		"GivenNotInitialized_ThenExpectFalse": {
+			givenStatus:    RepositoryStatus{},
+			expectedResult: false,
+		},
+		"GivenInitializedBackend_ThenExpectTrue": {
+			givenStatus:    RepositoryStatus{Initialized: true, InitializedRepository: "s3:https://s3.example.com/bucket"},
+			expectedResult: true,
+		},
+		"GivenChangedBackend_ThenExpectFalse": {
+			givenStatus:    RepositoryStatus{Initialized: true, InitializedRepository: "s3:https://s3.example.com/previous"},
+			expectedResult: false,
+		},
+		"GivenInitializedWithoutRepository_ThenExpectFalse": {
+			givenStatus:    RepositoryStatus{Initialized: true},
+			expectedResult: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			repository := &Repository{
+				Spec:   RepositorySpec{Backend: &Backend{S3: &S3Spec{Endpoint: "https://s3.example.com", Bucket: "bucket"}}},
+				Status: tt.givenStatus,
+			}
+			assert.Equal(t, tt.expectedResult, repository.IsInitialized())
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package v1
+
+import (
+	"context"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RepositoryKeySpec defines the rotation of the password of a restic repository.
+// The job adds a key for the new password, verifies that the new password opens the repository
+// and removes the key of the current password, which is the repoPasswordSecretRef of the backend.
+type RepositoryKeySpec struct {
+	RunnableSpec `json:",inline"`
+
+	// NewPasswordSecretRef references the new password of the repository.
+	// Once the job has succeeded, the repoPasswordSecretRef of the backend has to refer to the new password,
+	// as the current password doesn't open the repository anymore.
+	NewPasswordSecretRef *corev1.SecretKeySelector `json:"newPasswordSecretRef"`
+
+	// FailedJobsHistoryLimit amount of failed jobs to keep for later analysis.
+	// +optional
+	FailedJobsHistoryLimit *int `json:"failedJobsHistoryLimit,omitempty"`
+	// SuccessfulJobsHistoryLimit amount of successful jobs to keep for later analysis.
+	// +optional
+	SuccessfulJobsHistoryLimit *int `json:"successfulJobsHistoryLimit,omitempty"`
+}
+
+// RepositoryKeyStatus defines the observed state of RepositoryKey
+type RepositoryKeyStatus struct {
+	Status               `json:",inline"`
+	RepositoryKeyResults `json:",inline"`
+}
+
+// RepositoryKeyResults contains the keys that were changed by the job.
+// The job's container reports them in its termination message.
+type RepositoryKeyResults struct {
+	// KeyID is the ID of the key that was added for the new password.
+	// +optional
+	KeyID string `json:"keyID,omitempty"`
+	// RemovedKeyID is the ID of the key of the former password that was removed.
+	// +optional
+	RemovedKeyID string `json:"removedKeyID,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Key",type="string",JSONPath=`.status.keyID`,description="ID of the key of the new password"
+// +kubebuilder:printcolumn:name="Completion",type="string",JSONPath=`.status.conditions[?(@.type == "Completed")].reason`,description="Status of Completion"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// RepositoryKey is the Schema for the repositorykeys API.
+// It rotates the password of a restic repository.
+type RepositoryKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RepositoryKeySpec   `json:"spec,omitempty"`
+	Status RepositoryKeyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RepositoryKeyList contains a list of RepositoryKey
+type RepositoryKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RepositoryKey `json:"items"`
+}
+
+var (
+	RepositoryKeyKind = reflect.TypeOf(RepositoryKey{}).Name()
+)
+
+func init() {
+	SchemeBuilder.Register(&RepositoryKey{}, &RepositoryKeyList{})
+}
+
+// GetType implements JobObject.
+func (*RepositoryKey) GetType() JobType {
+	return RepositoryKeyType
+}
+
+// GetStatus retrieves the Status property
+func (r *RepositoryKey) GetStatus() Status {
+	return r.Status.Status
+}
+
+// SetStatus sets the Status property
+func (r *RepositoryKey) SetStatus(status Status) {
+	r.Status.Status = status
+}
+
+// GetResources returns the resource requirements
+func (r *RepositoryKey) GetResources() corev1.ResourceRequirements {
+	return r.Spec.Resources
+}
+
+// GetPodSecurityContext returns the pod security context
+func (r *RepositoryKey) GetPodSecurityContext() *corev1.PodSecurityContext {
+	return r.Spec.PodSecurityContext
+}
+
+// GetActiveDeadlineSeconds implements JobObject
+func (r *RepositoryKey) GetActiveDeadlineSeconds() *int64 {
+	return r.Spec.ActiveDeadlineSeconds
+}
+
+// GetPodConfig implements JobObject
+func (r *RepositoryKey) GetPodConfig(ctx context.Context, c client.Client) (*PodConfig, error) {
+	if r.Spec.PodConfigRef == nil {
+		return nil, nil
+	}
+	return NewPodConfig(ctx, r.Spec.PodConfigRef.Name, r.GetNamespace(), c)
+}
+
+// GetFailedJobsHistoryLimit returns failed jobs history limit.
+func (r *RepositoryKey) GetFailedJobsHistoryLimit() *int {
+	return r.Spec.FailedJobsHistoryLimit
+}
+
+// GetSuccessfulJobsHistoryLimit returns successful jobs history limit.
+func (r *RepositoryKey) GetSuccessfulJobsHistoryLimit() *int {
+	return r.Spec.SuccessfulJobsHistoryLimit
+}
+
+// GetJobObjects returns a sortable list of jobs
+func (r *RepositoryKeyList) GetJobObjects() JobObjectList {
+	items := make(JobObjectList, len(r.Items))
+	for i := range r.Items {
+		items[i] = &r.Items[i]
+	}
+	return items
+}
@@ -18,7 +18,15 @@ type RestoreSpec struct {
 	RestoreFilter string         `json:"restoreFilter,omitempty"`
 	// Simple filter to define a timestamp (prefix, YYYY-MM-DD hh:mm:ss) for snapshot selection instead of latest (or latest if nothing matches)
 	RestoreTimeFilter string `json:"restoreTimeFilter,omitempty"`
-	Snapshot          string `json:"snapshot,omitempty"`
+	// Snapshot is the ID of the snapshot to restore, or a prefix of it.
+	// If neither snapshot nor snapshotRef is set, the latest snapshot is restored.
+	Snapshot string `json:"snapshot,omitempty"`
+	// SnapshotRef refers to the Snapshot in the same namespace to restore.
+	// The backend is derived from the repository of the Snapshot if the restore configures neither backend nor repositoryRef.
+	// The restore isn't started if the derived backend doesn't match the repository of the Snapshot.
+	// It can't be used together with snapshot.
+	// +optional
+	SnapshotRef *corev1.LocalObjectReference `json:"snapshotRef,omitempty"`
 	// KeepJobs amount of jobs to keep for later analysis.
 	//
 	// Deprecated: Use FailedJobsHistoryLimit and SuccessfulJobsHistoryLimit respectively.
@@ -9,6 +9,10 @@ type RunnableSpec struct {
 	// Backend contains the restic repo where the job should backup to.
 	Backend *Backend `json:"backend,omitempty"`
 
+	// RepositoryRef refers to a Repository in the same namespace that contains the restic repo.
+	// It's mutually exclusive with Backend.
+	RepositoryRef *corev1.LocalObjectReference `json:"repositoryRef,omitempty"`
+
 	// Resources describes the compute resource requirements (cpu, memory, etc.)
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 
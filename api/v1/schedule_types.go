@@ -2,6 +2,7 @@ package v1
 
 import (
 	"context"
+	"reflect"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -17,8 +18,14 @@ type ScheduleSpec struct {
 	Archive *ArchiveSchedule `json:"archive,omitempty"`
 	Check   *CheckSchedule   `json:"check,omitempty"`
 	Prune   *PruneSchedule   `json:"prune,omitempty"`
+	Copy    *CopySchedule    `json:"copy,omitempty"`
 	Backend *Backend         `json:"backend,omitempty"`
 
+	// RepositoryRef refers to a Repository in the same namespace.
+	// It's used by all job definitions in this Schedule that don't configure a backend or repository themselves.
+	// It's mutually exclusive with Backend.
+	RepositoryRef *corev1.LocalObjectReference `json:"repositoryRef,omitempty"`
+
 	// KeepJobs amount of jobs to keep for later analysis.
 	//
 	// Deprecated: Use FailedJobsHistoryLimit and SuccessfulJobsHistoryLimit respectively.
@@ -75,6 +82,12 @@ type ArchiveSchedule struct {
 type CheckSchedule struct {
 	CheckSpec       `json:",inline"`
 	*ScheduleCommon `json:",inline"`
+
+	// RotateReadDataSubset advances the ReadDataSubset of the form "n/t" on each run, so that 1/7 becomes 2/7 on the next run and
+	// all data has been read after t runs.
+	// The subset of the last run is recorded in the status of the Schedule.
+	// +optional
+	RotateReadDataSubset bool `json:"rotateReadDataSubset,omitempty"`
 }
 
 // PruneSchedule manages the schedules for the prunes
@@ -83,6 +96,12 @@ type PruneSchedule struct {
 	*ScheduleCommon `json:",inline"`
 }
 
+// CopySchedule manages the schedules for the copies
+type CopySchedule struct {
+	CopySpec        `json:",inline"`
+	*ScheduleCommon `json:",inline"`
+}
+
 // ScheduleStatus defines the observed state of Schedule
 type ScheduleStatus struct {
 	// Conditions provide a standard mechanism for higher-level status reporting from a controller.
@@ -91,6 +110,8 @@ type ScheduleStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 	// EffectiveSchedules contains a list of schedules generated from randomizing schedules.
 	EffectiveSchedules []EffectiveSchedule `json:"effectiveSchedules,omitempty"`
+	// CheckReadDataSubset is the subset of the data that the last Check of the Schedule reads, if the Check rotates its subset.
+	CheckReadDataSubset string `json:"checkReadDataSubset,omitempty"`
 }
 
 type EffectiveSchedule struct {
@@ -127,6 +148,10 @@ const (
 	LegacyScheduleFinalizerName = "k8up.syn.tools/schedule"
 )
 
+var (
+	ScheduleKind = reflect.TypeOf(Schedule{}).Name()
+)
+
 func init() {
 	SchemeBuilder.Register(&Schedule{}, &ScheduleList{})
 }
@@ -198,6 +223,25 @@ func (s *Schedule) GetPodConfig(ctx context.Context, c client.Client) (*PodConfi
 	return NewPodConfig(ctx, s.Spec.PodConfigRef.Name, s.GetNamespace(), c)
 }
 
+const (
+	ScheduleHourlyRandom   ScheduleDefinition = "@hourly-random"
+	ScheduleDailyRandom    ScheduleDefinition = "@daily-random"
+	ScheduleYearlyRandom   ScheduleDefinition = "@yearly-random"
+	ScheduleAnnuallyRandom ScheduleDefinition = "@annually-random"
+	ScheduleMonthlyRandom  ScheduleDefinition = "@monthly-random"
+	ScheduleWeeklyRandom   ScheduleDefinition = "@weekly-random"
+)
+
+// RandomScheduleDefinitions are the '@x-random' definitions that the schedule controller randomizes.
+var RandomScheduleDefinitions = []ScheduleDefinition{
+	ScheduleHourlyRandom,
+	ScheduleDailyRandom,
+	ScheduleWeeklyRandom,
+	ScheduleMonthlyRandom,
+	ScheduleYearlyRandom,
+	ScheduleAnnuallyRandom,
+}
+
 // String casts the value to string.
 // "aScheduleDefinition.String()" and "string(aScheduleDefinition)" are equivalent.
 func (s ScheduleDefinition) String() string {
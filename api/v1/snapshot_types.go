@@ -14,10 +14,38 @@ type SnapshotSpec struct {
 	Date       *metav1.Time `json:"date,omitempty"`
 	Paths      *[]string    `json:"paths,omitempty"`
 	Repository *string      `json:"repository,omitempty"`
+
+	// Tags are the restic tags of the snapshot.
+	Tags []string `json:"tags,omitempty"`
+	// Hostname is the host restic recorded for the snapshot, K8up uses the namespace of the backup.
+	Hostname string `json:"hostname,omitempty"`
+	// Username is the user restic recorded for the snapshot.
+	Username string `json:"username,omitempty"`
+	// Tree is the ID of the snapshot's root tree in the repository.
+	Tree string `json:"tree,omitempty"`
+	// Source is the name of the PVC the snapshot was taken from,
+	// or the file name of the data of a backup command.
+	Source string `json:"source,omitempty"`
+	// BackupName is the name of the Backup that produced the snapshot.
+	// It's only known for snapshots that have been synchronized by the backup that took them.
+	BackupName string `json:"backupName,omitempty"`
+	// ScheduleName is the name of the Schedule that created the Backup which produced the snapshot.
+	ScheduleName string `json:"scheduleName,omitempty"`
 }
 
-// SnapshotStatus defines the observed state of Snapshot
+// SnapshotStatus contains the statistics restic reported when it took the snapshot.
+// They're only known for snapshots that have been synchronized by the backup that took them.
 type SnapshotStatus struct {
+	// FilesNew is the number of files that haven't been in the parent snapshot.
+	FilesNew int `json:"filesNew,omitempty"`
+	// FilesChanged is the number of files that changed since the parent snapshot.
+	FilesChanged int `json:"filesChanged,omitempty"`
+	// BytesAdded is the amount of data in bytes that has been added to the repository.
+	BytesAdded int64 `json:"bytesAdded,omitempty"`
+	// TotalFiles is the number of files in the snapshot.
+	TotalFiles int `json:"totalFiles,omitempty"`
+	// TotalBytes is the size of the snapshot in bytes.
+	TotalBytes int64 `json:"totalBytes,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -25,6 +53,14 @@ type SnapshotStatus struct {
 // +kubebuilder:printcolumn:name="Date taken",type="string",JSONPath=`.spec.date`,description="Date when snapshot was taken"
 // +kubebuilder:printcolumn:name="Paths",type="string",JSONPath=`.spec.paths[*]`,description="Snapshot's paths"
 // +kubebuilder:printcolumn:name="Repository",type="string",JSONPath=`.spec.repository`,description="Repository Url"
+// +kubebuilder:printcolumn:name="Source",type="string",JSONPath=`.spec.source`,description="PVC or file name the snapshot was taken from"
+// +kubebuilder:printcolumn:name="Size",type="integer",JSONPath=`.status.totalBytes`,description="Size of the snapshot in bytes"
+// +kubebuilder:printcolumn:name="Added",type="integer",JSONPath=`.status.bytesAdded`,description="Bytes added to the repository",priority=1
+// +kubebuilder:printcolumn:name="Files new",type="integer",JSONPath=`.status.filesNew`,description="Number of new files",priority=1
+// +kubebuilder:printcolumn:name="Hostname",type="string",JSONPath=`.spec.hostname`,description="Hostname restic recorded",priority=1
+// +kubebuilder:printcolumn:name="Tags",type="string",JSONPath=`.spec.tags[*]`,description="Snapshot's tags",priority=1
+// +kubebuilder:printcolumn:name="Backup",type="string",JSONPath=`.spec.backupName`,description="Backup that produced the snapshot",priority=1
+// +kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=`.spec.scheduleName`,description="Schedule that produced the snapshot",priority=1
 
 // Snapshot is the Schema for the snapshots API
 type Snapshot struct {
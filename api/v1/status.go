@@ -20,7 +20,7 @@ type Status struct {
 
 // HasFailed returns true in the following cases:
 //
-// * If ConditionCompleted is true with any other reason than ReasonSucceeded.
+// * If ConditionCompleted is true with any other reason than ReasonSucceeded or ReasonPartiallySucceeded.
 //
 // * If ConditionPreBackupPodReady is false with any of the "failed" reasons.
 func (in Status) HasFailed() bool {
@@ -28,7 +28,7 @@ func (in Status) HasFailed() bool {
 		return true
 	}
 	completedCond := meta.FindStatusCondition(in.Conditions, ConditionCompleted.String())
-	if completedCond != nil && !matchAnyReason(*completedCond, ReasonSucceeded) {
+	if completedCond != nil && !matchAnyReason(*completedCond, ReasonSucceeded, ReasonPartiallySucceeded) {
 		return completedCond.Status == metav1.ConditionTrue
 	}
 	return false
@@ -36,7 +36,7 @@ func (in Status) HasFailed() bool {
 
 // HasSucceeded returns true if all cases are true:
 //
-// * If ConditionCompleted is true with ReasonSucceeded.
+// * If ConditionCompleted is true with ReasonSucceeded or ReasonPartiallySucceeded.
 //
 // * If ConditionPreBackupPodReady has no failure reason.
 func (in Status) HasSucceeded() bool {
@@ -44,7 +44,7 @@ func (in Status) HasSucceeded() bool {
 		return false
 	}
 	completedCond := meta.FindStatusCondition(in.Conditions, ConditionCompleted.String())
-	if completedCond != nil && matchAnyReason(*completedCond, ReasonSucceeded) {
+	if completedCond != nil && matchAnyReason(*completedCond, ReasonSucceeded, ReasonPartiallySucceeded) {
 		return completedCond.Status == metav1.ConditionTrue
 	}
 	return false
@@ -135,6 +135,23 @@ func (in *Status) SetSucceeded(message string) {
 	})
 }
 
+// SetPartiallySucceeded sets ConditionCompleted to true with ReasonPartiallySucceeded,
+// and ConditionPartiallySucceeded to true with the given message.
+func (in *Status) SetPartiallySucceeded(message string) {
+	meta.SetStatusCondition(&in.Conditions, metav1.Condition{
+		Type:    ConditionCompleted.String(),
+		Status:  metav1.ConditionTrue,
+		Reason:  ReasonPartiallySucceeded.String(),
+		Message: message,
+	})
+	meta.SetStatusCondition(&in.Conditions, metav1.Condition{
+		Type:    ConditionPartiallySucceeded.String(),
+		Status:  metav1.ConditionTrue,
+		Reason:  ReasonPartiallySucceeded.String(),
+		Message: message,
+	})
+}
+
 // SetCondition sets a generic condition, overwriting existing one by type if present.
 func (in *Status) SetCondition(typ ConditionType, reason ConditionReason, status metav1.ConditionStatus, message string) {
 	meta.SetStatusCondition(&in.Conditions, metav1.Condition{
@@ -0,0 +1,648 @@
+package v1
+
+import (
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// supportedBackendNames contains the JSON field names of the backends, in the same order as returned by getSupportedBackends.
+var supportedBackendNames = []string{"azure", "b2", "gcs", "local", "rest", "s3", "swift", "sftp", "rclone"}
+
+// Validate returns an error for each violation of the Backend.
+// At most one storage backend may be configured.
+// A backend without storage type is completed by the ClusterRepository that selects the job's namespace at the time the job runs.
+// Whether such a ClusterRepository exists is checked by the admission webhook, see RunnableSpec.ValidateStorage.
+func (in *Backend) Validate(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if configured := in.configuredBackendNames(); len(configured) > 1 {
+		allErrs = append(allErrs, field.Forbidden(path, "only one backend may be configured, found: "+strings.Join(configured, ", ")))
+	}
+	if in.S3 != nil && in.S3.RoleARN != "" && (in.S3.AccessKeyIDSecretRef != nil || in.S3.SecretAccessKeySecretRef != nil) {
+		allErrs = append(allErrs, field.Forbidden(path.Child("s3", "roleARN"), "a role can't be combined with access keys"))
+	}
+	if in.GCS != nil && in.GCS.CredentialsSecretRef != nil && in.GCS.AccessTokenSecretRef != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("gcs", "credentialsSecretRef"), "credentials can't be combined with an access token"))
+	}
+	if in.Azure != nil && in.Azure.SASTokenSecretRef != nil && in.Azure.AccountKeySecretRef != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("azure", "sasTokenSecretRef"), "a SAS token can't be combined with an account key"))
+	}
+	if in.Swift != nil {
+		allErrs = append(allErrs, in.Swift.Validate(path.Child("swift"))...)
+	}
+	if in.SFTP != nil && in.SFTP.Host == "" {
+		allErrs = append(allErrs, field.Required(path.Child("sftp", "host"), "the host of the SSH server is required"))
+	}
+	if in.Rest != nil {
+		allErrs = append(allErrs, in.Rest.Validate(path.Child("rest"))...)
+	}
+	if in.Rclone != nil && in.Rclone.Remote == "" {
+		allErrs = append(allErrs, field.Required(path.Child("rclone", "remote"), "the name of the rclone remote is required"))
+	}
+	if in.Rclone != nil && in.Rclone.ConfigSecretRef == nil {
+		allErrs = append(allErrs, field.Required(path.Child("rclone", "configSecretRef"), "the rclone configuration is required"))
+	}
+	for _, operation := range []CredentialOperation{CredentialOperationBackup, CredentialOperationMaintenance} {
+		if in.credentialsFor(operation) != nil && in.WithCredentialsFor(operation).String() != in.String() {
+			allErrs = append(allErrs, field.Forbidden(path.Child("credentials", string(operation)), "the credentials may not change the location of the repository"))
+		}
+	}
+	allErrs = append(allErrs, in.validateRepoPassword(path)...)
+	allErrs = append(allErrs, in.TLSOptions.Validate(path.Child("tlsOptions"))...)
+	if in.ServiceAccount != nil && in.ServiceAccount.Name == "" {
+		allErrs = append(allErrs, field.Required(path.Child("serviceAccount", "name"), "the name of the ServiceAccount is required"))
+	}
+	return allErrs
+}
+
+// validateRepoPassword returns an error if more than one source of the repository password is configured,
+// or if the password command is incomplete.
+func (in *Backend) validateRepoPassword(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	var configured []string
+	if in.RepoPasswordSecretRef != nil {
+		configured = append(configured, "repoPasswordSecretRef")
+	}
+	if in.RepoPasswordFileSecretRef != nil {
+		configured = append(configured, "repoPasswordFileSecretRef")
+	}
+	if in.RepoPasswordCommand != nil {
+		configured = append(configured, "repoPasswordCommand")
+	}
+	if len(configured) > 1 {
+		allErrs = append(allErrs, field.Forbidden(path, "only one source of the repository password may be configured, found: "+strings.Join(configured, ", ")))
+	}
+	if command := in.RepoPasswordCommand; command != nil {
+		if strings.TrimSpace(command.Command) == "" {
+			allErrs = append(allErrs, field.Required(path.Child("repoPasswordCommand", "command"), "the command that prints the password is required"))
+		}
+		if command.Image == "" && len(command.InitCommand) > 0 {
+			allErrs = append(allErrs, field.Required(path.Child("repoPasswordCommand", "image"), "the image is required to run the init command"))
+		}
+		for i, volume := range command.Volumes {
+			if volume.Name == "" {
+				allErrs = append(allErrs, field.Required(path.Child("repoPasswordCommand", "volumes").Index(i).Child("name"), "the name of the volume is required"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// Validate returns an error for each violation of the SwiftSpec.
+// A spec without credentials is valid, the OS_* variables may be given by envFrom.
+// Otherwise it needs an auth URL and either an application credential or a user name and password,
+// with either the Keystone v2 tenant or the Keystone v3 domains and project.
+func (in *SwiftSpec) Validate(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	appCredential := in.ApplicationCredentialIDSecretRef != nil || in.ApplicationCredentialSecretRef != nil
+	password := in.UsernameSecretRef != nil || in.PasswordSecretRef != nil
+	v2 := in.TenantIDSecretRef != nil || in.TenantNameSecretRef != nil
+	v3 := in.UserDomainNameSecretRef != nil || in.ProjectNameSecretRef != nil || in.ProjectDomainNameSecretRef != nil
+	if !appCredential && !password && !v2 && !v3 && in.AuthURLSecretRef == nil {
+		return nil
+	}
+
+	if in.AuthURLSecretRef == nil {
+		allErrs = append(allErrs, field.Required(path.Child("authURLSecretRef"), "the URL of the identity service is required"))
+	}
+	switch {
+	case appCredential && password:
+		allErrs = append(allErrs, field.Forbidden(path, "an application credential can't be combined with a user name and password"))
+	case appCredential:
+		if in.ApplicationCredentialIDSecretRef == nil {
+			allErrs = append(allErrs, field.Required(path.Child("applicationCredentialIDSecretRef"), "the ID of the application credential is required"))
+		}
+		if in.ApplicationCredentialSecretRef == nil {
+			allErrs = append(allErrs, field.Required(path.Child("applicationCredentialSecretRef"), "the secret of the application credential is required"))
+		}
+	default:
+		if in.UsernameSecretRef == nil {
+			allErrs = append(allErrs, field.Required(path.Child("usernameSecretRef"), "the user name is required without application credential"))
+		}
+		if in.PasswordSecretRef == nil {
+			allErrs = append(allErrs, field.Required(path.Child("passwordSecretRef"), "the password is required without application credential"))
+		}
+	}
+	if v2 && v3 {
+		allErrs = append(allErrs, field.Forbidden(path, "the Keystone v2 tenant can't be combined with the Keystone v3 domains and project"))
+	}
+	if password && !appCredential && !v2 && !v3 {
+		allErrs = append(allErrs, field.Required(path, "either the Keystone v2 tenant or the Keystone v3 domains and project are required"))
+	}
+	if v3 && !appCredential && in.UserDomainNameSecretRef == nil {
+		allErrs = append(allErrs, field.Required(path.Child("userDomainNameSecretRef"), "the domain of the user is required for Keystone v3"))
+	}
+	return allErrs
+}
+
+// Validate returns an error for each violation of the RestServerSpec.
+// The URL has to be an http or https URL, it may be empty in jobs that get it from a ClusterRepository.
+func (in *RestServerSpec) Validate(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if in.URL != "" {
+		u, err := url.Parse(in.URL)
+		switch {
+		case err != nil:
+			allErrs = append(allErrs, field.Invalid(path.Child("url"), in.URL, err.Error()))
+		case u.Scheme != "http" && u.Scheme != "https":
+			allErrs = append(allErrs, field.Invalid(path.Child("url"), in.URL, "the URL has to start with http:// or https://"))
+		case u.Host == "":
+			allErrs = append(allErrs, field.Invalid(path.Child("url"), in.URL, "the URL has to contain the host of the rest-server"))
+		}
+	}
+	if in.PasswordSecretRef != nil && in.PasswordSecretReg != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("passwordSecretReg"), "the deprecated passwordSecretReg can't be combined with passwordSecretRef"))
+	}
+	return allErrs
+}
+
+// validateNamespacedPath returns an error if the backend appends the namespace to the path of the rest-server,
+// which only ClusterRepositories support.
+func validateNamespacedPath(backend *Backend, path *field.Path) field.ErrorList {
+	if backend == nil || backend.Rest == nil || !backend.Rest.NamespacedPath {
+		return nil
+	}
+	return field.ErrorList{field.Forbidden(path.Child("rest", "namespacedPath"), "only the backend of a ClusterRepository can append the namespace to the path")}
+}
+
+// Validate returns an error for each violation of the TLSOptions.
+// Each file may either be given as path or as reference, and the client certificate and key have to be given together.
+func (in *TLSOptions) Validate(path *field.Path) field.ErrorList {
+	if in == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	if in.CACertSecretRef != nil && in.CACertConfigMapRef != nil {
+		allErrs = append(allErrs, field.Forbidden(path, "only one of caCertSecretRef or caCertConfigMapRef may be configured"))
+	}
+	if in.CACert != "" && (in.CACertSecretRef != nil || in.CACertConfigMapRef != nil) {
+		allErrs = append(allErrs, field.Forbidden(path.Child("caCert"), "the path can't be combined with a reference"))
+	}
+	if in.ClientCert != "" && in.ClientCertSecretRef != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("clientCert"), "the path can't be combined with a reference"))
+	}
+	if in.ClientKey != "" && in.ClientKeySecretRef != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("clientKey"), "the path can't be combined with a reference"))
+	}
+	if (in.ClientCertSecretRef == nil) != (in.ClientKeySecretRef == nil) {
+		allErrs = append(allErrs, field.Required(path, "clientCertSecretRef and clientKeySecretRef have to be configured together"))
+	}
+	return allErrs
+}
+
+// validateStorage returns an error if the backend doesn't configure exactly one storage type.
+func validateStorage(backend *Backend, path *field.Path) field.ErrorList {
+	if backend == nil || !backend.HasStorageType() {
+		return field.ErrorList{field.Required(path, "exactly one of "+strings.Join(supportedBackendNames, ", ")+" has to be configured")}
+	}
+	return backend.Validate(path)
+}
+
+// HasStorageType returns true if at least one storage backend is configured.
+func (in *Backend) HasStorageType() bool {
+	return len(in.configuredBackendNames()) > 0
+}
+
+func (in *Backend) configuredBackendNames() []string {
+	var names []string
+	for i, backend := range in.getSupportedBackends() {
+		if !IsNil(backend) {
+			names = append(names, supportedBackendNames[i])
+		}
+	}
+	return names
+}
+
+// Validate returns an error for each violation of the RestoreMethod.
+// S3 and Folder are mutually exclusive, and a Folder restore requires a claim name.
+func (in *RestoreMethod) Validate(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	switch {
+	case in.S3 != nil && in.Folder != nil:
+		allErrs = append(allErrs, field.Forbidden(path, "only one of s3 or folder may be configured"))
+	case in.S3 == nil && in.Folder == nil:
+		allErrs = append(allErrs, field.Required(path, "one of s3 or folder has to be configured"))
+	case in.Folder != nil && (in.Folder.PersistentVolumeClaimVolumeSource == nil || in.Folder.ClaimName == ""):
+		allErrs = append(allErrs, field.Required(path.Child("folder", "claimName"), "the PVC to restore into has to be given"))
+	}
+	return append(allErrs, in.TLSOptions.Validate(path.Child("tlsOptions"))...)
+}
+
+// Validate returns an error for each violation of the RetentionPolicy.
+// None of the keep values may be negative, the keepWithin durations and the groupBy options have to be understood by restic.
+func (in *RetentionPolicy) Validate(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for _, keep := range []struct {
+		name  string
+		value int
+	}{
+		{"keepLast", in.KeepLast},
+		{"keepHourly", in.KeepHourly},
+		{"keepDaily", in.KeepDaily},
+		{"keepWeekly", in.KeepWeekly},
+		{"keepMonthly", in.KeepMonthly},
+		{"keepYearly", in.KeepYearly},
+	} {
+		if keep.value < 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child(keep.name), keep.value, "must not be negative"))
+		}
+	}
+	for _, keep := range []struct {
+		name  string
+		value string
+	}{
+		{"keepWithin", in.KeepWithin},
+		{"keepWithinHourly", in.KeepWithinHourly},
+		{"keepWithinDaily", in.KeepWithinDaily},
+		{"keepWithinWeekly", in.KeepWithinWeekly},
+		{"keepWithinMonthly", in.KeepWithinMonthly},
+		{"keepWithinYearly", in.KeepWithinYearly},
+	} {
+		if keep.value != "" && !KeepWithinPattern.MatchString(keep.value) {
+			allErrs = append(allErrs, field.Invalid(path.Child(keep.name), keep.value, `has to be a duration of years, months, days and hours like "2y5m7d3h"`))
+		}
+	}
+	for _, option := range UnknownGroupByOptions(in.GroupBy) {
+		allErrs = append(allErrs, field.NotSupported(path.Child("groupBy"), option, GroupByOptions))
+	}
+	return allErrs
+}
+
+// Validate returns an error if the ScheduleDefinition is neither a valid cron expression nor a supported '@x-random' definition.
+func (s ScheduleDefinition) Validate(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if s == "" {
+		return append(allErrs, field.Required(path, "a schedule has to be given"))
+	}
+	if s.IsRandom() {
+		if !slices.Contains(RandomScheduleDefinitions, s) {
+			supported := make([]string, 0, len(RandomScheduleDefinitions))
+			for _, definition := range RandomScheduleDefinitions {
+				supported = append(supported, definition.String())
+			}
+			allErrs = append(allErrs, field.NotSupported(path, s.String(), supported))
+		}
+		return allErrs
+	}
+	if _, err := cron.ParseStandard(s.String()); err != nil {
+		allErrs = append(allErrs, field.Invalid(path, s.String(), err.Error()))
+	}
+	return allErrs
+}
+
+// Validate returns an error for each violation of the RunnableSpec.
+// The backend and the repository reference are mutually exclusive.
+func (in *RunnableSpec) Validate(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if in.RepositoryRef != nil {
+		allErrs = append(allErrs, validateRepositoryRef(in.RepositoryRef, in.Backend, path)...)
+	} else if in.Backend != nil {
+		allErrs = append(allErrs, in.Backend.Validate(path.Child("backend"))...)
+		allErrs = append(allErrs, validateNamespacedPath(in.Backend, path.Child("backend"))...)
+	}
+	return allErrs
+}
+
+// ValidateStorage returns an error if the RunnableSpec neither refers to a Repository nor configures a storage type.
+// It only applies if neither a ClusterRepository nor the global repository of the operator completes the backend in the job's namespace.
+func (in *RunnableSpec) ValidateStorage(path *field.Path) field.ErrorList {
+	if in.RepositoryRef != nil || (in.Backend != nil && in.Backend.HasStorageType()) {
+		return nil
+	}
+	return field.ErrorList{field.Required(path.Child("backend"), "exactly one of "+strings.Join(supportedBackendNames, ", ")+" has to be configured, no ClusterRepository or global repository applies to the namespace")}
+}
+
+func validateRepositoryRef(ref *corev1.LocalObjectReference, backend *Backend, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if ref.Name == "" {
+		allErrs = append(allErrs, field.Required(path.Child("repositoryRef", "name"), "the name of the Repository has to be given"))
+	}
+	if backend != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("backend"), "only one of backend or repositoryRef may be configured"))
+	}
+	return allErrs
+}
+
+// Validate returns an error for each violation of the RepositorySpec.
+// Unlike in jobs, the backend has to configure a storage type, ClusterRepositories aren't used for Repositories.
+func (in *RepositorySpec) Validate(path *field.Path) field.ErrorList {
+	allErrs := validateStorage(in.Backend, path.Child("backend"))
+	return append(allErrs, validateNamespacedPath(in.Backend, path.Child("backend"))...)
+}
+
+// Validate returns an error for each violation of the ClusterRepositorySpec.
+// The backend has to configure a storage type and the namespace selector has to be valid.
+func (in *ClusterRepositorySpec) Validate(path *field.Path) field.ErrorList {
+	allErrs := validateStorage(in.Backend, path.Child("backend"))
+	if in.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(in.NamespaceSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("namespaceSelector"), in.NamespaceSelector, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// Validate returns an error for each violation of the BackupSpec.
+func (in *BackupSpec) Validate(path *field.Path) field.ErrorList {
+	return in.RunnableSpec.Validate(path)
+}
+
+// readDataSubsetPattern matches the subsets that restic can read when checking a repository.
+// The first group matches a part, the second the number of a percentage.
+var readDataSubsetPattern = regexp.MustCompile(`^(?:(\d+/\d+)|(\d+(?:\.\d+)?)%|\d+[kKmMgGtT]?)$`)
+
+// Validate returns an error for each violation of the CheckSpec.
+// readData and readDataSubset are mutually exclusive, and the subset has to be one that restic accepts.
+func (in *CheckSpec) Validate(path *field.Path) field.ErrorList {
+	allErrs := in.RunnableSpec.Validate(path)
+	if in.ReadDataSubset == "" {
+		return allErrs
+	}
+	subsetPath := path.Child("readDataSubset")
+	if in.ReadData {
+		allErrs = append(allErrs, field.Forbidden(subsetPath, "readData and readDataSubset can't be used together"))
+	}
+	match := readDataSubsetPattern.FindStringSubmatch(in.ReadDataSubset)
+	switch {
+	case match == nil:
+		allErrs = append(allErrs, field.Invalid(subsetPath, in.ReadDataSubset, `has to be a part like "1/7", a percentage like "10%" or a size like "500M"`))
+	case match[1] != "":
+		if n, t, _ := ReadDataPart(in.ReadDataSubset); n < 1 || n > t {
+			allErrs = append(allErrs, field.Invalid(subsetPath, in.ReadDataSubset, "the part has to be between 1 and the number of parts"))
+		}
+	case match[2] != "":
+		if percentage, _ := strconv.ParseFloat(match[2], 64); percentage <= 0 || percentage > 100 {
+			allErrs = append(allErrs, field.Invalid(subsetPath, in.ReadDataSubset, "the percentage has to be greater than 0 and at most 100"))
+		}
+	}
+	return allErrs
+}
+
+// Validate returns an error for each violation of the PruneSpec.
+func (in *PruneSpec) Validate(path *field.Path) field.ErrorList {
+	allErrs := in.RunnableSpec.Validate(path)
+	return append(allErrs, in.Retention.Validate(path.Child("retention"))...)
+}
+
+// Validate returns an error for each violation of the RepositoryKeySpec.
+// The new password is required and has to be different from the current password.
+func (in *RepositoryKeySpec) Validate(path *field.Path) field.ErrorList {
+	allErrs := in.RunnableSpec.Validate(path)
+	switch {
+	case in.NewPasswordSecretRef == nil || in.NewPasswordSecretRef.Name == "" || in.NewPasswordSecretRef.Key == "":
+		allErrs = append(allErrs, field.Required(path.Child("newPasswordSecretRef"), "the Secret of the new password has to be given"))
+	case in.Backend != nil && isSameSecretKey(in.NewPasswordSecretRef, in.Backend.RepoPasswordSecretRef):
+		allErrs = append(allErrs, field.Invalid(path.Child("newPasswordSecretRef"), in.NewPasswordSecretRef.Name, "the new password has to be different from the repoPasswordSecretRef of the backend"))
+	case in.Backend != nil && isSameSecretKey(in.NewPasswordSecretRef, in.Backend.RepoPasswordFileSecretRef):
+		allErrs = append(allErrs, field.Invalid(path.Child("newPasswordSecretRef"), in.NewPasswordSecretRef.Name, "the new password has to be different from the repoPasswordFileSecretRef of the backend"))
+	}
+	return allErrs
+}
+
+// Validate returns an error for each violation of the CopySpec.
+// The destination has to configure a storage type and has to be another repository than the source.
+// The source can't use the settings that aren't supported for copies, and the credentials that both repositories set have to be the same.
+func (in *CopySpec) Validate(path *field.Path) field.ErrorList {
+	allErrs := in.RunnableSpec.Validate(path)
+	destinationPath := path.Child("destination")
+	allErrs = append(allErrs, validateStorage(in.Destination, destinationPath)...)
+	if in.Destination != nil {
+		allErrs = append(allErrs, validateNamespacedPath(in.Destination, destinationPath)...)
+	}
+	if in.Backend == nil {
+		return allErrs
+	}
+	for _, setting := range in.Backend.UnsupportedCopySettings() {
+		allErrs = append(allErrs, field.Forbidden(path.Child("backend", strings.Split(setting, ".")...), "isn't supported for the source of a copy"))
+	}
+	if in.Destination == nil {
+		return allErrs
+	}
+	if in.Backend.HasStorageType() && in.Backend.IsBackendEqualTo(in.Destination) {
+		allErrs = append(allErrs, field.Invalid(destinationPath, in.Destination.String(), "the destination has to be another repository than the source"))
+	}
+	source := in.Backend.WithCredentialsFor(CredentialOperationBackup)
+	destination := in.Destination.WithCredentialsFor(CredentialOperationBackup)
+	if conflicts := CopyCredentialConflicts(source, destination); len(conflicts) > 0 {
+		allErrs = append(allErrs, field.Forbidden(destinationPath, "the credentials have to be the same as those of the source, restic reads both from the same variables: "+strings.Join(conflicts, ", ")))
+	}
+	return allErrs
+}
+
+// packIDPattern matches the ID of a pack file, restic only accepts complete IDs to repair packs.
+var packIDPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// Validate returns an error for each violation of the MaintenanceSpec.
+// The options are only allowed for the operations they apply to, and the IDs of the packs are required to repair packs.
+func (in *MaintenanceSpec) Validate(path *field.Path) field.ErrorList {
+	allErrs := in.RunnableSpec.Validate(path)
+	switch in.Operation {
+	case MaintenanceRebuildIndex, MaintenanceRepairIndex, MaintenanceRepairSnapshots, MaintenanceRepairPacks, MaintenanceUpgradeRepoV2, MaintenanceUnlockRemoveAll:
+	case "":
+		allErrs = append(allErrs, field.Required(path.Child("operation"), "a maintenance operation has to be given"))
+	default:
+		allErrs = append(allErrs, field.NotSupported(path.Child("operation"), in.Operation, []MaintenanceOperation{
+			MaintenanceRebuildIndex, MaintenanceRepairIndex, MaintenanceRepairSnapshots, MaintenanceRepairPacks, MaintenanceUpgradeRepoV2, MaintenanceUnlockRemoveAll,
+		}))
+	}
+	if in.ReadAllPacks && !in.Operation.IsIndexRepair() {
+		allErrs = append(allErrs, field.Forbidden(path.Child("readAllPacks"), "only applies to the rebuild-index and repair-index operations"))
+	}
+	if in.Forget && in.Operation != MaintenanceRepairSnapshots {
+		allErrs = append(allErrs, field.Forbidden(path.Child("forget"), "only applies to the repair-snapshots operation"))
+	}
+	if in.Operation != MaintenanceRepairPacks {
+		if len(in.Packs) > 0 {
+			allErrs = append(allErrs, field.Forbidden(path.Child("packs"), "only applies to the repair-packs operation"))
+		}
+		return allErrs
+	}
+	if len(in.Packs) == 0 {
+		allErrs = append(allErrs, field.Required(path.Child("packs"), "the IDs of the packs have to be given to repair packs"))
+	}
+	for i, pack := range in.Packs {
+		if !packIDPattern.MatchString(pack) {
+			allErrs = append(allErrs, field.Invalid(path.Child("packs").Index(i), pack, "has to be the complete ID of a pack, 64 hexadecimal characters"))
+		}
+	}
+	return allErrs
+}
+
+func isSameSecretKey(ref, other *corev1.SecretKeySelector) bool {
+	return other != nil && ref.Name == other.Name && ref.Key == other.Key
+}
+
+// Validate returns an error for each violation of the RestoreSpec.
+// A restore method is required.
+func (in *RestoreSpec) Validate(path *field.Path) field.ErrorList {
+	allErrs := in.RunnableSpec.Validate(path)
+	if in.SnapshotRef != nil {
+		if in.SnapshotRef.Name == "" {
+			allErrs = append(allErrs, field.Required(path.Child("snapshotRef", "name"), "the name of the snapshot is required"))
+		}
+		if in.Snapshot != "" {
+			allErrs = append(allErrs, field.Forbidden(path.Child("snapshotRef"), "snapshot and snapshotRef can't be used together"))
+		}
+	}
+	if in.RestoreMethod == nil {
+		return append(allErrs, field.Required(path.Child("restoreMethod"), "one of s3 or folder has to be configured"))
+	}
+	return append(allErrs, in.RestoreMethod.Validate(path.Child("restoreMethod"))...)
+}
+
+// Validate returns an error for each violation of the ArchiveSpec.
+// Unlike restores, the restore method is optional as archives fall back to the restore S3 settings of the ClusterRepository.
+func (in *ArchiveSpec) Validate(path *field.Path) field.ErrorList {
+	if in.RestoreSpec == nil {
+		return nil
+	}
+	allErrs := in.RunnableSpec.Validate(path)
+	if in.RestoreMethod != nil {
+		if in.RestoreMethod.Folder != nil {
+			allErrs = append(allErrs, field.Forbidden(path.Child("restoreMethod", "folder"), "archives can only be written to s3"))
+		} else {
+			allErrs = append(allErrs, in.RestoreMethod.Validate(path.Child("restoreMethod"))...)
+		}
+	}
+	return allErrs
+}
+
+// ValidateStorage returns an error if the ArchiveSpec neither refers to a Repository nor configures a storage type, see RunnableSpec.ValidateStorage.
+func (in *ArchiveSpec) ValidateStorage(path *field.Path) field.ErrorList {
+	if in.RestoreSpec == nil {
+		return (&RunnableSpec{}).ValidateStorage(path)
+	}
+	return in.RunnableSpec.ValidateStorage(path)
+}
+
+// Validate returns an error for each violation of the ScheduleSpec.
+// Job definitions that don't configure a storage backend themselves are validated with the Schedule's backend.
+func (in *ScheduleSpec) Validate(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if in.RepositoryRef != nil {
+		allErrs = append(allErrs, validateRepositoryRef(in.RepositoryRef, in.Backend, path)...)
+	} else if in.Backend != nil {
+		allErrs = append(allErrs, in.Backend.Validate(path.Child("backend"))...)
+		allErrs = append(allErrs, validateNamespacedPath(in.Backend, path.Child("backend"))...)
+	}
+
+	type specValidator interface {
+		Validate(path *field.Path) field.ErrorList
+	}
+	for _, jb := range []struct {
+		name   string
+		common *ScheduleCommon
+		spec   func() (specValidator, *RunnableSpec)
+	}{
+		{name: "backup", common: scheduleCommonOf(in.Backup), spec: func() (specValidator, *RunnableSpec) {
+			s := in.Backup.BackupSpec.DeepCopy()
+			return s, &s.RunnableSpec
+		}},
+		{name: "check", common: scheduleCommonOf(in.Check), spec: func() (specValidator, *RunnableSpec) {
+			s := in.Check.CheckSpec.DeepCopy()
+			return s, &s.RunnableSpec
+		}},
+		{name: "prune", common: scheduleCommonOf(in.Prune), spec: func() (specValidator, *RunnableSpec) {
+			s := in.Prune.PruneSpec.DeepCopy()
+			return s, &s.RunnableSpec
+		}},
+		{name: "copy", common: scheduleCommonOf(in.Copy), spec: func() (specValidator, *RunnableSpec) {
+			s := in.Copy.CopySpec.DeepCopy()
+			return s, &s.RunnableSpec
+		}},
+		{name: "restore", common: scheduleCommonOf(in.Restore), spec: func() (specValidator, *RunnableSpec) {
+			s := in.Restore.RestoreSpec.DeepCopy()
+			return s, &s.RunnableSpec
+		}},
+		{name: "archive", common: scheduleCommonOf(in.Archive), spec: func() (specValidator, *RunnableSpec) {
+			s := in.Archive.ArchiveSpec.DeepCopy()
+			if s.RestoreSpec == nil {
+				return s, nil
+			}
+			return s, &s.RunnableSpec
+		}},
+	} {
+		if jb.common == nil {
+			continue
+		}
+		jobPath := path.Child(jb.name)
+		allErrs = append(allErrs, jb.common.Schedule.Validate(jobPath.Child("schedule"))...)
+
+		spec, runnable := jb.spec()
+		if runnable != nil && runnable.Backend != nil && in.Backend != nil && !runnable.Backend.HasStorageType() {
+			// The job only overrides parts of the backend, e.g. the password, and inherits the storage from the Schedule.
+			runnable.Backend = nil
+		}
+		allErrs = append(allErrs, spec.Validate(jobPath)...)
+	}
+	if in.Check != nil && in.Check.RotateReadDataSubset {
+		if _, _, ok := ReadDataPart(in.Check.ReadDataSubset); !ok {
+			allErrs = append(allErrs, field.Invalid(path.Child("check", "readDataSubset"), in.Check.ReadDataSubset, `has to be a part like "1/7" to be rotated`))
+		}
+	}
+	return allErrs
+}
+
+// ValidateStorage returns an error for each job definition that neither configures a storage type nor inherits one from the Schedule.
+// It only applies if neither a ClusterRepository nor the global repository of the operator completes the backends in the Schedule's namespace.
+func (in *ScheduleSpec) ValidateStorage(path *field.Path) field.ErrorList {
+	if in.RepositoryRef != nil || (in.Backend != nil && in.Backend.HasStorageType()) {
+		return nil
+	}
+	var allErrs field.ErrorList
+	for _, jb := range []struct {
+		name     string
+		schedule ScheduleSpecInterface
+	}{
+		{"backup", in.Backup},
+		{"check", in.Check},
+		{"prune", in.Prune},
+		{"copy", in.Copy},
+		{"restore", in.Restore},
+		{"archive", in.Archive},
+	} {
+		if IsNil(jb.schedule) {
+			continue
+		}
+		if archive, ok := jb.schedule.(*ArchiveSchedule); ok {
+			allErrs = append(allErrs, archive.ArchiveSpec.ValidateStorage(path.Child(jb.name))...)
+			continue
+		}
+		allErrs = append(allErrs, jb.schedule.GetRunnableSpec().ValidateStorage(path.Child(jb.name))...)
+	}
+	return allErrs
+}
+
+// scheduleCommonOf returns the ScheduleCommon of the given job schedule.
+// It returns an empty ScheduleCommon if the job is defined without schedule, and nil if the job isn't defined at all.
+func scheduleCommonOf(schedule ScheduleSpecInterface) *ScheduleCommon {
+	if IsNil(schedule) {
+		return nil
+	}
+	var common *ScheduleCommon
+	switch s := schedule.(type) {
+	case *BackupSchedule:
+		common = s.ScheduleCommon
+	case *CheckSchedule:
+		common = s.ScheduleCommon
+	case *PruneSchedule:
+		common = s.ScheduleCommon
+	case *CopySchedule:
+		common = s.ScheduleCommon
+	case *RestoreSchedule:
+		common = s.ScheduleCommon
+	case *ArchiveSchedule:
+		common = s.ScheduleCommon
+	}
+	if common == nil {
+		return &ScheduleCommon{}
+	}
+	return common
+}
@@ -0,0 +1,782 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestBackend_Validate(t *testing.T) {
+	tests := map[string]struct {
+		givenBackend   *Backend
+		expectedErrors []string
+	}{
+		"GivenS3Backend_ThenExpectNoError": {
+			givenBackend: &Backend{S3: &S3Spec{Bucket: "bucket"}},
+		},
+		"GivenNoStorage_ThenExpectNoError": {
+			givenBackend: &Backend{RepoPasswordSecretRef: newSecretRef("password")},
+		},
+		"GivenS3AndGCSBackend_ThenExpectForbiddenError": {
+			givenBackend:   &Backend{S3: &S3Spec{}, GCS: &GCSSpec{}},
+			expectedErrors: []string{"spec.backend: Forbidden: only one backend may be configured, found: gcs, s3"},
+		},
+		"GivenSFTPBackendWithHost_ThenExpectNoErrors": {
+			givenBackend: &Backend{SFTP: &SFTPSpec{Host: "backup.example.com", Path: "/srv/restic"}},
+		},
+		"GivenSFTPBackendWithoutHost_ThenExpectRequiredError": {
+			givenBackend:   &Backend{SFTP: &SFTPSpec{Path: "/srv/restic"}},
+			expectedErrors: []string{"spec.backend.sftp.host: Required value: the host of the SSH server is required"},
+		},
+		"GivenPasswordCommand_ThenExpectNoErrors": {
+			givenBackend: &Backend{S3: &S3Spec{Bucket: "bucket"}, RepoPasswordCommand: &PasswordCommandSpec{
+				Command: "/opt/k8up/password-command/get-password",
+				Image:   "registry.example.com/kms-client",
+				Volumes: []RunnableVolumeSpec{{Name: "token", Secret: &corev1.SecretVolumeSource{SecretName: "kms-token"}}},
+			}},
+		},
+		"GivenPasswordSecretAndPasswordFile_ThenExpectForbiddenError": {
+			givenBackend:   &Backend{S3: &S3Spec{Bucket: "bucket"}, RepoPasswordSecretRef: newSecretRef("password"), RepoPasswordFileSecretRef: newSecretRef("password")},
+			expectedErrors: []string{"spec.backend: Forbidden: only one source of the repository password may be configured, found: repoPasswordSecretRef, repoPasswordFileSecretRef"},
+		},
+		"GivenIncompletePasswordCommand_ThenExpectRequiredErrors": {
+			givenBackend: &Backend{S3: &S3Spec{Bucket: "bucket"}, RepoPasswordCommand: &PasswordCommandSpec{
+				InitCommand: []string{"cp", "/bin/kms-client", "/opt/k8up/password-command"},
+				Volumes:     []RunnableVolumeSpec{{Secret: &corev1.SecretVolumeSource{SecretName: "kms-token"}}},
+			}},
+			expectedErrors: []string{
+				"spec.backend.repoPasswordCommand.command: Required value: the command that prints the password is required",
+				"spec.backend.repoPasswordCommand.image: Required value: the image is required to run the init command",
+				"spec.backend.repoPasswordCommand.volumes[0].name: Required value: the name of the volume is required",
+			},
+		},
+		"GivenRestBackendWithoutCredentials_ThenExpectNoErrors": {
+			givenBackend: &Backend{Rest: &RestServerSpec{URL: "https://rest.example.com:8000/", Path: "app"}},
+		},
+		"GivenRestBackendWithoutScheme_ThenExpectInvalidError": {
+			givenBackend:   &Backend{Rest: &RestServerSpec{URL: "rest.example.com"}},
+			expectedErrors: []string{`spec.backend.rest.url: Invalid value: "rest.example.com": the URL has to start with http:// or https://`},
+		},
+		"GivenRestBackendWithBothPasswords_ThenExpectForbiddenError": {
+			givenBackend:   &Backend{Rest: &RestServerSpec{URL: "https://rest.example.com", PasswordSecretRef: newSecretRef("password"), PasswordSecretReg: newSecretRef("password")}},
+			expectedErrors: []string{"spec.backend.rest.passwordSecretReg: Forbidden: the deprecated passwordSecretReg can't be combined with passwordSecretRef"},
+		},
+		"GivenS3BackendWithRoleARN_ThenExpectNoErrors": {
+			givenBackend: &Backend{S3: &S3Spec{Bucket: "bucket", RoleARN: "arn:aws:iam::123456789012:role/k8up"}},
+		},
+		"GivenS3BackendWithRoleARNAndAccessKeys_ThenExpectForbiddenError": {
+			givenBackend:   &Backend{S3: &S3Spec{Bucket: "bucket", RoleARN: "arn:aws:iam::123456789012:role/k8up", AccessKeyIDSecretRef: newSecretRef("id")}},
+			expectedErrors: []string{"spec.backend.s3.roleARN: Forbidden: a role can't be combined with access keys"},
+		},
+		"GivenGCSBackendWithCredentialsAndAccessToken_ThenExpectForbiddenError": {
+			givenBackend:   &Backend{GCS: &GCSSpec{Bucket: "bucket", CredentialsSecretRef: newSecretRef("json"), AccessTokenSecretRef: newSecretRef("token")}},
+			expectedErrors: []string{"spec.backend.gcs.credentialsSecretRef: Forbidden: credentials can't be combined with an access token"},
+		},
+		"GivenAzureBackendWithSASTokenAndAccountKey_ThenExpectForbiddenError": {
+			givenBackend:   &Backend{Azure: &AzureSpec{Container: "backup", SASTokenSecretRef: newSecretRef("sas"), AccountKeySecretRef: newSecretRef("key")}},
+			expectedErrors: []string{"spec.backend.azure.sasTokenSecretRef: Forbidden: a SAS token can't be combined with an account key"},
+		},
+		"GivenCredentialsForOperations_ThenExpectNoErrors": {
+			givenBackend: &Backend{
+				S3: &S3Spec{Bucket: "bucket", AccessKeyIDSecretRef: newSecretRef("id")},
+				Credentials: &OperationCredentials{
+					Backup:      &CredentialSet{S3: &S3Spec{AccessKeyIDSecretRef: newSecretRef("backup-id")}},
+					Maintenance: &CredentialSet{RepoPasswordSecretRef: newSecretRef("maintenance-password")},
+				},
+			},
+		},
+		"GivenCredentialsThatChangeTheRepository_ThenExpectForbiddenError": {
+			givenBackend: &Backend{
+				S3:          &S3Spec{Bucket: "bucket"},
+				Credentials: &OperationCredentials{Backup: &CredentialSet{S3: &S3Spec{Bucket: "other"}}},
+			},
+			expectedErrors: []string{"spec.backend.credentials.backup: Forbidden: the credentials may not change the location of the repository"},
+		},
+		"GivenTLSOptionsWithReferences_ThenExpectNoErrors": {
+			givenBackend: &Backend{S3: &S3Spec{Bucket: "bucket"}, TLSOptions: &TLSOptions{
+				CACertSecretRef:     newSecretRef("ca.crt"),
+				ClientCertSecretRef: newSecretRef("tls.crt"),
+				ClientKeySecretRef:  newSecretRef("tls.key"),
+			}},
+		},
+		"GivenTLSOptionsWithPathAndReference_ThenExpectForbiddenError": {
+			givenBackend: &Backend{S3: &S3Spec{Bucket: "bucket"}, TLSOptions: &TLSOptions{
+				CACert:          "/mnt/ca/ca.crt",
+				CACertSecretRef: newSecretRef("ca.crt"),
+			}},
+			expectedErrors: []string{"spec.backend.tlsOptions.caCert: Forbidden: the path can't be combined with a reference"},
+		},
+		"GivenTLSOptionsWithClientCertOnly_ThenExpectRequiredError": {
+			givenBackend: &Backend{S3: &S3Spec{Bucket: "bucket"}, TLSOptions: &TLSOptions{
+				ClientCertSecretRef: newSecretRef("tls.crt"),
+			}},
+			expectedErrors: []string{"spec.backend.tlsOptions: Required value: clientCertSecretRef and clientKeySecretRef have to be configured together"},
+		},
+		"GivenServiceAccountWithoutName_ThenExpectRequiredError": {
+			givenBackend:   &Backend{S3: &S3Spec{Bucket: "bucket"}, ServiceAccount: &BackendServiceAccount{}},
+			expectedErrors: []string{"spec.backend.serviceAccount.name: Required value: the name of the ServiceAccount is required"},
+		},
+		"GivenSwiftBackendWithoutCredentials_ThenExpectNoErrors": {
+			givenBackend: &Backend{Swift: &SwiftSpec{Container: "backup"}},
+		},
+		"GivenSwiftBackendWithKeystoneV3_ThenExpectNoErrors": {
+			givenBackend: &Backend{Swift: &SwiftSpec{
+				Container:               "backup",
+				AuthURLSecretRef:        newSecretRef("url"),
+				UsernameSecretRef:       newSecretRef("user"),
+				PasswordSecretRef:       newSecretRef("password"),
+				UserDomainNameSecretRef: newSecretRef("domain"),
+				ProjectNameSecretRef:    newSecretRef("project"),
+			}},
+		},
+		"GivenSwiftBackendWithKeystoneV2_ThenExpectNoErrors": {
+			givenBackend: &Backend{Swift: &SwiftSpec{
+				Container:           "backup",
+				AuthURLSecretRef:    newSecretRef("url"),
+				UsernameSecretRef:   newSecretRef("user"),
+				PasswordSecretRef:   newSecretRef("password"),
+				TenantNameSecretRef: newSecretRef("tenant"),
+			}},
+		},
+		"GivenSwiftBackendWithApplicationCredential_ThenExpectNoErrors": {
+			givenBackend: &Backend{Swift: &SwiftSpec{
+				Container:                        "backup",
+				AuthURLSecretRef:                 newSecretRef("url"),
+				ApplicationCredentialIDSecretRef: newSecretRef("id"),
+				ApplicationCredentialSecretRef:   newSecretRef("secret"),
+			}},
+		},
+		"GivenSwiftBackendWithIncompleteApplicationCredential_ThenExpectRequiredErrors": {
+			givenBackend: &Backend{Swift: &SwiftSpec{
+				Container:                        "backup",
+				ApplicationCredentialIDSecretRef: newSecretRef("id"),
+			}},
+			expectedErrors: []string{
+				"spec.backend.swift.authURLSecretRef: Required value: the URL of the identity service is required",
+				"spec.backend.swift.applicationCredentialSecretRef: Required value: the secret of the application credential is required",
+			},
+		},
+		"GivenSwiftBackendWithApplicationCredentialAndPassword_ThenExpectForbiddenError": {
+			givenBackend: &Backend{Swift: &SwiftSpec{
+				Container:                        "backup",
+				AuthURLSecretRef:                 newSecretRef("url"),
+				PasswordSecretRef:                newSecretRef("password"),
+				ApplicationCredentialIDSecretRef: newSecretRef("id"),
+				ApplicationCredentialSecretRef:   newSecretRef("secret"),
+			}},
+			expectedErrors: []string{"spec.backend.swift: Forbidden: an application credential can't be combined with a user name and password"},
+		},
+		"GivenSwiftBackendWithMixedKeystoneVersions_ThenExpectForbiddenError": {
+			givenBackend: &Backend{Swift: &SwiftSpec{
+				Container:               "backup",
+				AuthURLSecretRef:        newSecretRef("url"),
+				UsernameSecretRef:       newSecretRef("user"),
+				PasswordSecretRef:       newSecretRef("password"),
+				TenantNameSecretRef:     newSecretRef("tenant"),
+				UserDomainNameSecretRef: newSecretRef("domain"),
+			}},
+			expectedErrors: []string{"spec.backend.swift: Forbidden: the Keystone v2 tenant can't be combined with the Keystone v3 domains and project"},
+		},
+		"GivenSwiftBackendWithPasswordOnly_ThenExpectRequiredErrors": {
+			givenBackend: &Backend{Swift: &SwiftSpec{
+				Container:         "backup",
+				AuthURLSecretRef:  newSecretRef("url"),
+				PasswordSecretRef: newSecretRef("password"),
+			}},
+			expectedErrors: []string{
+				"spec.backend.swift.usernameSecretRef: Required value: the user name is required without application credential",
+				"spec.backend.swift: Required value: either the Keystone v2 tenant or the Keystone v3 domains and project are required",
+			},
+		},
+		"GivenRcloneBackend_ThenExpectNoErrors": {
+			givenBackend: &Backend{Rclone: &RcloneSpec{Remote: "dropbox", Path: "backups", ConfigSecretRef: newSecretRef("rclone.conf")}},
+		},
+		"GivenRcloneBackendWithoutRemoteAndConfig_ThenExpectRequiredErrors": {
+			givenBackend: &Backend{Rclone: &RcloneSpec{Path: "backups"}},
+			expectedErrors: []string{
+				"spec.backend.rclone.remote: Required value: the name of the rclone remote is required",
+				"spec.backend.rclone.configSecretRef: Required value: the rclone configuration is required",
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			errs := tt.givenBackend.Validate(field.NewPath("spec", "backend"))
+			assertErrorList(t, tt.expectedErrors, errs)
+		})
+	}
+}
+
+func TestRunnableSpec_Validate_RepositoryRef(t *testing.T) {
+	tests := map[string]struct {
+		givenSpec      RunnableSpec
+		expectedErrors []string
+	}{
+		"GivenRepositoryRef_ThenExpectNoError": {
+			givenSpec: RunnableSpec{RepositoryRef: &corev1.LocalObjectReference{Name: "repo"}},
+		},
+		"GivenRepositoryRefWithoutName_ThenExpectRequiredError": {
+			givenSpec:      RunnableSpec{RepositoryRef: &corev1.LocalObjectReference{}},
+			expectedErrors: []string{"spec.repositoryRef.name: Required value: the name of the Repository has to be given"},
+		},
+		"GivenRepositoryRefAndBackend_ThenExpectForbiddenError": {
+			givenSpec: RunnableSpec{
+				RepositoryRef: &corev1.LocalObjectReference{Name: "repo"},
+				Backend:       &Backend{S3: &S3Spec{}},
+			},
+			expectedErrors: []string{"spec.backend: Forbidden: only one of backend or repositoryRef may be configured"},
+		},
+		"GivenBackendWithNamespacedPath_ThenExpectForbiddenError": {
+			givenSpec:      RunnableSpec{Backend: &Backend{Rest: &RestServerSpec{URL: "https://rest.example.com", NamespacedPath: true}}},
+			expectedErrors: []string{"spec.backend.rest.namespacedPath: Forbidden: only the backend of a ClusterRepository can append the namespace to the path"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			errs := tt.givenSpec.Validate(field.NewPath("spec"))
+			assertErrorList(t, tt.expectedErrors, errs)
+		})
+	}
+}
+
+func TestRunnableSpec_ValidateStorage(t *testing.T) {
+	tests := map[string]struct {
+		givenSpec      RunnableSpec
+		expectedErrors []string
+	}{
+		"GivenStorageType_ThenExpectNoError": {
+			givenSpec: RunnableSpec{Backend: &Backend{S3: &S3Spec{}}},
+		},
+		"GivenRepositoryRef_ThenExpectNoError": {
+			givenSpec: RunnableSpec{RepositoryRef: &corev1.LocalObjectReference{Name: "repo"}},
+		},
+		"GivenNoBackend_ThenExpectRequiredError": {
+			givenSpec:      RunnableSpec{},
+			expectedErrors: []string{"spec.backend: Required value: exactly one of azure, b2, gcs, local, rest, s3, swift, sftp, rclone has to be configured, no ClusterRepository or global repository applies to the namespace"},
+		},
+		"GivenBackendWithoutStorageType_ThenExpectRequiredError": {
+			givenSpec:      RunnableSpec{Backend: &Backend{RepoPasswordSecretRef: newSecretRef("password")}},
+			expectedErrors: []string{"spec.backend: Required value: exactly one of azure, b2, gcs, local, rest, s3, swift, sftp, rclone has to be configured, no ClusterRepository or global repository applies to the namespace"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			errs := tt.givenSpec.ValidateStorage(field.NewPath("spec"))
+			assertErrorList(t, tt.expectedErrors, errs)
+		})
+	}
+}
+
+func TestRepositorySpec_Validate(t *testing.T) {
+	errs := (&RepositorySpec{Backend: &Backend{S3: &S3Spec{Bucket: "bucket"}}}).Validate(field.NewPath("spec"))
+	assert.Empty(t, errs)
+
+	errs = (&RepositorySpec{Backend: &Backend{RepoPasswordSecretRef: newSecretRef("password")}}).Validate(field.NewPath("spec"))
+	assertErrorList(t, []string{"spec.backend: Required value: exactly one of azure, b2, gcs, local, rest, s3, swift, sftp, rclone has to be configured"}, errs)
+}
+
+func TestClusterRepositorySpec_Validate(t *testing.T) {
+	tests := map[string]struct {
+		givenSpec      ClusterRepositorySpec
+		expectedErrors []string
+	}{
+		"GivenS3Backend_ThenExpectNoError": {
+			givenSpec: ClusterRepositorySpec{
+				Backend:           &Backend{S3: &S3Spec{Bucket: "bucket"}},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+			},
+		},
+		"GivenRestBackendWithNamespacedPath_ThenExpectNoError": {
+			givenSpec: ClusterRepositorySpec{Backend: &Backend{Rest: &RestServerSpec{URL: "https://rest.example.com", NamespacedPath: true}}},
+		},
+		"GivenNoStorage_ThenExpectRequiredError": {
+			givenSpec:      ClusterRepositorySpec{Backend: &Backend{RepoPasswordSecretRef: newSecretRef("password")}},
+			expectedErrors: []string{"spec.backend: Required value: exactly one of azure, b2, gcs, local, rest, s3, swift, sftp, rclone has to be configured"},
+		},
+		"GivenInvalidNamespaceSelector_ThenExpectInvalidError": {
+			givenSpec: ClusterRepositorySpec{
+				Backend: &Backend{S3: &S3Spec{Bucket: "bucket"}},
+				NamespaceSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "team", Operator: "Matches"},
+				}},
+			},
+			expectedErrors: []string{`spec.namespaceSelector: Invalid value: {"matchExpressions":[{"key":"team","operator":"Matches"}]}: "Matches" is not a valid label selector operator`},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			errs := tt.givenSpec.Validate(field.NewPath("spec"))
+			assertErrorList(t, tt.expectedErrors, errs)
+		})
+	}
+}
+
+func TestRestoreSpec_Validate(t *testing.T) {
+	tests := map[string]struct {
+		givenSpec      RestoreSpec
+		expectedErrors []string
+	}{
+		"GivenS3RestoreMethod_ThenExpectNoError": {
+			givenSpec: RestoreSpec{RestoreMethod: &RestoreMethod{S3: &S3Spec{}}},
+		},
+		"GivenFolderRestoreMethod_ThenExpectNoError": {
+			givenSpec: RestoreSpec{RestoreMethod: &RestoreMethod{Folder: &FolderRestore{
+				PersistentVolumeClaimVolumeSource: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc"},
+			}}},
+		},
+		"GivenNoRestoreMethod_ThenExpectRequiredError": {
+			givenSpec:      RestoreSpec{},
+			expectedErrors: []string{"spec.restoreMethod: Required value: one of s3 or folder has to be configured"},
+		},
+		"GivenEmptyRestoreMethod_ThenExpectRequiredError": {
+			givenSpec:      RestoreSpec{RestoreMethod: &RestoreMethod{}},
+			expectedErrors: []string{"spec.restoreMethod: Required value: one of s3 or folder has to be configured"},
+		},
+		"GivenS3AndFolderRestoreMethod_ThenExpectForbiddenError": {
+			givenSpec:      RestoreSpec{RestoreMethod: &RestoreMethod{S3: &S3Spec{}, Folder: &FolderRestore{}}},
+			expectedErrors: []string{"spec.restoreMethod: Forbidden: only one of s3 or folder may be configured"},
+		},
+		"GivenFolderWithoutClaimName_ThenExpectRequiredError": {
+			givenSpec:      RestoreSpec{RestoreMethod: &RestoreMethod{Folder: &FolderRestore{}}},
+			expectedErrors: []string{"spec.restoreMethod.folder.claimName: Required value: the PVC to restore into has to be given"},
+		},
+		"GivenSnapshotRef_ThenExpectNoError": {
+			givenSpec: RestoreSpec{
+				RestoreMethod: &RestoreMethod{S3: &S3Spec{}},
+				SnapshotRef:   &corev1.LocalObjectReference{Name: "snapshot"},
+			},
+		},
+		"GivenSnapshotRefAndSnapshot_ThenExpectForbiddenError": {
+			givenSpec: RestoreSpec{
+				RestoreMethod: &RestoreMethod{S3: &S3Spec{}},
+				Snapshot:      "162e7a85",
+				SnapshotRef:   &corev1.LocalObjectReference{Name: "snapshot"},
+			},
+			expectedErrors: []string{"spec.snapshotRef: Forbidden: snapshot and snapshotRef can't be used together"},
+		},
+		"GivenSnapshotRefWithoutName_ThenExpectRequiredError": {
+			givenSpec: RestoreSpec{
+				RestoreMethod: &RestoreMethod{S3: &S3Spec{}},
+				SnapshotRef:   &corev1.LocalObjectReference{},
+			},
+			expectedErrors: []string{"spec.snapshotRef.name: Required value: the name of the snapshot is required"},
+		},
+		"GivenInvalidBackend_ThenExpectBackendError": {
+			givenSpec: RestoreSpec{
+				RunnableSpec:  RunnableSpec{Backend: &Backend{S3: &S3Spec{}, B2: &B2Spec{}}},
+				RestoreMethod: &RestoreMethod{S3: &S3Spec{}},
+			},
+			expectedErrors: []string{"spec.backend: Forbidden: only one backend may be configured, found: b2, s3"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			errs := tt.givenSpec.Validate(field.NewPath("spec"))
+			assertErrorList(t, tt.expectedErrors, errs)
+		})
+	}
+}
+
+func TestCheckSpec_Validate(t *testing.T) {
+	tests := map[string]struct {
+		givenSpec      CheckSpec
+		expectedErrors []string
+	}{
+		"GivenReadData_ThenExpectNoError": {
+			givenSpec: CheckSpec{ReadData: true},
+		},
+		"GivenPart_ThenExpectNoError": {
+			givenSpec: CheckSpec{ReadDataSubset: "1/7"},
+		},
+		"GivenPercentage_ThenExpectNoError": {
+			givenSpec: CheckSpec{ReadDataSubset: "2.5%"},
+		},
+		"GivenSize_ThenExpectNoError": {
+			givenSpec: CheckSpec{ReadDataSubset: "500M"},
+		},
+		"GivenReadDataAndSubset_ThenExpectForbiddenError": {
+			givenSpec:      CheckSpec{ReadData: true, ReadDataSubset: "1/7"},
+			expectedErrors: []string{"spec.readDataSubset: Forbidden: readData and readDataSubset can't be used together"},
+		},
+		"GivenUnknownSubset_ThenExpectInvalidError": {
+			givenSpec:      CheckSpec{ReadDataSubset: "half"},
+			expectedErrors: []string{`spec.readDataSubset: Invalid value: "half": has to be a part like "1/7", a percentage like "10%" or a size like "500M"`},
+		},
+		"GivenPartAfterLastPart_ThenExpectInvalidError": {
+			givenSpec:      CheckSpec{ReadDataSubset: "8/7"},
+			expectedErrors: []string{`spec.readDataSubset: Invalid value: "8/7": the part has to be between 1 and the number of parts`},
+		},
+		"GivenZeroPercent_ThenExpectInvalidError": {
+			givenSpec:      CheckSpec{ReadDataSubset: "0%"},
+			expectedErrors: []string{`spec.readDataSubset: Invalid value: "0%": the percentage has to be greater than 0 and at most 100`},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			errs := tt.givenSpec.Validate(field.NewPath("spec"))
+			assertErrorList(t, tt.expectedErrors, errs)
+		})
+	}
+}
+
+func TestRepositoryKeySpec_Validate(t *testing.T) {
+	passwordRef := func(name, key string) *corev1.SecretKeySelector {
+		return &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Key: key}
+	}
+	tests := map[string]struct {
+		givenSpec      RepositoryKeySpec
+		expectedErrors []string
+	}{
+		"GivenNewPassword_ThenExpectNoError": {
+			givenSpec: RepositoryKeySpec{
+				RunnableSpec:         RunnableSpec{Backend: &Backend{S3: &S3Spec{Bucket: "bucket"}, RepoPasswordSecretRef: passwordRef("repo", "password")}},
+				NewPasswordSecretRef: passwordRef("repo", "new-password"),
+			},
+		},
+		"GivenNewPasswordAndRepositoryRef_ThenExpectNoError": {
+			givenSpec: RepositoryKeySpec{
+				RunnableSpec:         RunnableSpec{RepositoryRef: &corev1.LocalObjectReference{Name: "repo"}},
+				NewPasswordSecretRef: passwordRef("repo", "new-password"),
+			},
+		},
+		"GivenNoNewPassword_ThenExpectRequiredError": {
+			givenSpec:      RepositoryKeySpec{RunnableSpec: RunnableSpec{RepositoryRef: &corev1.LocalObjectReference{Name: "repo"}}},
+			expectedErrors: []string{"spec.newPasswordSecretRef: Required value: the Secret of the new password has to be given"},
+		},
+		"GivenNewPasswordWithoutKey_ThenExpectRequiredError": {
+			givenSpec: RepositoryKeySpec{
+				RunnableSpec:         RunnableSpec{RepositoryRef: &corev1.LocalObjectReference{Name: "repo"}},
+				NewPasswordSecretRef: passwordRef("repo", ""),
+			},
+			expectedErrors: []string{"spec.newPasswordSecretRef: Required value: the Secret of the new password has to be given"},
+		},
+		"GivenCurrentPasswordAsNewPassword_ThenExpectInvalidError": {
+			givenSpec: RepositoryKeySpec{
+				RunnableSpec:         RunnableSpec{Backend: &Backend{S3: &S3Spec{Bucket: "bucket"}, RepoPasswordSecretRef: passwordRef("repo", "password")}},
+				NewPasswordSecretRef: passwordRef("repo", "password"),
+			},
+			expectedErrors: []string{`spec.newPasswordSecretRef: Invalid value: "repo": the new password has to be different from the repoPasswordSecretRef of the backend`},
+		},
+		"GivenCurrentPasswordFileAsNewPassword_ThenExpectInvalidError": {
+			givenSpec: RepositoryKeySpec{
+				RunnableSpec:         RunnableSpec{Backend: &Backend{S3: &S3Spec{Bucket: "bucket"}, RepoPasswordFileSecretRef: passwordRef("repo", "password")}},
+				NewPasswordSecretRef: passwordRef("repo", "password"),
+			},
+			expectedErrors: []string{`spec.newPasswordSecretRef: Invalid value: "repo": the new password has to be different from the repoPasswordFileSecretRef of the backend`},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			errs := tt.givenSpec.Validate(field.NewPath("spec"))
+			assertErrorList(t, tt.expectedErrors, errs)
+		})
+	}
+}
+
+func TestCopySpec_Validate(t *testing.T) {
+	source := RunnableSpec{Backend: &Backend{S3: &S3Spec{Endpoint: "http://minio:9000", Bucket: "backups", AccessKeyIDSecretRef: newSecretRef("minio")}}}
+	tests := map[string]struct {
+		givenSpec      CopySpec
+		expectedErrors []string
+	}{
+		"GivenOtherBucket_ThenExpectNoError": {
+			givenSpec: CopySpec{
+				RunnableSpec: source,
+				Destination:  &Backend{S3: &S3Spec{Endpoint: "http://minio:9000", Bucket: "copy", AccessKeyIDSecretRef: newSecretRef("minio")}},
+			},
+		},
+		"GivenRepositoryRef_ThenExpectNoError": {
+			givenSpec: CopySpec{
+				RunnableSpec: RunnableSpec{RepositoryRef: &corev1.LocalObjectReference{Name: "repo"}},
+				Destination:  &Backend{Local: &LocalSpec{MountPath: "/data/copy"}},
+			},
+		},
+		"GivenNoDestination_ThenExpectRequiredError": {
+			givenSpec:      CopySpec{RunnableSpec: source},
+			expectedErrors: []string{"spec.destination: Required value: exactly one of azure, b2, gcs, local, rest, s3, swift, sftp, rclone has to be configured"},
+		},
+		"GivenSourceAsDestination_ThenExpectInvalidError": {
+			givenSpec: CopySpec{
+				RunnableSpec: source,
+				Destination:  &Backend{S3: &S3Spec{Endpoint: "http://minio:9000", Bucket: "backups", AccessKeyIDSecretRef: newSecretRef("minio")}},
+			},
+			expectedErrors: []string{`spec.destination: Invalid value: "s3:http://minio:9000/backups": the destination has to be another repository than the source`},
+		},
+		"GivenSourceWithRoleARN_ThenExpectForbiddenError": {
+			givenSpec: CopySpec{
+				RunnableSpec: RunnableSpec{Backend: &Backend{S3: &S3Spec{Endpoint: "http://minio:9000", Bucket: "backups", RoleARN: "arn:aws:iam::123456789012:role/k8up"}}},
+				Destination:  &Backend{Local: &LocalSpec{MountPath: "/data/copy"}},
+			},
+			expectedErrors: []string{"spec.backend.s3.roleARN: Forbidden: isn't supported for the source of a copy"},
+		},
+		"GivenDifferentAccessKeys_ThenExpectForbiddenError": {
+			givenSpec: CopySpec{
+				RunnableSpec: source,
+				Destination:  &Backend{S3: &S3Spec{Endpoint: "http://minio:9000", Bucket: "copy", AccessKeyIDSecretRef: newSecretRef("offsite")}},
+			},
+			expectedErrors: []string{"spec.destination: Forbidden: the credentials have to be the same as those of the source, restic reads both from the same variables: AWS_ACCESS_KEY_ID"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			errs := tt.givenSpec.Validate(field.NewPath("spec"))
+			assertErrorList(t, tt.expectedErrors, errs)
+		})
+	}
+}
+
+func TestMaintenanceSpec_Validate(t *testing.T) {
+	pack := "9b5bd2ba3f1e3b2a36b6fb4e8c5bd9a3c1a7e3c2f4b1a0d9e8c7b6a5f4e3d2c1"
+	tests := map[string]struct {
+		givenSpec      MaintenanceSpec
+		expectedErrors []string
+	}{
+		"GivenRepairIndexReadingAllPacks_ThenExpectNoError": {
+			givenSpec: MaintenanceSpec{Operation: MaintenanceRepairIndex, ReadAllPacks: true},
+		},
+		"GivenRepairPacks_ThenExpectNoError": {
+			givenSpec: MaintenanceSpec{Operation: MaintenanceRepairPacks, Packs: []string{pack}},
+		},
+		"GivenNoOperation_ThenExpectRequiredError": {
+			expectedErrors: []string{"spec.operation: Required value: a maintenance operation has to be given"},
+		},
+		"GivenUnknownOperation_ThenExpectNotSupportedError": {
+			givenSpec:      MaintenanceSpec{Operation: "prune"},
+			expectedErrors: []string{`spec.operation: Unsupported value: "prune": supported values: "rebuild-index", "repair-index", "repair-snapshots", "repair-packs", "migrate-upgrade-repo-v2", "unlock-remove-all"`},
+		},
+		"GivenOptionsOfOtherOperations_ThenExpectForbiddenErrors": {
+			givenSpec: MaintenanceSpec{Operation: MaintenanceUnlockRemoveAll, ReadAllPacks: true, Forget: true, Packs: []string{pack}},
+			expectedErrors: []string{
+				"spec.readAllPacks: Forbidden: only applies to the rebuild-index and repair-index operations",
+				"spec.forget: Forbidden: only applies to the repair-snapshots operation",
+				"spec.packs: Forbidden: only applies to the repair-packs operation",
+			},
+		},
+		"GivenRepairPacksWithoutPacks_ThenExpectRequiredError": {
+			givenSpec:      MaintenanceSpec{Operation: MaintenanceRepairPacks},
+			expectedErrors: []string{"spec.packs: Required value: the IDs of the packs have to be given to repair packs"},
+		},
+		"GivenShortPackID_ThenExpectInvalidError": {
+			givenSpec:      MaintenanceSpec{Operation: MaintenanceRepairPacks, Packs: []string{"9b5bd2ba"}},
+			expectedErrors: []string{`spec.packs[0]: Invalid value: "9b5bd2ba": has to be the complete ID of a pack, 64 hexadecimal characters`},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			errs := tt.givenSpec.Validate(field.NewPath("spec"))
+			assertErrorList(t, tt.expectedErrors, errs)
+		})
+	}
+}
+
+func TestRetentionPolicy_Validate(t *testing.T) {
+	tests := map[string]struct {
+		givenPolicy    RetentionPolicy
+		expectedErrors []string
+	}{
+		"GivenEmptyPolicy_ThenExpectNoError": {},
+		"GivenPositiveValues_ThenExpectNoError": {
+			givenPolicy: RetentionPolicy{KeepLast: 5, KeepDaily: 14},
+		},
+		"GivenNegativeValues_ThenExpectInvalidErrors": {
+			givenPolicy: RetentionPolicy{KeepLast: -1, KeepYearly: -2},
+			expectedErrors: []string{
+				"spec.retention.keepLast: Invalid value: -1: must not be negative",
+				"spec.retention.keepYearly: Invalid value: -2: must not be negative",
+			},
+		},
+		"GivenKeepWithinDurations_ThenExpectNoError": {
+			givenPolicy: RetentionPolicy{KeepWithin: "2y5m7d3h", KeepWithinDaily: "30d", GroupBy: "host,tags"},
+		},
+		"GivenInvalidKeepWithinDurations_ThenExpectInvalidErrors": {
+			givenPolicy: RetentionPolicy{KeepWithinHourly: "1w", KeepWithinWeekly: "-1d"},
+			expectedErrors: []string{
+				`spec.retention.keepWithinHourly: Invalid value: "1w": has to be a duration of years, months, days and hours like "2y5m7d3h"`,
+				`spec.retention.keepWithinWeekly: Invalid value: "-1d": has to be a duration of years, months, days and hours like "2y5m7d3h"`,
+			},
+		},
+		"GivenUnknownGroupBy_ThenExpectNotSupportedError": {
+			givenPolicy: RetentionPolicy{GroupBy: "host,namespace"},
+			expectedErrors: []string{
+				`spec.retention.groupBy: Unsupported value: "namespace": supported values: "host", "paths", "tags"`,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			errs := tt.givenPolicy.Validate(field.NewPath("spec", "retention"))
+			assertErrorList(t, tt.expectedErrors, errs)
+		})
+	}
+}
+
+func TestScheduleDefinition_Validate(t *testing.T) {
+	tests := map[string]struct {
+		input         ScheduleDefinition
+		expectInvalid bool
+	}{
+		"standard cron":    {input: "*/5 * * * *"},
+		"@daily":           {input: "@daily"},
+		"@every":           {input: "@every 1h"},
+		"@hourly-random":   {input: "@hourly-random"},
+		"@daily-random":    {input: "@daily-random"},
+		"@weekly-random":   {input: "@weekly-random"},
+		"@annually-random": {input: "@annually-random"},
+		"empty":            {input: "", expectInvalid: true},
+		"six fields":       {input: "0 */5 * * * *", expectInvalid: true},
+		"out of range":     {input: "61 * * * *", expectInvalid: true},
+		"unknown":          {input: "@fortnightly", expectInvalid: true},
+		"unknown random":   {input: "@fortnightly-random", expectInvalid: true},
+		"@-random":         {input: "@-random", expectInvalid: true},
+		"@every random":    {input: "@every 1h-random", expectInvalid: true},
+		"@midnight-random": {input: "@midnight-random", expectInvalid: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			errs := tc.input.Validate(field.NewPath("schedule"))
+			assert.Equal(t, tc.expectInvalid, len(errs) > 0, errs.ToAggregate())
+		})
+	}
+}
+
+func TestScheduleSpec_Validate(t *testing.T) {
+	tests := map[string]struct {
+		givenSpec      ScheduleSpec
+		expectedErrors []string
+	}{
+		"GivenValidSchedule_ThenExpectNoError": {
+			givenSpec: ScheduleSpec{
+				Backend: &Backend{S3: &S3Spec{}},
+				Backup:  &BackupSchedule{ScheduleCommon: &ScheduleCommon{Schedule: "@daily-random"}},
+				Prune: &PruneSchedule{
+					ScheduleCommon: &ScheduleCommon{Schedule: "0 1 * * *"},
+					PruneSpec:      PruneSpec{Retention: RetentionPolicy{KeepDaily: 14}},
+				},
+			},
+		},
+		"GivenJobOverridingOnlyPassword_ThenExpectScheduleBackendToBeUsed": {
+			givenSpec: ScheduleSpec{
+				Backend: &Backend{S3: &S3Spec{}},
+				Check: &CheckSchedule{
+					ScheduleCommon: &ScheduleCommon{Schedule: "@weekly"},
+					CheckSpec:      CheckSpec{RunnableSpec: RunnableSpec{Backend: &Backend{RepoPasswordSecretRef: newSecretRef("password")}}},
+				},
+			},
+		},
+		"GivenCopyWithoutDestination_ThenExpectRequiredError": {
+			givenSpec: ScheduleSpec{
+				Backend: &Backend{S3: &S3Spec{Bucket: "backups"}},
+				Copy:    &CopySchedule{ScheduleCommon: &ScheduleCommon{Schedule: "@daily"}},
+			},
+			expectedErrors: []string{"spec.copy.destination: Required value: exactly one of azure, b2, gcs, local, rest, s3, swift, sftp, rclone has to be configured"},
+		},
+		"GivenJobWithoutSchedule_ThenExpectRequiredError": {
+			givenSpec: ScheduleSpec{
+				Check: &CheckSchedule{},
+			},
+			expectedErrors: []string{"spec.check.schedule: Required value: a schedule has to be given"},
+		},
+		"GivenInvalidJobs_ThenExpectAllErrors": {
+			givenSpec: ScheduleSpec{
+				Backup: &BackupSchedule{
+					ScheduleCommon: &ScheduleCommon{Schedule: "@daily"},
+					BackupSpec:     BackupSpec{RunnableSpec: RunnableSpec{Backend: &Backend{S3: &S3Spec{}, Rest: &RestServerSpec{}}}},
+				},
+				Prune: &PruneSchedule{
+					ScheduleCommon: &ScheduleCommon{Schedule: "every day"},
+					PruneSpec:      PruneSpec{Retention: RetentionPolicy{KeepDaily: -14}},
+				},
+				Restore: &RestoreSchedule{ScheduleCommon: &ScheduleCommon{Schedule: "@daily"}},
+			},
+			expectedErrors: []string{
+				"spec.backup.backend: Forbidden: only one backend may be configured, found: rest, s3",
+				"spec.prune.schedule: Invalid value: \"every day\": expected exactly 5 fields, found 2: [every day]",
+				"spec.prune.retention.keepDaily: Invalid value: -14: must not be negative",
+				"spec.restore.restoreMethod: Required value: one of s3 or folder has to be configured",
+			},
+		},
+		"GivenRotatedPart_ThenExpectNoError": {
+			givenSpec: ScheduleSpec{
+				Backend: &Backend{S3: &S3Spec{}},
+				Check: &CheckSchedule{
+					ScheduleCommon:       &ScheduleCommon{Schedule: "@weekly"},
+					CheckSpec:            CheckSpec{ReadDataSubset: "1/7"},
+					RotateReadDataSubset: true,
+				},
+			},
+		},
+		"GivenRotatedPercentage_ThenExpectInvalidError": {
+			givenSpec: ScheduleSpec{
+				Backend: &Backend{S3: &S3Spec{}},
+				Check: &CheckSchedule{
+					ScheduleCommon:       &ScheduleCommon{Schedule: "@weekly"},
+					CheckSpec:            CheckSpec{ReadDataSubset: "10%"},
+					RotateReadDataSubset: true,
+				},
+			},
+			expectedErrors: []string{`spec.check.readDataSubset: Invalid value: "10%": has to be a part like "1/7" to be rotated`},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			errs := tt.givenSpec.Validate(field.NewPath("spec"))
+			assertErrorList(t, tt.expectedErrors, errs)
+		})
+	}
+}
+
+func TestScheduleSpec_ValidateStorage(t *testing.T) {
+	tests := map[string]struct {
+		givenSpec      ScheduleSpec
+		expectedErrors []string
+	}{
+		"GivenScheduleBackend_ThenExpectNoError": {
+			givenSpec: ScheduleSpec{
+				Backend: &Backend{S3: &S3Spec{}},
+				Backup:  &BackupSchedule{ScheduleCommon: &ScheduleCommon{Schedule: "@daily"}},
+			},
+		},
+		"GivenScheduleRepositoryRef_ThenExpectNoError": {
+			givenSpec: ScheduleSpec{
+				RepositoryRef: &corev1.LocalObjectReference{Name: "repo"},
+				Backup:        &BackupSchedule{ScheduleCommon: &ScheduleCommon{Schedule: "@daily"}},
+			},
+		},
+		"GivenJobsWithoutStorage_ThenExpectRequiredErrorForEach": {
+			givenSpec: ScheduleSpec{
+				Backup: &BackupSchedule{
+					ScheduleCommon: &ScheduleCommon{Schedule: "@daily"},
+					BackupSpec:     BackupSpec{RunnableSpec: RunnableSpec{Backend: &Backend{Local: &LocalSpec{}}}},
+				},
+				Check:   &CheckSchedule{ScheduleCommon: &ScheduleCommon{Schedule: "@weekly"}},
+				Archive: &ArchiveSchedule{ScheduleCommon: &ScheduleCommon{Schedule: "@monthly"}},
+			},
+			expectedErrors: []string{
+				"spec.check.backend: Required value: exactly one of azure, b2, gcs, local, rest, s3, swift, sftp, rclone has to be configured, no ClusterRepository or global repository applies to the namespace",
+				"spec.archive.backend: Required value: exactly one of azure, b2, gcs, local, rest, s3, swift, sftp, rclone has to be configured, no ClusterRepository or global repository applies to the namespace",
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			errs := tt.givenSpec.ValidateStorage(field.NewPath("spec"))
+			assertErrorList(t, tt.expectedErrors, errs)
+		})
+	}
+}
+
+func assertErrorList(t *testing.T, expected []string, actual field.ErrorList) {
+	t.Helper()
+	messages := make([]string, 0, len(actual))
+	for _, err := range actual {
+		messages = append(messages, err.Error())
+	}
+	if len(expected) == 0 {
+		assert.Empty(t, messages)
+		return
+	}
+	assert.Equal(t, expected, messages)
+}
@@ -123,6 +123,11 @@ func (in *AzureSpec) DeepCopyInto(out *AzureSpec) {
 		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SASTokenSecretRef != nil {
+		in, out := &in.SASTokenSecretRef, &out.SASTokenSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureSpec.
@@ -168,6 +173,16 @@ func (in *Backend) DeepCopyInto(out *Backend) {
 		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RepoPasswordFileSecretRef != nil {
+		in, out := &in.RepoPasswordFileSecretRef, &out.RepoPasswordFileSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RepoPasswordCommand != nil {
+		in, out := &in.RepoPasswordCommand, &out.RepoPasswordCommand
+		*out = new(PasswordCommandSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.EnvFrom != nil {
 		in, out := &in.EnvFrom, &out.EnvFrom
 		*out = make([]corev1.EnvFromSource, len(*in))
@@ -198,7 +213,7 @@ func (in *Backend) DeepCopyInto(out *Backend) {
 	if in.Swift != nil {
 		in, out := &in.Swift, &out.Swift
 		*out = new(SwiftSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.B2 != nil {
 		in, out := &in.B2, &out.B2
@@ -210,10 +225,20 @@ func (in *Backend) DeepCopyInto(out *Backend) {
 		*out = new(RestServerSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SFTP != nil {
+		in, out := &in.SFTP, &out.SFTP
+		*out = new(SFTPSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rclone != nil {
+		in, out := &in.Rclone, &out.Rclone
+		*out = new(RcloneSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.TLSOptions != nil {
 		in, out := &in.TLSOptions, &out.TLSOptions
 		*out = new(TLSOptions)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.VolumeMounts != nil {
 		in, out := &in.VolumeMounts, &out.VolumeMounts
@@ -226,6 +251,16 @@ func (in *Backend) DeepCopyInto(out *Backend) {
 			}
 		}
 	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(OperationCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(BackendServiceAccount)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Backend.
@@ -238,6 +273,28 @@ func (in *Backend) DeepCopy() *Backend {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendServiceAccount) DeepCopyInto(out *BackendServiceAccount) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendServiceAccount.
+func (in *BackendServiceAccount) DeepCopy() *BackendServiceAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendServiceAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Backup) DeepCopyInto(out *Backup) {
 	*out = *in
@@ -297,6 +354,60 @@ func (in *BackupList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupResults) DeepCopyInto(out *BackupResults) {
+	*out = *in
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]VolumeBackupStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StdinBackups != nil {
+		in, out := &in.StdinBackups, &out.StdinBackups
+		*out = make([]StdinBackupStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupResults.
+func (in *BackupResults) DeepCopy() *BackupResults {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupResults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRunStatus) DeepCopyInto(out *BackupRunStatus) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.FailedPaths != nil {
+		in, out := &in.FailedPaths, &out.FailedPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRunStatus.
+func (in *BackupRunStatus) DeepCopy() *BackupRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupSchedule) DeepCopyInto(out *BackupSchedule) {
 	*out = *in
@@ -361,6 +472,23 @@ func (in *BackupSpec) DeepCopy() *BackupSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	in.BackupResults.DeepCopyInto(&out.BackupResults)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupStatus.
+func (in *BackupStatus) DeepCopy() *BackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupTemplate) DeepCopyInto(out *BackupTemplate) {
 	*out = *in
@@ -446,6 +574,31 @@ func (in *CheckList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckResults) DeepCopyInto(out *CheckResults) {
+	*out = *in
+	if in.Successful != nil {
+		in, out := &in.Successful, &out.Successful
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DamagedPacks != nil {
+		in, out := &in.DamagedPacks, &out.DamagedPacks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckResults.
+func (in *CheckResults) DeepCopy() *CheckResults {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckResults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CheckSchedule) DeepCopyInto(out *CheckSchedule) {
 	*out = *in
@@ -499,221 +652,978 @@ func (in *CheckSpec) DeepCopy() *CheckSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EffectiveSchedule) DeepCopyInto(out *EffectiveSchedule) {
+func (in *CheckStatus) DeepCopyInto(out *CheckStatus) {
 	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	in.CheckResults.DeepCopyInto(&out.CheckResults)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EffectiveSchedule.
-func (in *EffectiveSchedule) DeepCopy() *EffectiveSchedule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckStatus.
+func (in *CheckStatus) DeepCopy() *CheckStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(EffectiveSchedule)
+	out := new(CheckStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Env) DeepCopyInto(out *Env) {
+func (in *ClusterRepository) DeepCopyInto(out *ClusterRepository) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Env.
-func (in *Env) DeepCopy() *Env {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRepository.
+func (in *ClusterRepository) DeepCopy() *ClusterRepository {
 	if in == nil {
 		return nil
 	}
-	out := new(Env)
+	out := new(ClusterRepository)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterRepository) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FolderRestore) DeepCopyInto(out *FolderRestore) {
+func (in *ClusterRepositoryList) DeepCopyInto(out *ClusterRepositoryList) {
 	*out = *in
-	if in.PersistentVolumeClaimVolumeSource != nil {
-		in, out := &in.PersistentVolumeClaimVolumeSource, &out.PersistentVolumeClaimVolumeSource
-		*out = new(corev1.PersistentVolumeClaimVolumeSource)
-		**out = **in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterRepository, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderRestore.
-func (in *FolderRestore) DeepCopy() *FolderRestore {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRepositoryList.
+func (in *ClusterRepositoryList) DeepCopy() *ClusterRepositoryList {
 	if in == nil {
 		return nil
 	}
-	out := new(FolderRestore)
+	out := new(ClusterRepositoryList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterRepositoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCSSpec) DeepCopyInto(out *GCSSpec) {
+func (in *ClusterRepositorySpec) DeepCopyInto(out *ClusterRepositorySpec) {
 	*out = *in
-	if in.ProjectIDSecretRef != nil {
-		in, out := &in.ProjectIDSecretRef, &out.ProjectIDSecretRef
-		*out = new(corev1.SecretKeySelector)
+	if in.Backend != nil {
+		in, out := &in.Backend, &out.Backend
+		*out = new(Backend)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.AccessTokenSecretRef != nil {
-		in, out := &in.AccessTokenSecretRef, &out.AccessTokenSecretRef
-		*out = new(corev1.SecretKeySelector)
+	if in.RestoreS3 != nil {
+		in, out := &in.RestoreS3, &out.RestoreS3
+		*out = new(S3Spec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResticOptions != nil {
+		in, out := &in.ResticOptions, &out.ResticOptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCSSpec.
-func (in *GCSSpec) DeepCopy() *GCSSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRepositorySpec.
+func (in *ClusterRepositorySpec) DeepCopy() *ClusterRepositorySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GCSSpec)
+	out := new(ClusterRepositorySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocalSpec) DeepCopyInto(out *LocalSpec) {
+func (in *Copy) DeepCopyInto(out *Copy) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalSpec.
-func (in *LocalSpec) DeepCopy() *LocalSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Copy.
+func (in *Copy) DeepCopy() *Copy {
 	if in == nil {
 		return nil
 	}
-	out := new(LocalSpec)
+	out := new(Copy)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Copy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Pod) DeepCopyInto(out *Pod) {
+func (in *CopyList) DeepCopyInto(out *CopyList) {
 	*out = *in
-	in.PodTemplateSpec.DeepCopyInto(&out.PodTemplateSpec)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Copy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pod.
-func (in *Pod) DeepCopy() *Pod {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CopyList.
+func (in *CopyList) DeepCopy() *CopyList {
 	if in == nil {
 		return nil
 	}
-	out := new(Pod)
+	out := new(CopyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CopyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodConfig) DeepCopyInto(out *PodConfig) {
+func (in *CopyResults) DeepCopyInto(out *CopyResults) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	if in.SnapshotsCopied != nil {
+		in, out := &in.SnapshotsCopied, &out.SnapshotsCopied
+		*out = new(int)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodConfig.
-func (in *PodConfig) DeepCopy() *PodConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CopyResults.
+func (in *CopyResults) DeepCopy() *CopyResults {
 	if in == nil {
 		return nil
 	}
-	out := new(PodConfig)
+	out := new(CopyResults)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PodConfig) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CopySchedule) DeepCopyInto(out *CopySchedule) {
+	*out = *in
+	in.CopySpec.DeepCopyInto(&out.CopySpec)
+	if in.ScheduleCommon != nil {
+		in, out := &in.ScheduleCommon, &out.ScheduleCommon
+		*out = new(ScheduleCommon)
+		**out = **in
 	}
-	return nil
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodConfigList) DeepCopyInto(out *PodConfigList) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]PodConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CopySchedule.
+func (in *CopySchedule) DeepCopy() *CopySchedule {
+	if in == nil {
+		return nil
 	}
+	out := new(CopySchedule)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodConfigList.
-func (in *PodConfigList) DeepCopy() *PodConfigList {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CopySpec) DeepCopyInto(out *CopySpec) {
+	*out = *in
+	in.RunnableSpec.DeepCopyInto(&out.RunnableSpec)
+	if in.Destination != nil {
+		in, out := &in.Destination, &out.Destination
+		*out = new(Backend)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailedJobsHistoryLimit != nil {
+		in, out := &in.FailedJobsHistoryLimit, &out.FailedJobsHistoryLimit
+		*out = new(int)
+		**out = **in
+	}
+	if in.SuccessfulJobsHistoryLimit != nil {
+		in, out := &in.SuccessfulJobsHistoryLimit, &out.SuccessfulJobsHistoryLimit
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CopySpec.
+func (in *CopySpec) DeepCopy() *CopySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CopySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CopyStatus) DeepCopyInto(out *CopyStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	in.CopyResults.DeepCopyInto(&out.CopyResults)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CopyStatus.
+func (in *CopyStatus) DeepCopy() *CopyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CopyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialSet) DeepCopyInto(out *CredentialSet) {
+	*out = *in
+	if in.RepoPasswordSecretRef != nil {
+		in, out := &in.RepoPasswordSecretRef, &out.RepoPasswordSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]corev1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3Spec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCS != nil {
+		in, out := &in.GCS, &out.GCS
+		*out = new(GCSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Swift != nil {
+		in, out := &in.Swift, &out.Swift
+		*out = new(SwiftSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.B2 != nil {
+		in, out := &in.B2, &out.B2
+		*out = new(B2Spec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rest != nil {
+		in, out := &in.Rest, &out.Rest
+		*out = new(RestServerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SFTP != nil {
+		in, out := &in.SFTP, &out.SFTP
+		*out = new(SFTPSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rclone != nil {
+		in, out := &in.Rclone, &out.Rclone
+		*out = new(RcloneSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialSet.
+func (in *CredentialSet) DeepCopy() *CredentialSet {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EffectiveSchedule) DeepCopyInto(out *EffectiveSchedule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EffectiveSchedule.
+func (in *EffectiveSchedule) DeepCopy() *EffectiveSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(EffectiveSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Env) DeepCopyInto(out *Env) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Env.
+func (in *Env) DeepCopy() *Env {
+	if in == nil {
+		return nil
+	}
+	out := new(Env)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FolderRestore) DeepCopyInto(out *FolderRestore) {
+	*out = *in
+	if in.PersistentVolumeClaimVolumeSource != nil {
+		in, out := &in.PersistentVolumeClaimVolumeSource, &out.PersistentVolumeClaimVolumeSource
+		*out = new(corev1.PersistentVolumeClaimVolumeSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderRestore.
+func (in *FolderRestore) DeepCopy() *FolderRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(FolderRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCSSpec) DeepCopyInto(out *GCSSpec) {
+	*out = *in
+	if in.ProjectIDSecretRef != nil {
+		in, out := &in.ProjectIDSecretRef, &out.ProjectIDSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AccessTokenSecretRef != nil {
+		in, out := &in.AccessTokenSecretRef, &out.AccessTokenSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCSSpec.
+func (in *GCSSpec) DeepCopy() *GCSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalSpec) DeepCopyInto(out *LocalSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalSpec.
+func (in *LocalSpec) DeepCopy() *LocalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Maintenance) DeepCopyInto(out *Maintenance) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Maintenance.
+func (in *Maintenance) DeepCopy() *Maintenance {
+	if in == nil {
+		return nil
+	}
+	out := new(Maintenance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Maintenance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceList) DeepCopyInto(out *MaintenanceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Maintenance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceList.
+func (in *MaintenanceList) DeepCopy() *MaintenanceList {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MaintenanceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceResults) DeepCopyInto(out *MaintenanceResults) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceResults.
+func (in *MaintenanceResults) DeepCopy() *MaintenanceResults {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceResults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceSpec) DeepCopyInto(out *MaintenanceSpec) {
+	*out = *in
+	in.RunnableSpec.DeepCopyInto(&out.RunnableSpec)
+	if in.Packs != nil {
+		in, out := &in.Packs, &out.Packs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailedJobsHistoryLimit != nil {
+		in, out := &in.FailedJobsHistoryLimit, &out.FailedJobsHistoryLimit
+		*out = new(int)
+		**out = **in
+	}
+	if in.SuccessfulJobsHistoryLimit != nil {
+		in, out := &in.SuccessfulJobsHistoryLimit, &out.SuccessfulJobsHistoryLimit
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceSpec.
+func (in *MaintenanceSpec) DeepCopy() *MaintenanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceStatus) DeepCopyInto(out *MaintenanceStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	out.MaintenanceResults = in.MaintenanceResults
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceStatus.
+func (in *MaintenanceStatus) DeepCopy() *MaintenanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperationCredentials) DeepCopyInto(out *OperationCredentials) {
+	*out = *in
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(CredentialSet)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(CredentialSet)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperationCredentials.
+func (in *OperationCredentials) DeepCopy() *OperationCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(OperationCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordCommandSpec) DeepCopyInto(out *PasswordCommandSpec) {
+	*out = *in
+	if in.InitCommand != nil {
+		in, out := &in.InitCommand, &out.InitCommand
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]RunnableVolumeSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordCommandSpec.
+func (in *PasswordCommandSpec) DeepCopy() *PasswordCommandSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordCommandSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Pod) DeepCopyInto(out *Pod) {
+	*out = *in
+	in.PodTemplateSpec.DeepCopyInto(&out.PodTemplateSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pod.
+func (in *Pod) DeepCopy() *Pod {
+	if in == nil {
+		return nil
+	}
+	out := new(Pod)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodConfig) DeepCopyInto(out *PodConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodConfig.
+func (in *PodConfig) DeepCopy() *PodConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PodConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PodConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodConfigList) DeepCopyInto(out *PodConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PodConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodConfigList.
+func (in *PodConfigList) DeepCopy() *PodConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(PodConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PodConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodConfigSpec) DeepCopyInto(out *PodConfigSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodConfigSpec.
+func (in *PodConfigSpec) DeepCopy() *PodConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodConfigStatus) DeepCopyInto(out *PodConfigStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodConfigStatus.
+func (in *PodConfigStatus) DeepCopy() *PodConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PodConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreBackupPod) DeepCopyInto(out *PreBackupPod) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreBackupPod.
+func (in *PreBackupPod) DeepCopy() *PreBackupPod {
+	if in == nil {
+		return nil
+	}
+	out := new(PreBackupPod)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PreBackupPod) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreBackupPodList) DeepCopyInto(out *PreBackupPodList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PreBackupPod, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreBackupPodList.
+func (in *PreBackupPodList) DeepCopy() *PreBackupPodList {
+	if in == nil {
+		return nil
+	}
+	out := new(PreBackupPodList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PreBackupPodList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreBackupPodSpec) DeepCopyInto(out *PreBackupPodSpec) {
+	*out = *in
+	if in.Pod != nil {
+		in, out := &in.Pod, &out.Pod
+		*out = new(Pod)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreBackupPodSpec.
+func (in *PreBackupPodSpec) DeepCopy() *PreBackupPodSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PreBackupPodSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Prune) DeepCopyInto(out *Prune) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Prune.
+func (in *Prune) DeepCopy() *Prune {
+	if in == nil {
+		return nil
+	}
+	out := new(Prune)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Prune) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PruneList) DeepCopyInto(out *PruneList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Prune, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PruneList.
+func (in *PruneList) DeepCopy() *PruneList {
+	if in == nil {
+		return nil
+	}
+	out := new(PruneList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PruneList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PruneSchedule) DeepCopyInto(out *PruneSchedule) {
+	*out = *in
+	in.PruneSpec.DeepCopyInto(&out.PruneSpec)
+	if in.ScheduleCommon != nil {
+		in, out := &in.ScheduleCommon, &out.ScheduleCommon
+		*out = new(ScheduleCommon)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PruneSchedule.
+func (in *PruneSchedule) DeepCopy() *PruneSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(PruneSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PruneSpec) DeepCopyInto(out *PruneSpec) {
+	*out = *in
+	in.RunnableSpec.DeepCopyInto(&out.RunnableSpec)
+	in.Retention.DeepCopyInto(&out.Retention)
+	if in.KeepJobs != nil {
+		in, out := &in.KeepJobs, &out.KeepJobs
+		*out = new(int)
+		**out = **in
+	}
+	if in.FailedJobsHistoryLimit != nil {
+		in, out := &in.FailedJobsHistoryLimit, &out.FailedJobsHistoryLimit
+		*out = new(int)
+		**out = **in
+	}
+	if in.SuccessfulJobsHistoryLimit != nil {
+		in, out := &in.SuccessfulJobsHistoryLimit, &out.SuccessfulJobsHistoryLimit
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PruneSpec.
+func (in *PruneSpec) DeepCopy() *PruneSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PodConfigList)
+	out := new(PruneSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PodConfigList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodConfigSpec) DeepCopyInto(out *PodConfigSpec) {
+func (in *RcloneSpec) DeepCopyInto(out *RcloneSpec) {
 	*out = *in
-	in.Template.DeepCopyInto(&out.Template)
+	if in.ConfigSecretRef != nil {
+		in, out := &in.ConfigSecretRef, &out.ConfigSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodConfigSpec.
-func (in *PodConfigSpec) DeepCopy() *PodConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RcloneSpec.
+func (in *RcloneSpec) DeepCopy() *RcloneSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PodConfigSpec)
+	out := new(RcloneSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodConfigStatus) DeepCopyInto(out *PodConfigStatus) {
+func (in *Repository) DeepCopyInto(out *Repository) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodConfigStatus.
-func (in *PodConfigStatus) DeepCopy() *PodConfigStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Repository.
+func (in *Repository) DeepCopy() *Repository {
 	if in == nil {
 		return nil
 	}
-	out := new(PodConfigStatus)
+	out := new(Repository)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Repository) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PreBackupPod) DeepCopyInto(out *PreBackupPod) {
+func (in *RepositoryKey) DeepCopyInto(out *RepositoryKey) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreBackupPod.
-func (in *PreBackupPod) DeepCopy() *PreBackupPod {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryKey.
+func (in *RepositoryKey) DeepCopy() *RepositoryKey {
 	if in == nil {
 		return nil
 	}
-	out := new(PreBackupPod)
+	out := new(RepositoryKey)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PreBackupPod) DeepCopyObject() runtime.Object {
+func (in *RepositoryKey) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -721,31 +1631,31 @@ func (in *PreBackupPod) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PreBackupPodList) DeepCopyInto(out *PreBackupPodList) {
+func (in *RepositoryKeyList) DeepCopyInto(out *RepositoryKeyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]PreBackupPod, len(*in))
+		*out = make([]RepositoryKey, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreBackupPodList.
-func (in *PreBackupPodList) DeepCopy() *PreBackupPodList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryKeyList.
+func (in *RepositoryKeyList) DeepCopy() *RepositoryKeyList {
 	if in == nil {
 		return nil
 	}
-	out := new(PreBackupPodList)
+	out := new(RepositoryKeyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PreBackupPodList) DeepCopyObject() runtime.Object {
+func (in *RepositoryKeyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -753,78 +1663,94 @@ func (in *PreBackupPodList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PreBackupPodSpec) DeepCopyInto(out *PreBackupPodSpec) {
+func (in *RepositoryKeyResults) DeepCopyInto(out *RepositoryKeyResults) {
 	*out = *in
-	if in.Pod != nil {
-		in, out := &in.Pod, &out.Pod
-		*out = new(Pod)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreBackupPodSpec.
-func (in *PreBackupPodSpec) DeepCopy() *PreBackupPodSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryKeyResults.
+func (in *RepositoryKeyResults) DeepCopy() *RepositoryKeyResults {
 	if in == nil {
 		return nil
 	}
-	out := new(PreBackupPodSpec)
+	out := new(RepositoryKeyResults)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Prune) DeepCopyInto(out *Prune) {
+func (in *RepositoryKeySpec) DeepCopyInto(out *RepositoryKeySpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	in.RunnableSpec.DeepCopyInto(&out.RunnableSpec)
+	if in.NewPasswordSecretRef != nil {
+		in, out := &in.NewPasswordSecretRef, &out.NewPasswordSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FailedJobsHistoryLimit != nil {
+		in, out := &in.FailedJobsHistoryLimit, &out.FailedJobsHistoryLimit
+		*out = new(int)
+		**out = **in
+	}
+	if in.SuccessfulJobsHistoryLimit != nil {
+		in, out := &in.SuccessfulJobsHistoryLimit, &out.SuccessfulJobsHistoryLimit
+		*out = new(int)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Prune.
-func (in *Prune) DeepCopy() *Prune {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryKeySpec.
+func (in *RepositoryKeySpec) DeepCopy() *RepositoryKeySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Prune)
+	out := new(RepositoryKeySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Prune) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryKeyStatus) DeepCopyInto(out *RepositoryKeyStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	out.RepositoryKeyResults = in.RepositoryKeyResults
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryKeyStatus.
+func (in *RepositoryKeyStatus) DeepCopy() *RepositoryKeyStatus {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(RepositoryKeyStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PruneList) DeepCopyInto(out *PruneList) {
+func (in *RepositoryList) DeepCopyInto(out *RepositoryList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Prune, len(*in))
+		*out = make([]Repository, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PruneList.
-func (in *PruneList) DeepCopy() *PruneList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryList.
+func (in *RepositoryList) DeepCopy() *RepositoryList {
 	if in == nil {
 		return nil
 	}
-	out := new(PruneList)
+	out := new(RepositoryList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PruneList) DeepCopyObject() runtime.Object {
+func (in *RepositoryList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -832,54 +1758,63 @@ func (in *PruneList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PruneSchedule) DeepCopyInto(out *PruneSchedule) {
+func (in *RepositorySpec) DeepCopyInto(out *RepositorySpec) {
 	*out = *in
-	in.PruneSpec.DeepCopyInto(&out.PruneSpec)
-	if in.ScheduleCommon != nil {
-		in, out := &in.ScheduleCommon, &out.ScheduleCommon
-		*out = new(ScheduleCommon)
-		**out = **in
+	if in.Backend != nil {
+		in, out := &in.Backend, &out.Backend
+		*out = new(Backend)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResticOptions != nil {
+		in, out := &in.ResticOptions, &out.ResticOptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = new([]RunnableVolumeSpec)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]RunnableVolumeSpec, len(*in))
+			for i := range *in {
+				(*in)[i].DeepCopyInto(&(*out)[i])
+			}
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PruneSchedule.
-func (in *PruneSchedule) DeepCopy() *PruneSchedule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositorySpec.
+func (in *RepositorySpec) DeepCopy() *RepositorySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PruneSchedule)
+	out := new(RepositorySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PruneSpec) DeepCopyInto(out *PruneSpec) {
+func (in *RepositoryStatus) DeepCopyInto(out *RepositoryStatus) {
 	*out = *in
-	in.RunnableSpec.DeepCopyInto(&out.RunnableSpec)
-	in.Retention.DeepCopyInto(&out.Retention)
-	if in.KeepJobs != nil {
-		in, out := &in.KeepJobs, &out.KeepJobs
-		*out = new(int)
-		**out = **in
-	}
-	if in.FailedJobsHistoryLimit != nil {
-		in, out := &in.FailedJobsHistoryLimit, &out.FailedJobsHistoryLimit
-		*out = new(int)
-		**out = **in
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
 	}
-	if in.SuccessfulJobsHistoryLimit != nil {
-		in, out := &in.SuccessfulJobsHistoryLimit, &out.SuccessfulJobsHistoryLimit
-		*out = new(int)
-		**out = **in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PruneSpec.
-func (in *PruneSpec) DeepCopy() *PruneSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryStatus.
+func (in *RepositoryStatus) DeepCopy() *RepositoryStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PruneSpec)
+	out := new(RepositoryStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -892,6 +1827,11 @@ func (in *RestServerSpec) DeepCopyInto(out *RestServerSpec) {
 		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PasswordSecretReg != nil {
 		in, out := &in.PasswordSecretReg, &out.PasswordSecretReg
 		*out = new(corev1.SecretKeySelector)
@@ -984,7 +1924,7 @@ func (in *RestoreMethod) DeepCopyInto(out *RestoreMethod) {
 	if in.TLSOptions != nil {
 		in, out := &in.TLSOptions, &out.TLSOptions
 		*out = new(TLSOptions)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.VolumeMounts != nil {
 		in, out := &in.VolumeMounts, &out.VolumeMounts
@@ -1039,6 +1979,11 @@ func (in *RestoreSpec) DeepCopyInto(out *RestoreSpec) {
 		*out = new(RestoreMethod)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SnapshotRef != nil {
+		in, out := &in.SnapshotRef, &out.SnapshotRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 	if in.KeepJobs != nil {
 		in, out := &in.KeepJobs, &out.KeepJobs
 		*out = new(int)
@@ -1114,6 +2059,11 @@ func (in *RunnableSpec) DeepCopyInto(out *RunnableSpec) {
 		*out = new(Backend)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RepositoryRef != nil {
+		in, out := &in.RepositoryRef, &out.RepositoryRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 	in.Resources.DeepCopyInto(&out.Resources)
 	if in.PodSecurityContext != nil {
 		in, out := &in.PodSecurityContext, &out.PodSecurityContext
@@ -1208,6 +2158,31 @@ func (in *S3Spec) DeepCopy() *S3Spec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SFTPSpec) DeepCopyInto(out *SFTPSpec) {
+	*out = *in
+	if in.PrivateKeySecretRef != nil {
+		in, out := &in.PrivateKeySecretRef, &out.PrivateKeySecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KnownHostsSecretRef != nil {
+		in, out := &in.KnownHostsSecretRef, &out.KnownHostsSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SFTPSpec.
+func (in *SFTPSpec) DeepCopy() *SFTPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SFTPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Schedule) DeepCopyInto(out *Schedule) {
 	*out = *in
@@ -1310,11 +2285,21 @@ func (in *ScheduleSpec) DeepCopyInto(out *ScheduleSpec) {
 		*out = new(PruneSchedule)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Copy != nil {
+		in, out := &in.Copy, &out.Copy
+		*out = new(CopySchedule)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Backend != nil {
 		in, out := &in.Backend, &out.Backend
 		*out = new(Backend)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RepositoryRef != nil {
+		in, out := &in.RepositoryRef, &out.RepositoryRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 	if in.KeepJobs != nil {
 		in, out := &in.KeepJobs, &out.KeepJobs
 		*out = new(int)
@@ -1465,6 +2450,11 @@ func (in *SnapshotSpec) DeepCopyInto(out *SnapshotSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotSpec.
@@ -1514,9 +2504,80 @@ func (in *Status) DeepCopy() *Status {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StdinBackupStatus) DeepCopyInto(out *StdinBackupStatus) {
+	*out = *in
+	in.BackupRunStatus.DeepCopyInto(&out.BackupRunStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StdinBackupStatus.
+func (in *StdinBackupStatus) DeepCopy() *StdinBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StdinBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SwiftSpec) DeepCopyInto(out *SwiftSpec) {
 	*out = *in
+	if in.AuthURLSecretRef != nil {
+		in, out := &in.AuthURLSecretRef, &out.AuthURLSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RegionNameSecretRef != nil {
+		in, out := &in.RegionNameSecretRef, &out.RegionNameSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UsernameSecretRef != nil {
+		in, out := &in.UsernameSecretRef, &out.UsernameSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TenantIDSecretRef != nil {
+		in, out := &in.TenantIDSecretRef, &out.TenantIDSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TenantNameSecretRef != nil {
+		in, out := &in.TenantNameSecretRef, &out.TenantNameSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UserDomainNameSecretRef != nil {
+		in, out := &in.UserDomainNameSecretRef, &out.UserDomainNameSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProjectNameSecretRef != nil {
+		in, out := &in.ProjectNameSecretRef, &out.ProjectNameSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProjectDomainNameSecretRef != nil {
+		in, out := &in.ProjectDomainNameSecretRef, &out.ProjectDomainNameSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ApplicationCredentialIDSecretRef != nil {
+		in, out := &in.ApplicationCredentialIDSecretRef, &out.ApplicationCredentialIDSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ApplicationCredentialSecretRef != nil {
+		in, out := &in.ApplicationCredentialSecretRef, &out.ApplicationCredentialSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftSpec.
@@ -1532,6 +2593,26 @@ func (in *SwiftSpec) DeepCopy() *SwiftSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLSOptions) DeepCopyInto(out *TLSOptions) {
 	*out = *in
+	if in.CACertSecretRef != nil {
+		in, out := &in.CACertSecretRef, &out.CACertSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CACertConfigMapRef != nil {
+		in, out := &in.CACertConfigMapRef, &out.CACertConfigMapRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientCertSecretRef != nil {
+		in, out := &in.ClientCertSecretRef, &out.ClientCertSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientKeySecretRef != nil {
+		in, out := &in.ClientKeySecretRef, &out.ClientKeySecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSOptions.
@@ -1543,3 +2624,19 @@ func (in *TLSOptions) DeepCopy() *TLSOptions {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeBackupStatus) DeepCopyInto(out *VolumeBackupStatus) {
+	*out = *in
+	in.BackupRunStatus.DeepCopyInto(&out.BackupRunStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeBackupStatus.
+func (in *VolumeBackupStatus) DeepCopy() *VolumeBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
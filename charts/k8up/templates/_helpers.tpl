@@ -100,3 +100,10 @@ Cleanup Image
 {{ if .cleanup.registry }}{{ .cleanup.registry }}{{ else }}{{ .image.registry }}{{ end }}/{{ if .cleanup.repository }}{{ .cleanup.repository }}{{ else }}{{ .image.repository }}{{ end }}:{{ if .cleanup.tag }}{{ .cleanup.tag }}{{ else }}{{ .image.tag }}{{ end }}
 {{- end -}}
 {{- end -}}
+
+{{/*
+Name of the Secret with the TLS certificate of the webhook server
+*/}}
+{{- define "k8up.webhookSecretName" -}}
+{{- printf "%s-webhook-tls" (include "k8up.fullname" .) -}}
+{{- end -}}
@@ -0,0 +1,91 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/helm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var (
+	tplWebhookService = []string{"templates/webhook/service.yaml"}
+	tplWebhooks       = []string{"templates/webhook/webhooks.yaml"}
+)
+
+func Test_Webhooks_GivenDefaultValues_ThenRenderNothing(t *testing.T) {
+	options := withReleaseNamespace(&helm.Options{})
+
+	_, err := helm.RenderTemplateE(t, options, helmChartPath, releaseName, tplWebhooks)
+
+	require.Error(t, err, "the webhooks are disabled by default")
+}
+
+func Test_Webhooks_GivenEnabled_ThenRenderServiceMatchingDeployment(t *testing.T) {
+	options := withReleaseNamespace(&helm.Options{
+		SetValues: map[string]string{
+			"webhooks.enabled": "true",
+		},
+	})
+
+	output := helm.RenderTemplate(t, options, helmChartPath, releaseName, tplWebhookService)
+	service := corev1.Service{}
+	helm.UnmarshalK8SYaml(t, output, &service)
+	deployment := renderDeployment(t, options, false)
+
+	assert.Equal(t, releaseName+"-k8up-webhook", service.Name)
+	assert.Equal(t, service.Spec.Selector, deployment.Spec.Template.Labels, "Service labels do not match with deployment labels")
+	assert.Equal(t, "webhook", service.Spec.Ports[0].TargetPort.String())
+	assert.Equal(t, "webhook", deployment.Spec.Template.Spec.Containers[0].Ports[1].Name)
+	assert.Equal(t, releaseName+"-k8up-webhook-tls", deployment.Spec.Template.Spec.Volumes[0].Secret.SecretName)
+}
+
+func Test_Webhooks_GivenEnabled_ThenRenderWebhookConfigurationsWithCABundle(t *testing.T) {
+	options := withReleaseNamespace(&helm.Options{
+		SetValues: map[string]string{
+			"webhooks.enabled": "true",
+		},
+	})
+
+	documents := strings.Split(helm.RenderTemplate(t, options, helmChartPath, releaseName, tplWebhooks), "\n---\n")
+	require.Len(t, documents, 3)
+	secret := corev1.Secret{}
+	helm.UnmarshalK8SYaml(t, documents[0], &secret)
+	mutating := admissionregistrationv1.MutatingWebhookConfiguration{}
+	helm.UnmarshalK8SYaml(t, documents[1], &mutating)
+	validating := admissionregistrationv1.ValidatingWebhookConfiguration{}
+	helm.UnmarshalK8SYaml(t, documents[2], &validating)
+
+	assert.Equal(t, releaseName+"-k8up-webhook-tls", secret.Name)
+	assert.NotEmpty(t, secret.Data["tls.crt"])
+	require.Len(t, mutating.Webhooks, 9)
+	assert.Equal(t, "mbackup.k8up.io", mutating.Webhooks[1].Name)
+	assert.Equal(t, "/mutate-k8up-io-v1-backup", *mutating.Webhooks[1].ClientConfig.Service.Path)
+	assert.NotEmpty(t, mutating.Webhooks[1].ClientConfig.CABundle)
+	require.Len(t, validating.Webhooks, 11)
+	assert.Equal(t, "vclusterrepository.k8up.io", validating.Webhooks[3].Name)
+	assert.Equal(t, []string{"clusterrepositories"}, validating.Webhooks[3].Rules[0].Resources)
+	assert.Equal(t, releaseNamespace, validating.Webhooks[3].ClientConfig.Service.Namespace)
+}
+
+func Test_Webhooks_GivenCertManager_ThenRenderCertificateAndInjectCA(t *testing.T) {
+	options := withReleaseNamespace(&helm.Options{
+		SetValues: map[string]string{
+			"webhooks.enabled":             "true",
+			"webhooks.certManager.enabled": "true",
+		},
+	})
+
+	documents := strings.Split(helm.RenderTemplate(t, options, helmChartPath, releaseName, tplWebhooks), "\n---\n")
+	require.Len(t, documents, 4)
+	assert.Contains(t, documents[0], "kind: Issuer")
+	assert.Contains(t, documents[1], "kind: Certificate")
+	validating := admissionregistrationv1.ValidatingWebhookConfiguration{}
+	helm.UnmarshalK8SYaml(t, documents[3], &validating)
+
+	assert.Equal(t, releaseNamespace+"/"+releaseName+"-k8up-webhook", validating.Annotations["cert-manager.io/inject-ca-from"])
+	assert.Empty(t, validating.Webhooks[0].ClientConfig.CABundle)
+}
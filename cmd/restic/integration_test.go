@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
 	"github.com/k8up-io/k8up/v2/restic/cfg"
 	"github.com/k8up-io/k8up/v2/restic/cli"
 	"github.com/k8up-io/k8up/v2/restic/s3"
@@ -314,3 +315,186 @@ func TestArchive(t *testing.T) {
 
 	testCheckS3Restore(t, env.ctx)
 }
+
+// TestSFTPBackup backs up to the SFTP repository in SFTP_REPOSITORY, e.g. the sshd container of 'make sshd-start'.
+func TestSFTPBackup(t *testing.T) {
+	repository := os.Getenv("SFTP_REPOSITORY")
+	if repository == "" {
+		t.Skip("SFTP_REPOSITORY is not set")
+	}
+	t.Setenv("RESTIC_REPOSITORY", repository)
+
+	ctx := context.Background()
+	cfg.Config = &cfg.Configuration{
+		Hostname:       os.Getenv("HOSTNAME"),
+		ResticBin:      os.Getenv("RESTIC_BINARY"),
+		BackupDir:      os.Getenv("BACKUP_DIR"),
+		VarDir:         t.TempDir(),
+		SFTPPrivateKey: os.Getenv("SFTP_PRIVATE_KEY_FILE"),
+		SFTPKnownHosts: os.Getenv("SFTP_KNOWN_HOSTS_FILE"),
+	}
+
+	mainLogger := zapr.NewLogger(zaptest.NewLogger(t))
+	statHandler := stats.NewHandler("", "", cfg.Config.Hostname, "", mainLogger)
+	resticCli := cli.New(ctx, mainLogger, statHandler)
+
+	cleanupDirs(t)
+	createTestFiles(t)
+	t.Cleanup(func() {
+		cleanupDirs(t)
+	})
+
+	require.NoError(t, run(ctx, resticCli, mainLogger))
+	info, err := os.Stat(filepath.Join(cfg.Config.VarDir, "sftp_id"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+// TestAppendOnlyBackup backs up to the append-only rest-server in APPEND_ONLY_REPOSITORY and expects prunes to fail,
+// as backup credentials for append-only repositories can't delete data.
+func TestAppendOnlyBackup(t *testing.T) {
+	repository := os.Getenv("APPEND_ONLY_REPOSITORY")
+	if repository == "" {
+		t.Skip("APPEND_ONLY_REPOSITORY is not set")
+	}
+	t.Setenv("RESTIC_REPOSITORY", repository)
+
+	ctx := context.Background()
+	cfg.Config = &cfg.Configuration{
+		Hostname:  os.Getenv("HOSTNAME"),
+		ResticBin: os.Getenv("RESTIC_BINARY"),
+		BackupDir: os.Getenv("BACKUP_DIR"),
+	}
+
+	mainLogger := zapr.NewLogger(zaptest.NewLogger(t))
+	statHandler := stats.NewHandler("", "", cfg.Config.Hostname, "", mainLogger)
+	resticCli := cli.New(ctx, mainLogger, statHandler)
+
+	cleanupDirs(t)
+	createTestFiles(t)
+	t.Cleanup(func() {
+		cleanupDirs(t)
+	})
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, run(ctx, resticCli, mainLogger), "backups to append-only repositories succeed")
+	}
+
+	cfg.Config.DoPrune = true
+	cfg.Config.PruneKeepLast = 1
+	assert.Error(t, run(ctx, resticCli, mainLogger), "prunes of append-only repositories fail")
+}
+
+// TestRotateKey rotates the password of a new repository in a temporary directory
+// and expects only the new password to open the repository afterwards.
+func TestRotateKey(t *testing.T) {
+	t.Setenv("RESTIC_REPOSITORY", t.TempDir())
+	newPasswordFile := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(newPasswordFile, []byte("new-password"), 0600))
+
+	ctx := context.Background()
+	cfg.Config = &cfg.Configuration{
+		Hostname:        os.Getenv("HOSTNAME"),
+		ResticBin:       os.Getenv("RESTIC_BINARY"),
+		DoRotateKey:     true,
+		NewPasswordFile: newPasswordFile,
+	}
+
+	mainLogger := zapr.NewLogger(zaptest.NewLogger(t))
+	statHandler := stats.NewHandler("", "", cfg.Config.Hostname, "", mainLogger)
+	resticCli := cli.New(ctx, mainLogger, statHandler)
+
+	require.NoError(t, run(ctx, resticCli, mainLogger))
+
+	keys, err := resticCli.ListKeys(newPasswordFile)
+	require.NoError(t, err)
+	require.Len(t, keys, 1, "the key of the former password is removed")
+	assert.True(t, keys[0].Current)
+
+	_, err = resticCli.ListKeys("")
+	assert.Error(t, err, "the former password doesn't open the repository anymore")
+}
+
+// TestRepoPasswordCommand initializes a new repository in a temporary directory with the password of a stub command
+// and expects the repository to be opened with that password, also when it's read from a file.
+func TestRepoPasswordCommand(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("RESTIC_REPOSITORY", filepath.Join(dir, "repository"))
+	t.Setenv("RESTIC_PASSWORD", "")
+	passwordCommand := filepath.Join(dir, "password-command")
+	require.NoError(t, os.WriteFile(passwordCommand, []byte("#!/bin/sh\necho command-password\n"), 0700))
+	t.Setenv("RESTIC_PASSWORD_COMMAND", passwordCommand)
+	passwordFile := filepath.Join(dir, "password")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("command-password"), 0600))
+	wrongPasswordFile := filepath.Join(dir, "wrong-password")
+	require.NoError(t, os.WriteFile(wrongPasswordFile, []byte("wrong-password"), 0600))
+
+	ctx := context.Background()
+	cfg.Config = &cfg.Configuration{
+		Hostname:  os.Getenv("HOSTNAME"),
+		ResticBin: os.Getenv("RESTIC_BINARY"),
+		DoInit:    true,
+	}
+
+	mainLogger := zapr.NewLogger(zaptest.NewLogger(t))
+	statHandler := stats.NewHandler("", "", cfg.Config.Hostname, "", mainLogger)
+	resticCli := cli.New(ctx, mainLogger, statHandler)
+
+	require.NoError(t, run(ctx, resticCli, mainLogger))
+	_, err := resticCli.ListKeys("")
+	require.NoError(t, err, "the password command opens the repository")
+	_, err = resticCli.ListKeys(passwordFile)
+	require.NoError(t, err, "the password of the command is the password of the repository")
+	_, err = resticCli.ListKeys(wrongPasswordFile)
+	assert.Error(t, err)
+
+	t.Setenv("RESTIC_PASSWORD_COMMAND", "")
+	t.Setenv("RESTIC_PASSWORD_FILE", passwordFile)
+	_, err = resticCli.ListKeys("")
+	assert.NoError(t, err, "the password file opens the repository")
+}
+
+// TestCopy backs up into a new repository in a temporary directory, copies its snapshots into another new repository
+// and expects a second copy to skip the snapshots that were copied before.
+func TestCopy(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "source")
+	t.Setenv("RESTIC_REPOSITORY", source)
+
+	ctx := context.Background()
+	cfg.Config = &cfg.Configuration{
+		Hostname:         os.Getenv("HOSTNAME"),
+		ResticBin:        os.Getenv("RESTIC_BINARY"),
+		BackupDir:        os.Getenv("BACKUP_DIR"),
+		SkipSnapshotSync: true,
+	}
+
+	mainLogger := zapr.NewLogger(zaptest.NewLogger(t))
+	statHandler := stats.NewHandler("", "", cfg.Config.Hostname, "", mainLogger)
+
+	cleanupDirs(t)
+	createTestFiles(t)
+	t.Cleanup(func() {
+		cleanupDirs(t)
+	})
+	require.NoError(t, run(ctx, cli.New(ctx, mainLogger, statHandler), mainLogger))
+
+	t.Setenv("RESTIC_REPOSITORY", filepath.Join(dir, "destination"))
+	t.Setenv("RESTIC_FROM_REPOSITORY", source)
+	t.Setenv("RESTIC_FROM_PASSWORD", os.Getenv("RESTIC_PASSWORD"))
+	terminationMessage := filepath.Join(dir, "termination-log")
+	cfg.Config.DoCopy = true
+	cfg.Config.TerminationMessagePath = terminationMessage
+
+	for _, expected := range []int{1, 0} {
+		require.NoError(t, os.WriteFile(terminationMessage, nil, 0644))
+		require.NoError(t, run(ctx, cli.New(ctx, mainLogger, statHandler), mainLogger))
+
+		message, err := os.ReadFile(terminationMessage)
+		require.NoError(t, err)
+		results := k8upv1.CopyResults{}
+		require.NoError(t, json.Unmarshal(message, &results))
+		require.NotNil(t, results.SnapshotsCopied)
+		assert.Equal(t, expected, *results.SnapshotsCopied)
+	}
+}
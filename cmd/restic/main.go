@@ -46,21 +46,30 @@ var (
 			&cli.BoolFlag{Destination: &cfg.Config.DoPrune, Name: "prune", Usage: "Set, if the container should do a prune"},
 			&cli.BoolFlag{Destination: &cfg.Config.DoRestore, Name: "restore", Usage: "Set, if the container should attempt a restore"},
 			&cli.BoolFlag{Destination: &cfg.Config.DoArchive, Name: "archive", Usage: "Set, if the container should do an archive"},
+			&cli.BoolFlag{Destination: &cfg.Config.DoInit, Name: "init", Usage: "Set, if the container should only initialise the repository"},
+			&cli.BoolFlag{Destination: &cfg.Config.DoRotateKey, Name: "rotateKey", Usage: "Set, if the container should replace the key of the repository password with a key of the password in -newPasswordFile"},
+			&cli.BoolFlag{Destination: &cfg.Config.DoCopy, Name: "copy", Usage: "Set, if the container should copy the snapshots of the repository in RESTIC_FROM_REPOSITORY into the repository"},
+			&cli.StringFlag{Destination: &cfg.Config.Maintenance, Name: "maintenance", Usage: "Set to the maintenance operation the container should run on the repository: 'rebuild-index', 'repair-index', 'repair-snapshots', 'repair-packs', 'migrate-upgrade-repo-v2' or 'unlock-remove-all'"},
 
 			&cli.StringSliceFlag{Name: "tag", Usage: "List of tags to consider for given operation"},
 			&cli.StringSliceFlag{Name: "path", Usage: "List of paths a snapshot has to include for given operation"},
+			&cli.StringSliceFlag{Name: "host", Usage: "List of hosts a snapshot has to belong to for given operation"},
 
 			&cli.StringFlag{Destination: &cfg.Config.BackupCommandAnnotation, Name: "backupCommandAnnotation", EnvVars: []string{"BACKUPCOMMAND_ANNOTATION"}, Usage: "Defines the command to invoke when doing a backup via STDOUT."},
 			&cli.StringFlag{Destination: &cfg.Config.BackupFileExtensionAnnotation, Name: "fileExtensionAnnotation", EnvVars: []string{"FILEEXTENSION_ANNOTATION"}, Usage: "Defines the file extension to use for STDOUT backups."},
 			&cli.StringFlag{Destination: &cfg.Config.BackupContainerAnnotation, Name: "backucontainerannotation", EnvVars: []string{"BACKUP_CONTAINERANNOTATION"}, Value: "k8up.io/backupcommand-container", Usage: "set the annotation name that specify the backup container inside the Pod"},
 			&cli.BoolFlag{Destination: &cfg.Config.SkipPreBackup, Name: "skipPreBackup", EnvVars: []string{"SKIP_PREBACKUP"}, Usage: "If the job should skip the backup command and only backup volumes."},
 			&cli.BoolFlag{Destination: &cfg.Config.SkipSnapshotSync, Name: "skipSnapshotSync", EnvVars: []string{"BACKUP_SKIP_SNAPSHOT_SYNC"}, Usage: "If set, skip synchronizing Snapshot custom resources to the cluster after backup or prune operations. Webhook notifications are still sent."},
+			&cli.BoolFlag{Destination: &cfg.Config.FailOnReadErrors, Name: "failOnReadErrors", EnvVars: []string{"FAIL_ON_READ_ERRORS"}, Usage: "If set, the backup fails if some files could not be read, instead of only reporting them."},
+			&cli.StringFlag{Destination: &cfg.Config.BackupName, Name: "backupName", EnvVars: []string{"BACKUP_NAME"}, Usage: "Sets the name of the Backup that is recorded in the Snapshot custom resources of new snapshots."},
+			&cli.StringFlag{Destination: &cfg.Config.ScheduleName, Name: "scheduleName", EnvVars: []string{"SCHEDULE_NAME"}, Usage: "Sets the name of the Schedule that is recorded in the Snapshot custom resources of new snapshots."},
 
 			&cli.StringFlag{Destination: &cfg.Config.PromURL, Name: "promURL", EnvVars: []string{"PROM_URL"}, Usage: "Sets the URL of a prometheus push gateway to report metrics."},
 			&cli.StringFlag{Destination: &cfg.Config.ClusterName, Name: "clusterName", EnvVars: []string{"CLUSTER_NAME"}, Usage: "Sets the Kubernetes cluster name for grouping metrics in push gateway"},
 			&cli.StringFlag{Destination: &cfg.Config.WebhookURL, Name: "webhookURL", Aliases: []string{"statsURL"}, EnvVars: []string{"STATS_URL"}, Usage: "Sets the URL of a server which will retrieve a webhook after the action completes."},
 
 			&cli.StringFlag{Destination: &cfg.Config.Hostname, Name: "hostname", EnvVars: []string{"HOSTNAME"}, Usage: "Sets the hostname to use in reports.", Hidden: true, Required: true},
+			&cli.StringFlag{Destination: &cfg.Config.TerminationMessagePath, Name: "terminationMessagePath", Usage: "Sets the file the results of a backup, a check, a key rotation, a copy or a maintenance operation are written to, for the operator to read them from the termination message of the container.", Hidden: true, Value: "/dev/termination-log"},
 			&cli.StringFlag{Destination: &cfg.Config.KubeConfig, Name: "kubeconfig", EnvVars: []string{"KUBECONFIG"}, Usage: "Overwrite the default kubernetes config to use.", Hidden: true, Value: clientcmd.RecommendedHomeFile},
 
 			&cli.StringFlag{Destination: &cfg.Config.BackupDir, Name: "backupDir", EnvVars: []string{backupDirEnvKey}, Value: "/data", Usage: "Set from which directory the backup should be performed."},
@@ -109,15 +118,27 @@ var (
 			&cli.StringFlag{Destination: &cfg.Config.PruneKeepWithinWeekly, Name: "keepWithinWeekly", EnvVars: []string{"KEEP_WITHIN_WEEKLY"}, Usage: "While pruning, keep weekly snapshots within the given duration, e.g. '2y5m7d3h'"},
 			&cli.StringFlag{Destination: &cfg.Config.PruneKeepWithinMonthly, Name: "keepWithinMonthly", EnvVars: []string{"KEEP_WITHIN_MONTHLY"}, Usage: "While pruning, keep monthly snapshots within the given duration, e.g. '2y5m7d3h'"},
 			&cli.StringFlag{Destination: &cfg.Config.PruneKeepWithinYearly, Name: "keepWithinYearly", EnvVars: []string{"KEEP_WITHIN_YEARLY"}, Usage: "While pruning, keep yearly snapshots within the given duration, e.g. '2y5m7d3h'"},
-			&cli.StringFlag{Destination: &cfg.Config.PruneKeepWithin, Name: "keepWithin", EnvVars: []string{"KEEP_WITHIN"}, Usage: "While pruning, keep tagged snapshots within the given duration, e.g. '2y5m7d3h'"},
+			&cli.StringFlag{Destination: &cfg.Config.PruneKeepWithin, Name: "keepWithin", EnvVars: []string{"KEEP_WITHIN"}, Usage: "While pruning, keep all snapshots within the given duration, e.g. '2y5m7d3h'"},
+			&cli.StringFlag{Destination: &cfg.Config.PruneGroupBy, Name: "groupBy", EnvVars: []string{"GROUP_BY"}, Usage: "While pruning, group the snapshots by a comma separated list of 'host', 'paths' and 'tags' before applying the policy"},
 
 			&cli.StringSliceFlag{Name: "targetPods", EnvVars: []string{"TARGET_PODS"}, Usage: "Filter list of pods by TARGET_PODS names"},
 			&cli.DurationFlag{Destination: &cfg.Config.SleepDuration, Name: "sleepDuration", EnvVars: []string{"SLEEP_DURATION"}, Usage: "Sleep for specified amount until init starts"},
+			&cli.BoolFlag{Destination: &cfg.Config.SkipInit, Name: "skipInit", EnvVars: []string{"SKIP_INIT"}, Usage: "Skip the initialisation of the repository, e.g. if it has been initialised by the operator already"},
+			&cli.PathFlag{Destination: &cfg.Config.NewPasswordFile, Name: "newPasswordFile", Usage: "The file path of the new repository password when rotating the repository key"},
+			&cli.BoolFlag{Destination: &cfg.Config.ReadAllPacks, Name: "readAllPacks", Usage: "In maintenance, read all pack files to rebuild the index"},
+			&cli.BoolFlag{Destination: &cfg.Config.Forget, Name: "forget", Usage: "In maintenance, remove the original snapshots once they're replaced by repaired snapshots"},
+			&cli.StringSliceFlag{Name: "pack", Usage: "In maintenance, the IDs of the pack files to repair"},
+			&cli.BoolFlag{Destination: &cfg.Config.ReadData, Name: "readData", Usage: "In check, read all data of the repository"},
+			&cli.StringFlag{Destination: &cfg.Config.ReadDataSubset, Name: "readDataSubset", Usage: "In check, read a subset of the data of the repository, e.g. '1/7', '10%' or '500M'"},
+			&cli.BoolFlag{Destination: &cfg.Config.WithCache, Name: "withCache", Usage: "In check, use the cache instead of a temporary one"},
 
 			&cli.PathFlag{Destination: &cfg.Config.VarDir, Name: "varDir", Value: "/k8up", Usage: "The var directory is stored k8up metadata files and temporary files"},
 			&cli.PathFlag{Destination: &cfg.Config.CACert, Name: "caCert", EnvVars: []string{caCertFileEnvKey}, Usage: "The certificate authority file path"},
 			&cli.PathFlag{Destination: &cfg.Config.ClientCert, Name: "clientCert", EnvVars: []string{clientCertFileEnvKey}, Usage: "The client certificate file path"},
 			&cli.PathFlag{Destination: &cfg.Config.ClientKey, Name: "clientKey", EnvVars: []string{clientKeyFileEnvKey}, Usage: "The client private key file path"},
+			&cli.PathFlag{Destination: &cfg.Config.SFTPPrivateKey, Name: "sftpPrivateKey", EnvVars: []string{"SFTP_PRIVATE_KEY_FILE"}, Usage: "The private SSH key file path for SFTP repositories"},
+			&cli.PathFlag{Destination: &cfg.Config.SFTPKnownHosts, Name: "sftpKnownHosts", EnvVars: []string{"SFTP_KNOWN_HOSTS_FILE"}, Usage: "The known_hosts file path for SFTP repositories"},
+			&cli.StringFlag{Destination: &cfg.Config.SFTPCommand, Name: "sftpCommand", EnvVars: []string{"SFTP_COMMAND"}, Usage: "The command to connect to the server of SFTP repositories, replaces the SSH key and known_hosts files"},
 
 			&cli.BoolFlag{Destination: &cfg.Config.InsecureAllowPodExecSPDYFallback, Name: "insecure-allow-podexec-spdy-fallback", EnvVars: []string{cfg.InsecureAllowPodExecSPDYFallback}, Required: false, Value: false, Usage: "enable fallback to SPDY connections for data streaming used by application aware backups. Might need to be enabled if the cluster has Kubernetes version 1.30 or lower. K8up uses WebSockets by default. CAUTION: Has been observed to cause silent data corruption in some network setups, use at own risk!"},
 		},
@@ -130,6 +151,8 @@ func resticMain(c *cli.Context) error {
 
 	cfg.Config.Tags = c.StringSlice("tag")
 	cfg.Config.Paths = cmd.SplitAtComma(c.StringSlice("path"))
+	cfg.Config.Hosts = cmd.SplitAtComma(c.StringSlice("host"))
+	cfg.Config.Packs = cmd.SplitAtComma(c.StringSlice("pack"))
 	cfg.Config.TargetPods = cmd.SplitAtComma(c.StringSlice("targetPods"))
 
 	cfg.Config.Exclude = cmd.SplitAtComma(c.StringSlice("exclude"))
@@ -157,14 +180,41 @@ func resticMain(c *cli.Context) error {
 }
 
 func run(ctx context.Context, resticCLI *resticCli.Restic, mainLogger logr.Logger) error {
+	if cfg.Config.Maintenance != "" {
+		// The repository is neither initialized nor opened beforehand, it may be damaged or locked.
+		return doMaintenance(resticCLI, mainLogger)
+	}
+
+	if cfg.Config.DoCheck {
+		defer func() {
+			if err := resticCLI.WriteCheckResults(cfg.Config.TerminationMessagePath); err != nil {
+				mainLogger.Error(err, "cannot report the check results to the operator")
+			}
+		}()
+	}
+
 	if err := resticInitialization(resticCLI, mainLogger); err != nil {
+		// A check reports that it couldn't open the repository, e.g. because of a wrong password.
+		resticCLI.RecordCheckFailure(err)
 		return err
 	}
+	if cfg.Config.DoInit {
+		return nil
+	}
 
 	if err := waitForEndOfConcurrentOperations(resticCLI); err != nil {
+		resticCLI.RecordCheckFailure(err)
 		return err
 	}
 
+	if cfg.Config.DoRotateKey {
+		return doRotateKey(resticCLI, mainLogger)
+	}
+
+	if cfg.Config.DoCopy {
+		return doCopy(resticCLI, mainLogger)
+	}
+
 	if cfg.Config.DoPrune || cfg.Config.DoCheck || cfg.Config.DoRestore || cfg.Config.DoArchive {
 		return doNonBackupTasks(resticCLI)
 	}
@@ -173,12 +223,19 @@ func run(ctx context.Context, resticCLI *resticCli.Restic, mainLogger logr.Logge
 }
 
 func resticInitialization(resticCLI *resticCli.Restic, mainLogger logr.Logger) error {
-	if cfg.Config.SleepDuration > 0 {
-		mainLogger.Info("sleeping until init", "duration", cfg.Config.SleepDuration)
-		time.Sleep(cfg.Config.SleepDuration)
+	if err := resticCLI.PrepareSFTPKey(); err != nil {
+		return err
 	}
-	if err := resticCLI.Init(); err != nil {
-		return fmt.Errorf("failed to initialise the restic repository: %w", err)
+	if cfg.Config.SkipInit {
+		mainLogger.Info("skipping init, the repository has been initialised already")
+	} else {
+		if cfg.Config.SleepDuration > 0 {
+			mainLogger.Info("sleeping until init", "duration", cfg.Config.SleepDuration)
+			time.Sleep(cfg.Config.SleepDuration)
+		}
+		if err := resticCLI.Init(); err != nil {
+			return fmt.Errorf("failed to initialise the restic repository: %w", err)
+		}
 	}
 
 	if err := resticCLI.Unlock(false); err != nil {
@@ -194,7 +251,7 @@ func resticInitialization(resticCLI *resticCli.Restic, mainLogger logr.Logger) e
 }
 
 func waitForEndOfConcurrentOperations(resticCLI *resticCli.Restic) error {
-	if cfg.Config.DoPrune || cfg.Config.DoCheck {
+	if cfg.Config.DoPrune || cfg.Config.DoCheck || cfg.Config.DoRotateKey {
 		if err := resticCLI.Wait(); err != nil {
 			return fmt.Errorf("failed to list repository locks: %w", err)
 		}
@@ -223,7 +280,7 @@ func doNonBackupTasks(resticCLI *resticCli.Restic) error {
 
 func doPrune(resticCLI *resticCli.Restic) error {
 	if cfg.Config.DoPrune {
-		if err := resticCLI.Prune(cfg.Config.Tags); err != nil {
+		if err := resticCLI.Prune(cfg.Config.Tags, cfg.Config.Hosts); err != nil {
 			return fmt.Errorf("prune job failed: %w", err)
 		}
 	}
@@ -232,13 +289,64 @@ func doPrune(resticCLI *resticCli.Restic) error {
 
 func doCheck(resticCLI *resticCli.Restic) error {
 	if cfg.Config.DoCheck {
-		if err := resticCLI.Check(); err != nil {
+		if err := resticCLI.Check(resticCli.CheckOptions{
+			ReadData:       cfg.Config.ReadData,
+			ReadDataSubset: cfg.Config.ReadDataSubset,
+			WithCache:      cfg.Config.WithCache,
+		}); err != nil {
 			return fmt.Errorf("check job failed: %w", err)
 		}
 	}
 	return nil
 }
 
+func doRotateKey(resticCLI *resticCli.Restic, mainLogger logr.Logger) error {
+	defer func() {
+		if err := resticCLI.WriteRepositoryKeyResults(cfg.Config.TerminationMessagePath); err != nil {
+			mainLogger.Error(err, "cannot report the repository key results to the operator")
+		}
+	}()
+
+	if err := resticCLI.RotateKey(cfg.Config.NewPasswordFile); err != nil {
+		return fmt.Errorf("repository key job failed: %w", err)
+	}
+	return nil
+}
+
+func doCopy(resticCLI *resticCli.Restic, mainLogger logr.Logger) error {
+	defer func() {
+		if err := resticCLI.WriteCopyResults(cfg.Config.TerminationMessagePath); err != nil {
+			mainLogger.Error(err, "cannot report the copy results to the operator")
+		}
+	}()
+
+	if err := resticCLI.Copy(cfg.Config.Tags, cfg.Config.Hosts, cfg.Config.Paths); err != nil {
+		return fmt.Errorf("copy job failed: %w", err)
+	}
+	return nil
+}
+
+func doMaintenance(resticCLI *resticCli.Restic, mainLogger logr.Logger) error {
+	defer func() {
+		if err := resticCLI.WriteMaintenanceResults(cfg.Config.TerminationMessagePath); err != nil {
+			mainLogger.Error(err, "cannot report the maintenance results to the operator")
+		}
+	}()
+
+	if err := resticCLI.PrepareSFTPKey(); err != nil {
+		return err
+	}
+	options := resticCli.MaintenanceOptions{
+		ReadAllPacks: cfg.Config.ReadAllPacks,
+		Forget:       cfg.Config.Forget,
+		Packs:        cfg.Config.Packs,
+	}
+	if err := resticCLI.Maintenance(cfg.Config.Maintenance, options); err != nil {
+		return fmt.Errorf("maintenance job failed: %w", err)
+	}
+	return nil
+}
+
 func doRestore(resticCLI *resticCli.Restic) error {
 	if !cfg.Config.DoRestore {
 		return nil
@@ -292,6 +400,12 @@ func doArchive(resticCLI *resticCli.Restic) error {
 }
 
 func doBackup(ctx context.Context, resticCLI *resticCli.Restic, mainLogger logr.Logger) error {
+	defer func() {
+		if err := resticCLI.WriteBackupResults(cfg.Config.TerminationMessagePath); err != nil {
+			mainLogger.Error(err, "cannot report the backup results to the operator")
+		}
+	}()
+
 	err := backupAnnotatedPods(ctx, resticCLI, mainLogger)
 	if err != nil {
 		return fmt.Errorf("backup of annotated pods failed: %w", err)
@@ -302,6 +416,9 @@ func doBackup(ctx context.Context, resticCLI *resticCli.Restic, mainLogger logr.
 	if err != nil {
 		return fmt.Errorf("backup job failed in dir '%s': %w", cfg.Config.BackupDir, err)
 	}
+	if cfg.Config.FailOnReadErrors && resticCLI.HasReadErrors() {
+		return fmt.Errorf("backup job failed: %w", resticCli.ErrIncompleteBackup)
+	}
 	return nil
 }
 
@@ -335,12 +452,13 @@ func backupAnnotatedPods(ctx context.Context, resticCLI *resticCli.Restic, mainL
 }
 
 func backupAnnotatedPod(pod kubernetes.BackupPod, mainLogger logr.Logger, hostname string, resticCLI *resticCli.Restic) error {
+	filename := fmt.Sprintf("/%s-%s", hostname, pod.ContainerName)
 	data, err := kubernetes.PodExec(pod, mainLogger)
 	if err != nil {
+		resticCLI.RecordStdinBackupFailure(pod, filename, err)
 		return fmt.Errorf("error occurred during data stream from k8s: %w", err)
 	}
-	filename := fmt.Sprintf("/%s-%s", hostname, pod.ContainerName)
-	err = resticCLI.StdinBackup(data, filename, pod.FileExtension, cfg.Config.Tags)
+	err = resticCLI.StdinBackup(data, pod, filename, cfg.Config.Tags)
 	if err != nil {
 		return fmt.Errorf("backup commands failed: %w", err)
 	}
@@ -25,6 +25,7 @@ integration-test: export RESTORE_SECRETACCESSKEY = $(minio_root_password)
 integration-test: export BACKUP_DIR = $(backup_dir)
 integration-test: export RESTORE_DIR = $(restore_dir)
 integration-test: export STATS_URL = $(stats_url)
+integration-test: export APPEND_ONLY_REPOSITORY = rest:http://$(rest_server_address)/
 # }
 integration-test: $(setup_envtest_bin) generate restic-integration-test-setup .envtest_crds ## Run integration tests with envtest
 	$(setup_envtest_bin) $(ENVTEST_ADDITIONAL_FLAGS) use '$(ENVTEST_K8S_VERSION)!'
@@ -5,17 +5,19 @@ import (
 	"time"
 
 	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
-	"github.com/k8up-io/k8up/v2/operator/cfg"
 	"github.com/k8up-io/k8up/v2/operator/job"
 	"github.com/k8up-io/k8up/v2/operator/locker"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // ArchiveReconciler reconciles Archive objects
 type ArchiveReconciler struct {
-	Kube client.Client
+	Kube     client.Client
+	Recorder events.EventRecorder
 }
 
 func (r *ArchiveReconciler) NewObject() *k8upv1.Archive {
@@ -29,21 +31,29 @@ func (r *ArchiveReconciler) NewObjectList() *k8upv1.ArchiveList {
 func (r *ArchiveReconciler) Provision(ctx context.Context, obj *k8upv1.Archive) (controllerruntime.Result, error) {
 	log := controllerruntime.LoggerFrom(ctx)
 
-	repository := cfg.Config.GetGlobalRepository()
-	if obj.Spec.Backend != nil {
-		repository = obj.Spec.Backend.String()
-	}
 	if obj.Spec.RestoreSpec == nil {
 		obj.Spec.RestoreSpec = &k8upv1.RestoreSpec{}
 	}
-	config := job.NewConfig(r.Kube, obj, repository)
+	config, err := job.NewRepositoryConfig(ctx, r.Kube, obj, &obj.Spec.RunnableSpec, k8upv1.CredentialOperationBackup)
+	if err != nil {
+		return controllerruntime.Result{}, err
+	}
+	config.Recorder = r.Recorder
 	executor := NewArchiveExecutor(config)
+	if executor.restoreMethod == nil && config.ClusterRepository != nil && config.ClusterRepository.Spec.RestoreS3 != nil {
+		executor.restoreMethod = &k8upv1.RestoreMethod{S3: &k8upv1.S3Spec{}}
+	}
+	if executor.restoreMethod != nil && executor.restoreMethod.S3 != nil {
+		if err := job.ApplyClusterRestoreS3(ctx, r.Kube, config.ClusterRepository, obj, executor.restoreMethod.S3); err != nil {
+			return controllerruntime.Result{}, err
+		}
+	}
 
 	jobKey := types.NamespacedName{
 		Namespace: obj.GetNamespace(),
 		Name:      executor.jobName(),
 	}
-	if err := job.ReconcileJobStatus(ctx, jobKey, r.Kube, obj); err != nil {
+	if err := config.ReconcileJobStatus(ctx, jobKey); err != nil {
 		return controllerruntime.Result{}, err
 	}
 
@@ -57,10 +67,15 @@ func (r *ArchiveReconciler) Provision(ctx context.Context, obj *k8upv1.Archive)
 		return controllerruntime.Result{}, nil
 	}
 
-	lock := locker.GetForRepository(r.Kube, repository)
+	if !config.RepositoryReady(ctx) {
+		return controllerruntime.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	lock := locker.GetForRepository(r.Kube, config.Repository)
 	didRun, err := lock.TryRun(ctx, config, executor.GetConcurrencyLimit(), executor.Execute)
 	if !didRun && err == nil {
 		log.Info("Skipping job due to exclusivity or concurrency limit")
+		config.Eventf(nil, corev1.EventTypeNormal, job.EventReasonWaiting, job.EventActionWait, "Waiting for other jobs of repository to finish or for the concurrency limit")
 	}
 	return controllerruntime.Result{RequeueAfter: time.Second * 30}, err
 }
@@ -24,14 +24,21 @@ const (
 type ArchiveExecutor struct {
 	executor.Generic
 	archive *k8upv1.Archive
+	// restoreMethod is a copy of the archive's restore method, whose S3 target is completed with the ClusterRepository.
+	restoreMethod *k8upv1.RestoreMethod
 }
 
 // NewArchiveExecutor will return a new executor for archive jobs.
 func NewArchiveExecutor(config job.Config) *ArchiveExecutor {
-	return &ArchiveExecutor{
+	archive := config.Obj.(*k8upv1.Archive)
+	e := &ArchiveExecutor{
 		Generic: executor.Generic{Config: config},
-		archive: config.Obj.(*k8upv1.Archive),
+		archive: archive,
 	}
+	if archive.Spec.RestoreSpec != nil {
+		e.restoreMethod = archive.Spec.RestoreMethod.DeepCopy()
+	}
+	return e
 }
 
 // GetConcurrencyLimit returns the concurrent jobs limit
@@ -56,7 +63,11 @@ func (a *ArchiveExecutor) Execute(ctx context.Context) error {
 		batchJob.Spec.Template.Spec.Containers[0].Env = append(batchJob.Spec.Template.Spec.Containers[0].Env, a.setupEnvVars(ctx, a.archive)...)
 		a.archive.Spec.AppendEnvFromToContainer(&batchJob.Spec.Template.Spec.Containers[0])
 		batchJob.Spec.Template.Spec.Containers[0].VolumeMounts = append(batchJob.Spec.Template.Spec.Containers[0].VolumeMounts, a.attachTLSVolumeMounts()...)
-		batchJob.Spec.Template.Spec.Volumes = append(batchJob.Spec.Template.Spec.Volumes, utils.AttachEmptyDirVolumes(a.archive.Spec.Volumes)...)
+		executor.AttachBackend(&batchJob.Spec.Template, a.Backend)
+		if err := executor.AttachServiceAccount(ctx, a.Client, &batchJob.Spec.Template.Spec, batchJob.Namespace, a.Backend); err != nil {
+			return err
+		}
+		batchJob.Spec.Template.Spec.Volumes = append(batchJob.Spec.Template.Spec.Volumes, utils.AttachEmptyDirVolumes(a.Volumes, a.tlsMounts()...)...)
 
 		batchJob.Spec.Template.Spec.Containers[0].Args = a.setupArgs()
 
@@ -69,6 +80,7 @@ func (a *ArchiveExecutor) Execute(ctx context.Context) error {
 	}
 
 	a.SetStarted(ctx, "the job '%v/%v' was created", batchJob.Namespace, batchJob.Name)
+	a.Eventf(batchJob, corev1.EventTypeNormal, job.EventReasonJobCreated, job.EventActionCreate, "Created job '%s'", batchJob.Name)
 	return nil
 }
 
@@ -81,11 +93,11 @@ func (a *ArchiveExecutor) setupArgs() []string {
 	if a.archive.Spec.RestoreSpec != nil && len(a.archive.Spec.Tags) > 0 {
 		args = append(args, executor.BuildListArgs("--tag", a.archive.Spec.Tags)...)
 	}
-	if a.archive.Spec.Backend != nil {
-		args = append(args, utils.AppendTLSOptionsArgs(a.archive.Spec.Backend.TLSOptions)...)
+	if a.Backend != nil {
+		args = append(args, utils.AppendTLSOptionsArgs(a.Backend.TLSOptions)...)
 	}
-	if a.archive.Spec.RestoreSpec != nil && a.archive.Spec.RestoreMethod != nil {
-		args = append(args, utils.AppendTLSOptionsArgs(a.archive.Spec.RestoreMethod.TLSOptions, certPrefixName)...)
+	if a.restoreMethod != nil {
+		args = append(args, utils.AppendTLSOptionsArgs(a.restoreMethod.TLSOptions, certPrefixName)...)
 	}
 
 	return args
@@ -95,8 +107,8 @@ func (a *ArchiveExecutor) setupEnvVars(ctx context.Context, archive *k8upv1.Arch
 	log := controllerruntime.LoggerFrom(ctx)
 	vars := executor.NewEnvVarConverter()
 
-	if archive.Spec.RestoreSpec != nil && archive.Spec.RestoreMethod != nil {
-		for key, value := range archive.Spec.RestoreMethod.S3.RestoreEnvVars() {
+	if a.restoreMethod != nil {
+		for key, value := range a.restoreMethod.S3.RestoreEnvVars() {
 			// FIXME(mw): ugly, due to EnvVarConverter()
 			if value.Value != "" {
 				vars.SetString(key, value.Value)
@@ -104,22 +116,27 @@ func (a *ArchiveExecutor) setupEnvVars(ctx context.Context, archive *k8upv1.Arch
 				vars.SetEnvVarSource(key, value.ValueFrom)
 			}
 		}
+		if err := vars.Merge(executor.DefaultRestoreEnv()); err != nil {
+			log.Error(err, "error while merging the restore environment variables", "name", archive.GetName(), "namespace", archive.GetNamespace())
+		}
 	}
 
-	if archive.Spec.RestoreSpec != nil && archive.Spec.RestoreMethod != nil {
-		if archive.Spec.RestoreMethod.Folder != nil {
+	if a.restoreMethod != nil {
+		if a.restoreMethod.Folder != nil {
 			vars.SetString("RESTORE_DIR", archivePath)
 		}
 	}
 
-	if archive.Spec.Backend != nil {
-		for key, value := range archive.Spec.Backend.GetCredentialEnv() {
+	if a.Backend != nil {
+		for key, value := range a.Backend.GetCredentialEnv() {
 			vars.SetEnvVarSource(key, value)
 		}
-		vars.SetString(cfg.ResticRepositoryEnvName, archive.Spec.Backend.String())
+		vars.SetString(cfg.ResticRepositoryEnvName, a.Backend.String())
 	}
 
-	err := vars.Merge(executor.DefaultEnv(a.Obj.GetNamespace()))
+	a.SetRepositoryEnv(&vars, a.Backend)
+
+	err := vars.Merge(executor.DefaultEnv(a.Obj.GetNamespace(), a.Backend))
 	if err != nil {
 		log.Error(err, "error while merging the environment variables", "name", a.Obj.GetName(), "namespace", a.Obj.GetNamespace())
 	}
@@ -133,12 +150,22 @@ func (a *ArchiveExecutor) cleanupOldArchives(ctx context.Context, archive *k8upv
 
 func (a *ArchiveExecutor) attachTLSVolumeMounts() []corev1.VolumeMount {
 	var tlsVolumeMounts []corev1.VolumeMount
-	if a.archive.Spec.Backend != nil && !utils.ZeroLen(a.archive.Spec.Backend.VolumeMounts) {
-		tlsVolumeMounts = append(tlsVolumeMounts, *a.archive.Spec.Backend.VolumeMounts...)
+	if a.Backend != nil && !utils.ZeroLen(a.Backend.VolumeMounts) {
+		tlsVolumeMounts = append(tlsVolumeMounts, *a.Backend.VolumeMounts...)
 	}
-	if a.archive.Spec.RestoreSpec != nil && a.archive.Spec.RestoreMethod != nil && !utils.ZeroLen(a.archive.Spec.RestoreMethod.VolumeMounts) {
-		tlsVolumeMounts = append(tlsVolumeMounts, *a.archive.Spec.RestoreMethod.VolumeMounts...)
+	if a.restoreMethod != nil && !utils.ZeroLen(a.restoreMethod.VolumeMounts) {
+		tlsVolumeMounts = append(tlsVolumeMounts, *a.restoreMethod.VolumeMounts...)
 	}
 
+	tlsVolumeMounts = append(tlsVolumeMounts, utils.TLSVolumeMounts(a.tlsMounts()...)...)
+
 	return utils.AttachEmptyDirVolumeMounts(cfg.Config.PodVarDir, &tlsVolumeMounts)
 }
+
+// tlsMounts returns the TLS options of the backend and of the restore method, the latter are mounted below the cert prefix.
+func (a *ArchiveExecutor) tlsMounts() []utils.TLSMount {
+	if a.restoreMethod == nil {
+		return executor.TLSMounts(a.Backend)
+	}
+	return executor.TLSMounts(a.Backend, utils.TLSMount{Options: a.restoreMethod.TLSOptions, Prefix: certPrefixName})
+}
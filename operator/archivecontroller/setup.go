@@ -16,7 +16,8 @@ import (
 func SetupWithManager(mgr controllerruntime.Manager) error {
 	name := "archive.k8up.io"
 	r := reconciler.NewReconciler[*k8upv1.Archive, *k8upv1.ArchiveList](mgr.GetClient(), &ArchiveReconciler{
-		Kube: mgr.GetClient(),
+		Kube:     mgr.GetClient(),
+		Recorder: mgr.GetEventRecorder(name),
 	})
 	return controllerruntime.NewControllerManagedBy(mgr).
 		For(&k8upv1.Archive{}).
@@ -10,12 +10,11 @@ import (
 	"github.com/k8up-io/k8up/v2/operator/executor"
 	"github.com/k8up-io/k8up/v2/operator/utils"
 	corev1 "k8s.io/api/core/v1"
-	rbacv1 "k8s.io/api/rbac/v1"
-	controllerruntime "sigs.k8s.io/controller-runtime"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
 	"github.com/k8up-io/k8up/v2/operator/cfg"
 )
 
@@ -130,35 +129,9 @@ func containsAccessMode(s []corev1.PersistentVolumeAccessMode, e corev1.Persiste
 	return false
 }
 
+// createServiceAccountAndBinding creates the ServiceAccount the backup jobs run as and allows it to execute commands in pods.
 func (b *BackupExecutor) createServiceAccountAndBinding(ctx context.Context) error {
-	sa := &corev1.ServiceAccount{}
-	sa.Name = cfg.Config.ServiceAccount
-	sa.Namespace = b.backup.Namespace
-	_, err := controllerruntime.CreateOrUpdate(ctx, b.Client, sa, func() error {
-		return nil
-	})
-	if err != nil {
-		return err
-	}
-
-	roleBinding := &rbacv1.RoleBinding{}
-	roleBinding.Name = cfg.Config.PodExecRoleName + "-namespaced"
-	roleBinding.Namespace = b.backup.Namespace
-	_, err = controllerruntime.CreateOrUpdate(ctx, b.Client, roleBinding, func() error {
-		roleBinding.Subjects = []rbacv1.Subject{
-			{
-				Kind:      "ServiceAccount",
-				Namespace: b.backup.Namespace,
-				Name:      sa.Name,
-			},
-		}
-		roleBinding.RoleRef = rbacv1.RoleRef{
-			Kind:     "ClusterRole",
-			Name:     "k8up-executor",
-			APIGroup: "rbac.authorization.k8s.io",
-		}
-		return nil
-	})
+	_, err := executor.ReconcileExecutorServiceAccount(ctx, b.Client, b.backup.Namespace, b.Backend)
 	return err
 }
 
@@ -167,8 +140,8 @@ func (b *BackupExecutor) setupArgs(userArgs []string) []string {
 	if len(b.backup.Spec.Tags) > 0 {
 		args = append(args, executor.BuildListArgs("--tag", b.backup.Spec.Tags)...)
 	}
-	if b.backup.Spec.Backend != nil {
-		args = append(args, utils.AppendTLSOptionsArgs(b.backup.Spec.Backend.TLSOptions)...)
+	if b.Backend != nil {
+		args = append(args, utils.AppendTLSOptionsArgs(b.Backend.TLSOptions)...)
 	}
 	args = append(args, userArgs...)
 
@@ -179,11 +152,11 @@ func (b *BackupExecutor) setupEnvVars() ([]corev1.EnvVar, error) {
 	vars := executor.NewEnvVarConverter()
 
 	if b.backup != nil {
-		if b.backup.Spec.Backend != nil {
-			for key, value := range b.backup.Spec.Backend.GetCredentialEnv() {
+		if b.Backend != nil {
+			for key, value := range b.Backend.GetCredentialEnv() {
 				vars.SetEnvVarSource(key, value)
 			}
-			vars.SetString(cfg.ResticRepositoryEnvName, b.backup.Spec.Backend.String())
+			vars.SetString(cfg.ResticRepositoryEnvName, b.Backend.String())
 		}
 	}
 
@@ -196,8 +169,15 @@ func (b *BackupExecutor) setupEnvVars() ([]corev1.EnvVar, error) {
 	if cfg.Config.SkipSnapshotSync {
 		vars.SetString("BACKUP_SKIP_SNAPSHOT_SYNC", "true")
 	}
+	if b.backup.Spec.FailOnReadErrors {
+		vars.SetString("FAIL_ON_READ_ERRORS", "true")
+	}
+	vars.SetString("BACKUP_NAME", b.backup.Name)
+	vars.SetString("SCHEDULE_NAME", b.backup.Labels[k8upv1.LabelK8upScheduleName])
+
+	b.SetRepositoryEnv(&vars, b.Backend)
 
-	err := vars.Merge(executor.DefaultEnv(b.backup.GetNamespace()))
+	err := vars.Merge(executor.DefaultEnv(b.backup.GetNamespace(), b.Backend))
 	if err != nil {
 		return nil, fmt.Errorf("cannot merge environment variables: %w", err)
 	}
@@ -14,6 +14,7 @@ import (
 func TestBackupExecutor_setupEnvVars(t *testing.T) {
 	tests := map[string]struct {
 		givenSpec       v1.BackupSpec
+		givenLabels     map[string]string
 		givenConfig     *cfg.Configuration
 		expectedEnvVars []corev1.EnvVar
 	}{
@@ -49,6 +50,20 @@ func TestBackupExecutor_setupEnvVars(t *testing.T) {
 				{Name: "PROM_URL", Value: "https://custom:port/prom"},
 			},
 		},
+		"GivenFailOnReadErrors_ThenExpectVariable": {
+			givenSpec: v1.BackupSpec{FailOnReadErrors: true},
+			expectedEnvVars: []corev1.EnvVar{
+				{Name: "FAIL_ON_READ_ERRORS", Value: "true"},
+			},
+		},
+		"GivenScheduledBackup_ThenExpectBackupAndScheduleName": {
+			givenSpec:   v1.BackupSpec{},
+			givenLabels: map[string]string{v1.LabelK8upScheduleName: "schedule"},
+			expectedEnvVars: []corev1.EnvVar{
+				{Name: "BACKUP_NAME", Value: "backup"},
+				{Name: "SCHEDULE_NAME", Value: "schedule"},
+			},
+		},
 	}
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -59,7 +74,9 @@ func TestBackupExecutor_setupEnvVars(t *testing.T) {
 			backup := &v1.Backup{
 				Spec: tt.givenSpec,
 				ObjectMeta: metav1.ObjectMeta{
+					Name:      "backup",
 					Namespace: "testNamespace",
+					Labels:    tt.givenLabels,
 				},
 			}
 			if tt.givenConfig != nil {
@@ -79,3 +96,35 @@ func TestBackupExecutor_setupEnvVars(t *testing.T) {
 		})
 	}
 }
+
+func TestBackupExecutor_setupArgs(t *testing.T) {
+	tests := map[string]struct {
+		givenBackend *v1.Backend
+		expectedArgs []string
+	}{
+		"GivenS3BackendWithoutTLSOptions_ThenExpectNoCACert": {
+			givenBackend: &v1.Backend{S3: &v1.S3Spec{Endpoint: "https://s3.example.com", Bucket: "backup"}},
+			expectedArgs: []string{"-varDir", "/k8up"},
+		},
+		"GivenS3BackendWithCACert_ThenExpectCACertOfBackend": {
+			givenBackend: &v1.Backend{
+				S3:         &v1.S3Spec{Endpoint: "https://s3.example.com", Bucket: "backup"},
+				TLSOptions: &v1.TLSOptions{CACert: "/mnt/ca/ca.crt"},
+			},
+			expectedArgs: []string{"-varDir", "/k8up", "-caCert", "/mnt/ca/ca.crt"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			currentConfig := cfg.Config
+			defer func() {
+				cfg.Config = currentConfig
+			}()
+			cfg.Config = &cfg.Configuration{PodVarDir: "/k8up"}
+			backup := &v1.Backup{ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "testNamespace"}}
+			exec := NewBackupExecutor(job.Config{Obj: backup, Backend: tt.givenBackend})
+
+			assert.Equal(t, tt.expectedArgs, exec.setupArgs(nil))
+		})
+	}
+}
@@ -3,22 +3,28 @@ package backupcontroller
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
-	"github.com/k8up-io/k8up/v2/operator/cfg"
 	"github.com/k8up-io/k8up/v2/operator/job"
 	"github.com/k8up-io/k8up/v2/operator/locker"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/events"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+// maxReportedFailedPaths is the number of unreadable paths listed in the PartiallySucceeded condition.
+const maxReportedFailedPaths = 10
+
 // BackupReconciler reconciles a Backup object
 type BackupReconciler struct {
-	Kube client.Client
+	Kube     client.Client
+	Recorder events.EventRecorder
 }
 
 func (r *BackupReconciler) NewObject() *k8upv1.Backup {
@@ -32,14 +38,14 @@ func (r *BackupReconciler) NewObjectList() *k8upv1.BackupList {
 func (r *BackupReconciler) Provision(ctx context.Context, obj *k8upv1.Backup) (reconcile.Result, error) {
 	log := controllerruntime.LoggerFrom(ctx)
 
-	repository := cfg.Config.GetGlobalRepository()
-	if obj.Spec.Backend != nil {
-		repository = obj.Spec.Backend.String()
+	config, err := job.NewRepositoryConfig(ctx, r.Kube, obj, &obj.Spec.RunnableSpec, k8upv1.CredentialOperationBackup)
+	if err != nil {
+		return controllerruntime.Result{}, err
 	}
-	config := job.NewConfig(r.Kube, obj, repository)
+	config.Recorder = r.Recorder
 	executor := NewBackupExecutor(config)
 
-	if err := r.ReconcileJobStatus(ctx, obj); err != nil {
+	if err := r.ReconcileJobStatus(ctx, config, obj); err != nil {
 		return controllerruntime.Result{}, err
 	}
 
@@ -63,17 +69,22 @@ func (r *BackupReconciler) Provision(ctx context.Context, obj *k8upv1.Backup) (r
 		return controllerruntime.Result{RequeueAfter: time.Second * 30}, nil
 	}
 
-	lock := locker.GetForRepository(r.Kube, repository)
+	if !config.RepositoryReady(ctx) {
+		return controllerruntime.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	lock := locker.GetForRepository(r.Kube, config.Repository)
 	didRun, err := lock.TryRun(ctx, config, executor.GetConcurrencyLimit(), executor.Execute)
 	if !didRun && err == nil {
 		log.Info("Skipping job due to exclusivity or concurrency limit")
+		config.Eventf(nil, corev1.EventTypeNormal, job.EventReasonWaiting, job.EventActionWait, "Waiting for other jobs of repository to finish or for the concurrency limit")
 	}
 	return controllerruntime.Result{RequeueAfter: time.Second * 30}, err
 }
 
 // ReconcileJobStatus implements a custom job reconciliation since there can be multiple jobs per Backup (this is different
 // from the implementation in the job package).
-func (r *BackupReconciler) ReconcileJobStatus(ctx context.Context, obj *k8upv1.Backup) error {
+func (r *BackupReconciler) ReconcileJobStatus(ctx context.Context, config job.Config, obj *k8upv1.Backup) error {
 	log := controllerruntime.LoggerFrom(ctx)
 	ownedBy := obj.GetType().String() + "_" + obj.GetName()
 	log.V(1).Info("reconciling jobs", "owned-by", ownedBy)
@@ -102,12 +113,29 @@ func (r *BackupReconciler) ReconcileJobStatus(ctx context.Context, obj *k8upv1.B
 		}
 	}
 
-	objStatus := obj.Status
+	results, err := r.collectBackupResults(ctx, jobList.Items)
+	if err != nil {
+		return err
+	}
+	obj.Status.BackupResults = results
+
+	objStatus := obj.GetStatus()
 	message := fmt.Sprintf("%q has %d succeeded, %d failed, and %d started jobs", ownedBy, numSucceeded, numFailed, numStarted)
 	if numJobs == numSucceeded {
-		job.SetSucceeded(ctx, ownedBy, obj.Namespace, obj.GetType(), &objStatus, message)
+		wasPartiallySucceeded := meta.IsStatusConditionTrue(objStatus.Conditions, k8upv1.ConditionPartiallySucceeded.String())
+		config.SetSucceeded(ctx, ownedBy, &objStatus, message)
+		if errorCount, paths := results.ReadErrors(maxReportedFailedPaths); errorCount > 0 {
+			readErrors := fmt.Sprintf("%d files could not be read", errorCount)
+			if len(paths) > 0 {
+				readErrors += ": " + strings.Join(paths, ", ")
+			}
+			if !wasPartiallySucceeded {
+				config.Eventf(nil, corev1.EventTypeWarning, job.EventReasonPartiallySucceeded, job.EventActionComplete, "Backup completed, but %s", readErrors)
+			}
+			objStatus.SetPartiallySucceeded(fmt.Sprintf("%s; %s", message, readErrors))
+		}
 	} else if numFailed > 0 {
-		job.SetFailed(ctx, ownedBy, obj.Namespace, obj.GetType(), &objStatus, message)
+		config.SetFailed(ctx, ownedBy, &objStatus, message)
 	} else if numStarted > 0 {
 		objStatus.SetStarted(message)
 	}
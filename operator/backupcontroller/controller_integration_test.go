@@ -57,6 +57,41 @@ func (ts *BackupTestSuite) Test_GivenBackup_ExpectBackupJob() {
 	ts.expectABackupJob()
 }
 
+func (ts *BackupTestSuite) Test_GivenBackupWithBackupCredentials_WhenConditionIsUpdatedBeforeJobCreation_ThenExpectBackupCredentialsInJob() {
+	ts.BackupResource.Spec.Backend = &k8upv1.Backend{
+		S3: &k8upv1.S3Spec{
+			Endpoint:                 "http://minio:9000",
+			Bucket:                   "backups",
+			AccessKeyIDSecretRef:     &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "admin"}, Key: "id"},
+			SecretAccessKeySecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "admin"}, Key: "secret"},
+		},
+		Credentials: &k8upv1.OperationCredentials{Backup: &k8upv1.CredentialSet{S3: &k8upv1.S3Spec{
+			AccessKeyIDSecretRef:     &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "append-only"}, Key: "id"},
+			SecretAccessKeySecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "append-only"}, Key: "secret"},
+		}}},
+	}
+	pvc := ts.newPvc("test-pvc", corev1.ReadWriteMany)
+	ts.EnsureResources(ts.BackupResource, pvc)
+
+	pvc.Status.Phase = corev1.ClaimBound
+	ts.UpdateStatus(pvc)
+
+	ts.whenReconciling(ts.BackupResource)
+
+	// The PreBackupPodReady condition is updated between the resolution of the backend and the creation of the job,
+	// which resets the spec of the backup to the stored one.
+	ts.assertCondition(ts.BackupResource.Status.Conditions, k8upv1.ConditionPreBackupPodReady, k8upv1.ReasonNoPreBackupPodsFound, metav1.ConditionTrue)
+	backupJob := ts.expectABackupJob()
+	secretRefs := map[string]string{}
+	for _, env := range backupJob.Spec.Template.Spec.Containers[0].Env {
+		if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+			secretRefs[env.Name] = env.ValueFrom.SecretKeyRef.Name
+		}
+	}
+	ts.Assert().Equal("append-only", secretRefs["AWS_ACCESS_KEY_ID"])
+	ts.Assert().Equal("append-only", secretRefs["AWS_SECRET_ACCESS_KEY"])
+}
+
 func (ts *BackupTestSuite) Test_GivenBackup_AndJob_KeepBackupProgressing() {
 	backupJob := ts.newJob(ts.BackupResource)
 	pvc := ts.newPvc("test-pvc", corev1.ReadWriteMany)
@@ -421,7 +421,7 @@ func (ts *BackupTestSuite) markDeploymentAsFinished(deployment *appsv1.Deploymen
 }
 
 func (ts *BackupTestSuite) markBackupAsFinished(backup *k8upv1.Backup) {
-	backup.Status = k8upv1.Status{
+	backup.Status.Status = k8upv1.Status{
 		Started:  true,
 		Finished: true,
 		Conditions: []metav1.Condition{
@@ -22,6 +22,7 @@ import (
 	"k8s.io/utils/ptr"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 // BackupExecutor creates a batch.job object on the cluster. It merges all the
@@ -311,7 +312,7 @@ func (b *BackupExecutor) startBackup(ctx context.Context) error {
 
 	index := 0
 	for name, batchJob := range backupJobs {
-		_, err = controllerruntime.CreateOrUpdate(ctx, b.Client, batchJob.job, func() error {
+		op, err := controllerruntime.CreateOrUpdate(ctx, b.Client, batchJob.job, func() error {
 			mutateErr := job.MutateBatchJob(ctx, batchJob.job, b.backup, b.Config, b.Client)
 			if mutateErr != nil {
 				return mutateErr
@@ -343,8 +344,8 @@ func (b *BackupExecutor) startBackup(ctx context.Context) error {
 			}
 
 			// each job sleeps for index * 10 seconds to avoid concurrent restic repository creation. Not the prettiest way but it works and a repository
-			// is created only once usually.
-			if name == "prebackup" || index != 0 {
+			// is created only once usually. Repositories managed by a Repository object are initialized by the operator beforehand.
+			if b.ManagedRepository == nil && (name == "prebackup" || index != 0) {
 				secondsToSleep := time.Duration(index*10) * time.Second
 				batchJob.job.Spec.Template.Spec.Containers[0].Env = append(batchJob.job.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
 					Name:  "SLEEP_DURATION",
@@ -353,14 +354,17 @@ func (b *BackupExecutor) startBackup(ctx context.Context) error {
 			}
 			b.backup.Spec.AppendEnvFromToContainer(&batchJob.job.Spec.Template.Spec.Containers[0])
 			batchJob.job.Spec.Template.Spec.Volumes = append(batchJob.job.Spec.Template.Spec.Volumes, batchJob.volumes...)
-			batchJob.job.Spec.Template.Spec.Volumes = append(batchJob.job.Spec.Template.Spec.Volumes, utils.AttachEmptyDirVolumes(b.backup.Spec.Volumes)...)
+			batchJob.job.Spec.Template.Spec.Volumes = append(batchJob.job.Spec.Template.Spec.Volumes, utils.AttachEmptyDirVolumes(b.Volumes, executor.TLSMounts(b.Backend)...)...)
 			batchJob.job.Spec.Template.Spec.Containers[0].VolumeMounts = append(batchJob.job.Spec.Template.Spec.Containers[0].VolumeMounts, b.newVolumeMounts(batchJob.volumes)...)
 			batchJob.job.Spec.Template.Spec.Containers[0].VolumeMounts = append(batchJob.job.Spec.Template.Spec.Containers[0].VolumeMounts, b.attachTLSVolumeMounts()...)
+			executor.AttachBackend(&batchJob.job.Spec.Template, b.Backend)
 			batchJob.job.Spec.BackoffLimit = ptr.To(int32(cfg.Config.GlobalBackoffLimit))
 
 			batchJob.job.Spec.Template.Spec.Containers[0].Args = b.setupArgs(batchJob.resticArgs)
 
-			if batchJob.job.Spec.Template.Spec.ServiceAccountName == "" {
+			if name := executor.ServiceAccountName(b.Backend, ""); name != "" {
+				batchJob.job.Spec.Template.Spec.ServiceAccountName = name
+			} else if batchJob.job.Spec.Template.Spec.ServiceAccountName == "" {
 				batchJob.job.Spec.Template.Spec.ServiceAccountName = cfg.Config.ServiceAccount
 			}
 
@@ -370,6 +374,9 @@ func (b *BackupExecutor) startBackup(ctx context.Context) error {
 		if err != nil {
 			return fmt.Errorf("unable to createOrUpdate(%q): %w", batchJob.job.Name, err)
 		}
+		if op == controllerutil.OperationResultCreated {
+			b.Eventf(batchJob.job, corev1.EventTypeNormal, job.EventReasonJobCreated, job.EventActionCreate, "Created job '%s'", batchJob.job.Name)
+		}
 	}
 
 	if len(backupJobs) == 0 {
@@ -406,9 +413,11 @@ func (b *BackupExecutor) jobName(name string) string {
 
 func (b *BackupExecutor) attachTLSVolumeMounts() []corev1.VolumeMount {
 	var tlsVolumeMounts []corev1.VolumeMount
-	if b.backup.Spec.Backend != nil && !utils.ZeroLen(b.backup.Spec.Backend.VolumeMounts) {
-		tlsVolumeMounts = append(tlsVolumeMounts, *b.backup.Spec.Backend.VolumeMounts...)
+	if b.Backend != nil && !utils.ZeroLen(b.Backend.VolumeMounts) {
+		tlsVolumeMounts = append(tlsVolumeMounts, *b.Backend.VolumeMounts...)
 	}
 
+	tlsVolumeMounts = append(tlsVolumeMounts, utils.TLSVolumeMounts(executor.TLSMounts(b.Backend)...)...)
+
 	return utils.AttachEmptyDirVolumeMounts(cfg.Config.PodVarDir, &tlsVolumeMounts)
 }
@@ -4,12 +4,14 @@ import (
 	"context"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/job"
 )
 
 // StartPreBackup will start the defined pods as deployments.
@@ -71,6 +73,8 @@ func (b *BackupExecutor) allDeploymentsAreReady(ctx context.Context, deployments
 		ready, err := isPreBackupDeploymentReady(deployment)
 		if err != nil {
 			log.Info("backup failed: deadline exceeded on pre backup deployment")
+			b.Eventf(deployment, corev1.EventTypeWarning, job.EventReasonPreBackupPodTimeout, job.EventActionWait, "%s", err.Error())
+			b.recordPreBackupPodEvent(ctx, deployment, corev1.EventTypeWarning, job.EventReasonPreBackupPodTimeout, job.EventActionWait, "%s", err.Error())
 			b.SetConditionFalseWithMessage(ctx, k8upv1.ConditionPreBackupPodReady, k8upv1.ReasonFailed, "%s", err.Error())
 			b.SetConditionTrueWithMessage(ctx, k8upv1.ConditionReady, k8upv1.ReasonFailed, "%s", err.Error())
 			b.deletePreBackupDeployment(ctx, deployment)
@@ -85,6 +89,17 @@ func (b *BackupExecutor) allDeploymentsAreReady(ctx context.Context, deployments
 	return true, nil
 }
 
+// recordPreBackupPodEvent records an Event about the PreBackupPod the given deployment has been generated from.
+func (b *BackupExecutor) recordPreBackupPodEvent(ctx context.Context, deployment *appsv1.Deployment, eventtype, reason, action, note string, args ...interface{}) {
+	preBackupPod := &k8upv1.PreBackupPod{}
+	key := client.ObjectKey{Namespace: deployment.Namespace, Name: deployment.Labels["k8up.io/preBackupPod"]}
+	if err := b.Client.Get(ctx, key, preBackupPod); err != nil {
+		controllerruntime.LoggerFrom(ctx).V(1).Info("cannot get PreBackupPod to record event", "preBackupPod", key.String(), "error", err.Error())
+		return
+	}
+	b.EventfFor(preBackupPod, b.Obj, eventtype, reason, action, note, args...)
+}
+
 // StopPreBackupDeployments will remove the deployments.
 func (b *BackupExecutor) StopPreBackupDeployments(ctx context.Context) {
 	log := controllerruntime.LoggerFrom(ctx)
@@ -0,0 +1,58 @@
+package backupcontroller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/job"
+)
+
+// collectBackupResults returns the results that the backup containers of the given jobs reported in their termination messages.
+// Jobs that haven't finished yet are skipped.
+func (r *BackupReconciler) collectBackupResults(ctx context.Context, jobs []batchv1.Job) (k8upv1.BackupResults, error) {
+	log := controllerruntime.LoggerFrom(ctx)
+	results := k8upv1.BackupResults{}
+	for _, item := range jobs {
+		if !job.HasSucceeded(item.Status.Conditions) && !job.HasFailed(item.Status.Conditions) {
+			continue
+		}
+		pods := &corev1.PodList{}
+		if err := r.Kube.List(ctx, pods, client.InNamespace(item.Namespace), client.MatchingLabels{batchv1.JobNameLabel: item.Name}); err != nil {
+			return results, fmt.Errorf("list pods of job %q: %w", item.Name, err)
+		}
+		pod, message := job.LastTerminationMessage(pods.Items)
+		if message == "" {
+			continue
+		}
+		reported := k8upv1.BackupResults{}
+		if err := json.Unmarshal([]byte(message), &reported); err != nil {
+			log.V(1).Info("ignoring termination message that doesn't contain backup results", "pod", pod.Name, "error", err.Error())
+			continue
+		}
+		for _, volume := range reported.Volumes {
+			volume.JobName = item.Name
+			volume.NodeName = pod.Spec.NodeName
+			results.Volumes = append(results.Volumes, volume)
+		}
+		for _, stdinBackup := range reported.StdinBackups {
+			stdinBackup.JobName = item.Name
+			stdinBackup.NodeName = pod.Spec.NodeName
+			results.StdinBackups = append(results.StdinBackups, stdinBackup)
+		}
+	}
+	sort.SliceStable(results.Volumes, func(i, j int) bool {
+		return results.Volumes[i].Name < results.Volumes[j].Name
+	})
+	sort.SliceStable(results.StdinBackups, func(i, j int) bool {
+		return results.StdinBackups[i].PodName < results.StdinBackups[j].PodName
+	})
+	return results, nil
+}
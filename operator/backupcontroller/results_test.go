@@ -0,0 +1,66 @@
+package backupcontroller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+)
+
+func TestBackupReconciler_collectBackupResults(t *testing.T) {
+	finished := batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup-node-a", Namespace: "app"},
+		Status:     batchv1.JobStatus{Conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}},
+	}
+	running := batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "backup-node-b", Namespace: "app"}}
+	now := metav1.Now()
+	objects := []client.Object{
+		newBackupPod("backup-node-a-old", finished.Name, "node-a", metav1.NewTime(now.Add(-time.Minute)), `{"volumes":[{"name":"old"}]}`),
+		newBackupPod("backup-node-a-new", finished.Name, "node-a", now, `{"volumes":[{"name":"data","result":"Succeeded"},{"name":"config","result":"Failed","message":"exit status 1"}],"stdinBackups":[{"podName":"mariadb","fileName":"app-mariadb.sql"}]}`),
+		newBackupPod("backup-node-b", running.Name, "node-b", now, `{"volumes":[{"name":"running"}]}`),
+	}
+	r := &BackupReconciler{Kube: newFakeClient(t, objects...)}
+
+	results, err := r.collectBackupResults(context.TODO(), []batchv1.Job{finished, running})
+	require.NoError(t, err)
+
+	require.Len(t, results.Volumes, 2, "only the latest pod of finished jobs counts")
+	assert.Equal(t, "config", results.Volumes[0].Name)
+	assert.Equal(t, k8upv1.BackupResultFailed, results.Volumes[0].Result)
+	assert.Equal(t, "exit status 1", results.Volumes[0].Message)
+	assert.Equal(t, "data", results.Volumes[1].Name)
+	assert.Equal(t, "backup-node-a", results.Volumes[1].JobName)
+	assert.Equal(t, "node-a", results.Volumes[1].NodeName)
+	require.Len(t, results.StdinBackups, 1)
+	assert.Equal(t, "mariadb", results.StdinBackups[0].PodName)
+	assert.Equal(t, "backup-node-a", results.StdinBackups[0].JobName)
+}
+
+func newBackupPod(name, jobName, nodeName string, finishedAt metav1.Time, message string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "app", Labels: map[string]string{batchv1.JobNameLabel: jobName}},
+		Spec:       corev1.PodSpec{NodeName: nodeName, Containers: []corev1.Container{{Name: "backup"}}},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  "backup",
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{FinishedAt: finishedAt, Message: message}},
+		}}},
+	}
+}
+
+func newFakeClient(t *testing.T, objects ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, k8upv1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
@@ -25,7 +25,8 @@ import (
 func SetupWithManager(mgr controllerruntime.Manager) error {
 	name := "backup.k8up.io"
 	r := reconciler.NewReconciler[*k8upv1.Backup, *k8upv1.BackupList](mgr.GetClient(), &BackupReconciler{
-		Kube: mgr.GetClient(),
+		Kube:     mgr.GetClient(),
+		Recorder: mgr.GetEventRecorder(name),
 	})
 	return controllerruntime.NewControllerManagedBy(mgr).
 		Named(name).
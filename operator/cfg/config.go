@@ -12,24 +12,53 @@ const (
 	RestoreS3AccessKeyIDEnvName     = "RESTORE_ACCESSKEYID"
 	RestoreS3SecretAccessKeyEnvName = "RESTORE_SECRETACCESSKEY"
 
-	ResticRepositoryEnvName = "RESTIC_REPOSITORY"
-	ResticPasswordEnvName   = "RESTIC_PASSWORD"
-	ResticOptionsEnvName    = "RESTIC_OPTIONS"
+	ResticRepositoryEnvName      = "RESTIC_REPOSITORY"
+	ResticPasswordEnvName        = "RESTIC_PASSWORD"
+	ResticPasswordFileEnvName    = "RESTIC_PASSWORD_FILE"
+	ResticPasswordCommandEnvName = "RESTIC_PASSWORD_COMMAND"
+	ResticOptionsEnvName         = "RESTIC_OPTIONS"
 
-	AwsAccessKeyIDEnvName     = "AWS_ACCESS_KEY_ID"
-	AwsSecretAccessKeyEnvName = "AWS_SECRET_ACCESS_KEY"
+	ResticFromRepositoryEnvName   = "RESTIC_FROM_REPOSITORY"
+	ResticFromPasswordEnvName     = "RESTIC_FROM_PASSWORD"
+	ResticFromPasswordFileEnvName = "RESTIC_FROM_PASSWORD_FILE"
+
+	AwsAccessKeyIDEnvName          = "AWS_ACCESS_KEY_ID"
+	AwsSecretAccessKeyEnvName      = "AWS_SECRET_ACCESS_KEY"
+	AwsRoleARNEnvName              = "AWS_ROLE_ARN"
+	AwsWebIdentityTokenFileEnvName = "AWS_WEB_IDENTITY_TOKEN_FILE"
 
 	AzureAccountEnvName    = "AZURE_ACCOUNT_NAME"
 	AzureAccountKeyEnvName = "AZURE_ACCOUNT_KEY"
+	AzureAccountSASEnvName = "AZURE_ACCOUNT_SAS"
+	AzureClientIDEnvName   = "AZURE_CLIENT_ID"
 
 	GcsProjectIDEnvName   = "GOOGLE_PROJECT_ID"
 	GcsAccessTokenEnvName = "GOOGLE_ACCESS_TOKEN"
+	GcsCredentialsEnvName = "GOOGLE_APPLICATION_CREDENTIALS"
 
 	B2AccountIDEnvName  = "B2_ACCOUNT_ID"
 	B2AccountKeyEnvName = "B2_ACCOUNT_KEY"
 
-	RestUserEnvName     = "USER"
-	RestPasswordEnvName = "PASSWORD"
+	SwiftAuthURLEnvName                     = "OS_AUTH_URL"
+	SwiftRegionNameEnvName                  = "OS_REGION_NAME"
+	SwiftUsernameEnvName                    = "OS_USERNAME"
+	SwiftPasswordEnvName                    = "OS_PASSWORD"
+	SwiftTenantIDEnvName                    = "OS_TENANT_ID"
+	SwiftTenantNameEnvName                  = "OS_TENANT_NAME"
+	SwiftUserDomainNameEnvName              = "OS_USER_DOMAIN_NAME"
+	SwiftProjectNameEnvName                 = "OS_PROJECT_NAME"
+	SwiftProjectDomainNameEnvName           = "OS_PROJECT_DOMAIN_NAME"
+	SwiftApplicationCredentialIDEnvName     = "OS_APPLICATION_CREDENTIAL_ID"
+	SwiftApplicationCredentialSecretEnvName = "OS_APPLICATION_CREDENTIAL_SECRET"
+
+	RestUserEnvName     = "RESTIC_REST_USERNAME"
+	RestPasswordEnvName = "RESTIC_REST_PASSWORD"
+
+	SFTPPrivateKeyFileEnvName = "SFTP_PRIVATE_KEY_FILE"
+	SFTPKnownHostsFileEnvName = "SFTP_KNOWN_HOSTS_FILE"
+	SFTPCommandEnvName        = "SFTP_COMMAND"
+
+	RcloneConfigEnvName = "RCLONE_CONFIG"
 
 	InsecureAllowPodExecSPDYFallback = "INSECURE_ALLOW_PODEXEC_SPDY_FALLBACK"
 )
@@ -68,6 +97,7 @@ type Configuration struct {
 	GlobalConcurrentArchiveJobsLimit int
 	GlobalConcurrentBackupJobsLimit  int
 	GlobalConcurrentCheckJobsLimit   int
+	GlobalConcurrentCopyJobsLimit    int
 	GlobalConcurrentPruneJobsLimit   int
 	GlobalConcurrentRestoreJobsLimit int
 	GlobalCPUResourceRequest         string
@@ -92,6 +122,10 @@ type Configuration struct {
 	EnableLeaderElection bool
 	OperatorNamespace    string
 
+	// Enabling this will register the admission webhooks, which requires a TLS certificate in WebhookCertDir.
+	EnableWebhooks bool
+	WebhookCertDir string
+
 	// the var data dir for read/write k8up data or temp file in the backup pod
 	PodVarDir string
 
@@ -128,6 +162,11 @@ func (c Configuration) GetGlobalDefaultResources() (res corev1.ResourceRequireme
 	return res
 }
 
+// HasGlobalRepository returns true if either the global S3 endpoint or bucket is set.
+func (c Configuration) HasGlobalRepository() bool {
+	return c.GlobalS3Endpoint != "" || c.GlobalS3Bucket != ""
+}
+
 // GetGlobalRepository is a shortcut for building an S3 string "s3:<endpoint>/<bucket>"
 func (c Configuration) GetGlobalRepository() string {
 	return fmt.Sprintf("s3:%s/%s", c.GlobalS3Endpoint, c.GlobalS3Bucket)
@@ -2,20 +2,27 @@ package checkcontroller
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
-	"github.com/k8up-io/k8up/v2/operator/cfg"
 	"github.com/k8up-io/k8up/v2/operator/job"
 	"github.com/k8up-io/k8up/v2/operator/locker"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // CheckReconciler reconciles a Check object
 type CheckReconciler struct {
-	Kube client.Client
+	Kube     client.Client
+	Recorder events.EventRecorder
 }
 
 func (r *CheckReconciler) NewObject() *k8upv1.Check {
@@ -29,12 +36,11 @@ func (r *CheckReconciler) NewObjectList() *k8upv1.CheckList {
 func (r *CheckReconciler) Provision(ctx context.Context, obj *k8upv1.Check) (controllerruntime.Result, error) {
 	log := controllerruntime.LoggerFrom(ctx)
 
-	repository := cfg.Config.GetGlobalRepository()
-	if obj.Spec.Backend != nil {
-		repository = obj.Spec.Backend.String()
+	config, err := job.NewRepositoryConfig(ctx, r.Kube, obj, &obj.Spec.RunnableSpec, k8upv1.CredentialOperationMaintenance)
+	if err != nil {
+		return controllerruntime.Result{}, err
 	}
-
-	config := job.NewConfig(r.Kube, obj, repository)
+	config.Recorder = r.Recorder
 
 	executor := NewCheckExecutor(config)
 
@@ -42,7 +48,7 @@ func (r *CheckReconciler) Provision(ctx context.Context, obj *k8upv1.Check) (con
 		Namespace: obj.GetNamespace(),
 		Name:      executor.jobName(),
 	}
-	if err := job.ReconcileJobStatus(ctx, jobKey, r.Kube, obj); err != nil {
+	if err := config.ReconcileJobStatus(ctx, jobKey); err != nil {
 		return controllerruntime.Result{}, err
 	}
 
@@ -52,14 +58,22 @@ func (r *CheckReconciler) Provision(ctx context.Context, obj *k8upv1.Check) (con
 	}
 
 	if obj.Status.HasFinished() {
+		if err := r.updateResults(ctx, obj, jobKey); err != nil {
+			return controllerruntime.Result{}, err
+		}
 		executor.cleanupOldChecks(ctx, obj)
 		return controllerruntime.Result{}, nil
 	}
 
-	lock := locker.GetForRepository(r.Kube, repository)
+	if !config.RepositoryReady(ctx) {
+		return controllerruntime.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	lock := locker.GetForRepository(r.Kube, config.Repository)
 	didRun, err := lock.TryRunExclusively(ctx, executor.Execute)
 	if !didRun && err == nil {
 		log.Info("Delaying check task, another job is running")
+		config.Eventf(nil, corev1.EventTypeNormal, job.EventReasonWaiting, job.EventActionWait, "Waiting for other jobs of repository to finish")
 	}
 	return controllerruntime.Result{RequeueAfter: time.Second * 30}, err
 }
@@ -67,3 +81,45 @@ func (r *CheckReconciler) Provision(ctx context.Context, obj *k8upv1.Check) (con
 func (r *CheckReconciler) Deprovision(_ context.Context, _ *k8upv1.Check) (controllerruntime.Result, error) {
 	return controllerruntime.Result{}, nil
 }
+
+// updateResults copies the results that the job's container reported in its termination message into the status.
+// The reason of a failure replaces the generic reason of the Completed condition,
+// which is set again whenever the job's status is reconciled.
+func (r *CheckReconciler) updateResults(ctx context.Context, obj *k8upv1.Check, jobKey types.NamespacedName) error {
+	changed := false
+	if obj.Status.Successful == nil {
+		pods := &corev1.PodList{}
+		if err := r.Kube.List(ctx, pods, client.InNamespace(jobKey.Namespace), client.MatchingLabels{batchv1.JobNameLabel: jobKey.Name}); err != nil {
+			return fmt.Errorf("list pods of job %q: %w", jobKey.Name, err)
+		}
+		pod, message := job.LastTerminationMessage(pods.Items)
+		if message == "" {
+			return nil
+		}
+		results := k8upv1.CheckResults{}
+		if err := json.Unmarshal([]byte(message), &results); err != nil {
+			controllerruntime.LoggerFrom(ctx).V(1).Info("ignoring termination message that doesn't contain check results", "pod", pod.Name, "error", err.Error())
+			return nil
+		}
+		if results.Successful == nil {
+			return nil
+		}
+		obj.Status.CheckResults = results
+		changed = true
+	}
+
+	completed := meta.FindStatusCondition(obj.Status.Conditions, k8upv1.ConditionCompleted.String())
+	reason := obj.Status.FailureReason
+	if reason != "" && obj.Status.HasFailed() && completed != nil && completed.Reason != reason.String() {
+		obj.Status.SetCondition(k8upv1.ConditionCompleted, reason, metav1.ConditionTrue, completed.Message)
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+	if err := r.Kube.Status().Update(ctx, obj); err != nil {
+		return fmt.Errorf("check status update failed: %w", err)
+	}
+	return nil
+}
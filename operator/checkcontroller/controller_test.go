@@ -0,0 +1,99 @@
+package checkcontroller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+)
+
+func TestCheckReconciler_updateResults(t *testing.T) {
+	tests := map[string]struct {
+		givenMessage       string
+		givenFailed        bool
+		expectedSuccessful *bool
+		expectedReason     k8upv1.ConditionReason
+	}{
+		"GivenSuccessfulCheck_ThenExpectResults": {
+			givenMessage:       `{"successful":true,"unreferencedPacks":2}`,
+			expectedSuccessful: ptr.To(true),
+			expectedReason:     k8upv1.ReasonSucceeded,
+		},
+		"GivenDamagedRepository_ThenExpectFailureReason": {
+			givenMessage:       `{"successful":false,"errorCount":1,"damagedPacks":["1a2b3c4d"],"failureReason":"RepositoryDamaged"}`,
+			givenFailed:        true,
+			expectedSuccessful: ptr.To(false),
+			expectedReason:     k8upv1.ReasonRepositoryDamaged,
+		},
+		"GivenOtherTerminationMessage_ThenExpectNoResults": {
+			givenMessage:   `{"snapshotsCopied":3}`,
+			givenFailed:    true,
+			expectedReason: k8upv1.ReasonFailed,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			obj := &k8upv1.Check{ObjectMeta: metav1.ObjectMeta{Name: "weekly", Namespace: "app"}}
+			if tt.givenFailed {
+				obj.Status.SetFailed("job 'check-weekly' has 0 active, 0 succeeded and 1 failed pods")
+			} else {
+				obj.Status.SetSucceeded("job 'check-weekly' has 0 active, 1 succeeded and 0 failed pods")
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "check-weekly-abcde", Namespace: "app", Labels: map[string]string{batchv1.JobNameLabel: "check-weekly"}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "check"}}},
+				Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  "check",
+					State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{FinishedAt: metav1.Now(), Message: tt.givenMessage}},
+				}}},
+			}
+			r := &CheckReconciler{Kube: newFakeClient(t, obj, pod)}
+
+			require.NoError(t, r.updateResults(context.TODO(), obj, types.NamespacedName{Namespace: "app", Name: "check-weekly"}))
+
+			stored := &k8upv1.Check{}
+			require.NoError(t, r.Kube.Get(context.TODO(), client.ObjectKeyFromObject(obj), stored))
+			assert.Equal(t, tt.expectedSuccessful, stored.Status.Successful)
+			completed := meta.FindStatusCondition(stored.Status.Conditions, k8upv1.ConditionCompleted.String())
+			require.NotNil(t, completed)
+			assert.Equal(t, tt.expectedReason.String(), completed.Reason)
+			assert.True(t, stored.Status.HasFinished())
+		})
+	}
+}
+
+func TestCheckReconciler_updateResults_GivenResetReason_ThenExpectFailureReasonAgain(t *testing.T) {
+	obj := &k8upv1.Check{ObjectMeta: metav1.ObjectMeta{Name: "weekly", Namespace: "app"}}
+	obj.Status.CheckResults = k8upv1.CheckResults{Successful: ptr.To(false), FailureReason: k8upv1.ReasonRepositoryLocked}
+	// The reconciliation of the job's status sets the generic reason again.
+	obj.Status.SetFailed("job 'check-weekly' has 0 active, 0 succeeded and 1 failed pods")
+	r := &CheckReconciler{Kube: newFakeClient(t, obj)}
+
+	require.NoError(t, r.updateResults(context.TODO(), obj, types.NamespacedName{Namespace: "app", Name: "check-weekly"}))
+
+	completed := meta.FindStatusCondition(obj.Status.Conditions, k8upv1.ConditionCompleted.String())
+	require.NotNil(t, completed)
+	assert.Equal(t, k8upv1.ReasonRepositoryLocked.String(), completed.Reason)
+	assert.Equal(t, "job 'check-weekly' has 0 active, 0 succeeded and 1 failed pods", completed.Message)
+	assert.True(t, obj.Status.HasFailed())
+}
+
+func newFakeClient(t *testing.T, objects ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, k8upv1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).WithStatusSubresource(&k8upv1.Check{}).Build()
+}
@@ -53,7 +53,11 @@ func (c *CheckExecutor) Execute(ctx context.Context) error {
 		batchJob.Spec.Template.Spec.Containers[0].Env = append(batchJob.Spec.Template.Spec.Containers[0].Env, c.setupEnvVars(ctx)...)
 		c.check.Spec.AppendEnvFromToContainer(&batchJob.Spec.Template.Spec.Containers[0])
 		batchJob.Spec.Template.Spec.Containers[0].VolumeMounts = append(batchJob.Spec.Template.Spec.Containers[0].VolumeMounts, c.attachTLSVolumeMounts()...)
-		batchJob.Spec.Template.Spec.Volumes = append(batchJob.Spec.Template.Spec.Volumes, utils.AttachEmptyDirVolumes(c.check.Spec.Volumes)...)
+		executor.AttachBackend(&batchJob.Spec.Template, c.Backend)
+		if err := executor.AttachServiceAccount(ctx, c.Client, &batchJob.Spec.Template.Spec, batchJob.Namespace, c.Backend); err != nil {
+			return err
+		}
+		batchJob.Spec.Template.Spec.Volumes = append(batchJob.Spec.Template.Spec.Volumes, utils.AttachEmptyDirVolumes(c.Volumes, executor.TLSMounts(c.Backend)...)...)
 		batchJob.Labels[job.K8upExclusive] = "true"
 
 		batchJob.Spec.Template.Spec.Containers[0].Args = c.setupArgs()
@@ -66,6 +70,7 @@ func (c *CheckExecutor) Execute(ctx context.Context) error {
 		return err
 	}
 	c.SetStarted(ctx, "the job '%v/%v' was created", batchJob.Namespace, batchJob.Name)
+	c.Eventf(batchJob, corev1.EventTypeNormal, job.EventReasonJobCreated, job.EventActionCreate, "Created job '%s'", batchJob.Name)
 	return nil
 }
 
@@ -75,8 +80,17 @@ func (c *CheckExecutor) jobName() string {
 
 func (c *CheckExecutor) setupArgs() []string {
 	args := []string{"-varDir", cfg.Config.PodVarDir, "-check"}
-	if c.check.Spec.Backend != nil {
-		args = append(args, utils.AppendTLSOptionsArgs(c.check.Spec.Backend.TLSOptions)...)
+	if c.check.Spec.ReadData {
+		args = append(args, "-readData")
+	}
+	if c.check.Spec.ReadDataSubset != "" {
+		args = append(args, "-readDataSubset", c.check.Spec.ReadDataSubset)
+	}
+	if c.check.Spec.WithCache {
+		args = append(args, "-withCache")
+	}
+	if c.Backend != nil {
+		args = append(args, utils.AppendTLSOptionsArgs(c.Backend.TLSOptions)...)
 	}
 
 	return args
@@ -87,18 +101,20 @@ func (c *CheckExecutor) setupEnvVars(ctx context.Context) []corev1.EnvVar {
 	vars := executor.NewEnvVarConverter()
 
 	if c.check != nil {
-		if c.check.Spec.Backend != nil {
-			for key, value := range c.check.Spec.Backend.GetCredentialEnv() {
+		if c.Backend != nil {
+			for key, value := range c.Backend.GetCredentialEnv() {
 				vars.SetEnvVarSource(key, value)
 			}
-			vars.SetString(cfg.ResticRepositoryEnvName, c.check.Spec.Backend.String())
+			vars.SetString(cfg.ResticRepositoryEnvName, c.Backend.String())
 		}
 	}
 
 	vars.SetString("PROM_URL", cfg.Config.PromURL)
 	vars.SetString("CLUSTER_NAME", cfg.Config.ClusterName)
 
-	err := vars.Merge(executor.DefaultEnv(c.Obj.GetNamespace()))
+	c.SetRepositoryEnv(&vars, c.Backend)
+
+	err := vars.Merge(executor.DefaultEnv(c.Obj.GetNamespace(), c.Backend))
 	if err != nil {
 		log.Error(err, "error while merging the environment variables", "name", c.Obj.GetName(), "namespace", c.Obj.GetNamespace())
 	}
@@ -112,9 +128,11 @@ func (c *CheckExecutor) cleanupOldChecks(ctx context.Context, check *k8upv1.Chec
 
 func (c *CheckExecutor) attachTLSVolumeMounts() []corev1.VolumeMount {
 	var tlsVolumeMounts []corev1.VolumeMount
-	if c.check.Spec.Backend != nil && !utils.ZeroLen(c.check.Spec.Backend.VolumeMounts) {
-		tlsVolumeMounts = append(tlsVolumeMounts, *c.check.Spec.Backend.VolumeMounts...)
+	if c.Backend != nil && !utils.ZeroLen(c.Backend.VolumeMounts) {
+		tlsVolumeMounts = append(tlsVolumeMounts, *c.Backend.VolumeMounts...)
 	}
 
+	tlsVolumeMounts = append(tlsVolumeMounts, utils.TLSVolumeMounts(executor.TLSMounts(c.Backend)...)...)
+
 	return utils.AttachEmptyDirVolumeMounts(cfg.Config.PodVarDir, &tlsVolumeMounts)
 }
@@ -11,12 +11,14 @@ import (
 // +kubebuilder:rbac:groups=k8up.io,resources=checks,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=k8up.io,resources=checks/status;checks/finalizers,verbs=get;update;patch
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=list;get;watch
 
 // SetupWithManager configures the reconciler.
 func SetupWithManager(mgr ctrl.Manager) error {
 	name := "check.k8up.io"
 	r := reconciler.NewReconciler[*k8upv1.Check, *k8upv1.CheckList](mgr.GetClient(), &CheckReconciler{
-		Kube: mgr.GetClient(),
+		Kube:     mgr.GetClient(),
+		Recorder: mgr.GetEventRecorder(name),
 	})
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&k8upv1.Check{}).
@@ -0,0 +1,136 @@
+package copycontroller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/job"
+	"github.com/k8up-io/k8up/v2/operator/locker"
+)
+
+// CopyReconciler reconciles a Copy object
+type CopyReconciler struct {
+	Kube     client.Client
+	Recorder events.EventRecorder
+}
+
+func (r *CopyReconciler) NewObject() *k8upv1.Copy {
+	return &k8upv1.Copy{}
+}
+
+func (r *CopyReconciler) NewObjectList() *k8upv1.CopyList {
+	return &k8upv1.CopyList{}
+}
+
+func (r *CopyReconciler) Provision(ctx context.Context, obj *k8upv1.Copy) (controllerruntime.Result, error) {
+	log := controllerruntime.LoggerFrom(ctx)
+
+	config, err := job.NewRepositoryConfig(ctx, r.Kube, obj, &obj.Spec.RunnableSpec, k8upv1.CredentialOperationBackup)
+	if err != nil {
+		return controllerruntime.Result{}, err
+	}
+	config.Recorder = r.Recorder
+	executor := NewCopyExecutor(config)
+
+	jobKey := types.NamespacedName{
+		Namespace: obj.GetNamespace(),
+		Name:      executor.jobName(),
+	}
+	if err := config.ReconcileJobStatus(ctx, jobKey); err != nil {
+		return controllerruntime.Result{}, err
+	}
+
+	if obj.Status.HasStarted() {
+		log.V(1).Info("copy just started, waiting")
+		return controllerruntime.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+
+	if obj.Status.HasFinished() {
+		if err := r.updateResults(ctx, obj, jobKey); err != nil {
+			return controllerruntime.Result{}, err
+		}
+		executor.cleanupOldCopies(ctx, obj)
+		return controllerruntime.Result{}, nil
+	}
+
+	if err := checkRepositories(config.Backend, executor.destination, config.Repository); err != nil {
+		// The job can't run until the Copy, or the Repository or ClusterRepository it uses, is changed.
+		log.Info("Not creating copy job", "reason", err.Error())
+		config.SetConditionFalseWithMessage(ctx, k8upv1.ConditionReady, k8upv1.ReasonCreationFailed, "cannot copy: %v", err)
+		return controllerruntime.Result{}, nil
+	}
+
+	if !config.RepositoryReady(ctx) {
+		return controllerruntime.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	lock := locker.GetForRepository(r.Kube, config.Repository)
+	didRun, err := lock.TryRunWith(ctx, config, executor.GetConcurrencyLimit(), executor.destination.String(), executor.Execute)
+	if !didRun && err == nil {
+		log.Info("Delaying copy task, another job is running")
+		config.Eventf(nil, corev1.EventTypeNormal, job.EventReasonWaiting, job.EventActionWait, "Waiting for other jobs of the source and destination repositories to finish")
+	}
+	return controllerruntime.Result{RequeueAfter: time.Second * 30}, err
+}
+
+func (r *CopyReconciler) Deprovision(_ context.Context, _ *k8upv1.Copy) (controllerruntime.Result, error) {
+	return controllerruntime.Result{}, nil
+}
+
+// checkRepositories returns an error if the source and destination can't be used for a copy.
+// The admission webhook validates the Copy only, while the source may as well come from a Repository or ClusterRepository.
+func checkRepositories(source, destination *k8upv1.Backend, repository string) error {
+	if destination == nil || !destination.HasStorageType() {
+		return fmt.Errorf("the destination doesn't configure a storage backend")
+	}
+	if destination.String() == repository {
+		return fmt.Errorf("the destination %q is the source repository", repository)
+	}
+	if unsupported := source.UnsupportedCopySettings(); len(unsupported) > 0 {
+		return fmt.Errorf("the source uses settings that aren't supported for copies: %s", strings.Join(unsupported, ", "))
+	}
+	if conflicts := k8upv1.CopyCredentialConflicts(source, destination); len(conflicts) > 0 {
+		return fmt.Errorf("the source and the destination set different credentials: %s", strings.Join(conflicts, ", "))
+	}
+	return nil
+}
+
+// updateResults copies the number of copied snapshots that the job's container reported in its termination message into the status.
+// This is done for failed jobs as well, as the snapshots copied before the failure remain in the destination.
+func (r *CopyReconciler) updateResults(ctx context.Context, obj *k8upv1.Copy, jobKey types.NamespacedName) error {
+	if obj.Status.SnapshotsCopied != nil {
+		return nil
+	}
+	pods := &corev1.PodList{}
+	if err := r.Kube.List(ctx, pods, client.InNamespace(jobKey.Namespace), client.MatchingLabels{batchv1.JobNameLabel: jobKey.Name}); err != nil {
+		return fmt.Errorf("list pods of job %q: %w", jobKey.Name, err)
+	}
+	pod, message := job.LastTerminationMessage(pods.Items)
+	if message == "" {
+		return nil
+	}
+	results := k8upv1.CopyResults{}
+	if err := json.Unmarshal([]byte(message), &results); err != nil {
+		controllerruntime.LoggerFrom(ctx).V(1).Info("ignoring termination message that doesn't contain copy results", "pod", pod.Name, "error", err.Error())
+		return nil
+	}
+	if results.SnapshotsCopied == nil {
+		return nil
+	}
+	obj.Status.CopyResults = results
+	if err := r.Kube.Status().Update(ctx, obj); err != nil {
+		return fmt.Errorf("copy status update failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package copycontroller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/cfg"
+	"github.com/k8up-io/k8up/v2/operator/job"
+)
+
+func TestCopyReconciler_updateResults(t *testing.T) {
+	obj := &k8upv1.Copy{ObjectMeta: metav1.ObjectMeta{Name: "offsite", Namespace: "app"}}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "copy-offsite-abcde", Namespace: "app", Labels: map[string]string{batchv1.JobNameLabel: "copy-offsite"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "copy"}}},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  "copy",
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{FinishedAt: metav1.Now(), Message: `{"snapshotsCopied":3}`}},
+		}}},
+	}
+	r := &CopyReconciler{Kube: newFakeClient(t, obj, pod)}
+
+	require.NoError(t, r.updateResults(context.TODO(), obj, types.NamespacedName{Namespace: "app", Name: "copy-offsite"}))
+
+	require.NotNil(t, obj.Status.SnapshotsCopied)
+	assert.Equal(t, 3, *obj.Status.SnapshotsCopied)
+	stored := &k8upv1.Copy{}
+	require.NoError(t, r.Kube.Get(context.TODO(), client.ObjectKeyFromObject(obj), stored))
+	require.NotNil(t, stored.Status.SnapshotsCopied)
+	assert.Equal(t, 3, *stored.Status.SnapshotsCopied)
+}
+
+func TestCheckRepositories(t *testing.T) {
+	source := &k8upv1.Backend{S3: &k8upv1.S3Spec{Endpoint: "http://minio:9000", Bucket: "backups", AccessKeyIDSecretRef: &corev1.SecretKeySelector{Key: "id"}}}
+	tests := map[string]struct {
+		givenDestination *k8upv1.Backend
+		givenSource      *k8upv1.Backend
+		expectedError    string
+	}{
+		"GivenOtherRepository_ThenExpectNoError": {
+			givenSource:      source,
+			givenDestination: &k8upv1.Backend{Local: &k8upv1.LocalSpec{MountPath: "/data/copy"}},
+		},
+		"GivenNoDestination_ThenExpectError": {
+			givenSource:   source,
+			expectedError: "the destination doesn't configure a storage backend",
+		},
+		"GivenSourceRepository_ThenExpectError": {
+			givenSource:      source,
+			givenDestination: &k8upv1.Backend{S3: &k8upv1.S3Spec{Endpoint: "http://minio:9000", Bucket: "backups"}},
+			expectedError:    `the destination "s3:http://minio:9000/backups" is the source repository`,
+		},
+		"GivenSourceWithRclone_ThenExpectError": {
+			givenSource:      &k8upv1.Backend{Rclone: &k8upv1.RcloneSpec{Remote: "remote", Path: "backups"}},
+			givenDestination: &k8upv1.Backend{Local: &k8upv1.LocalSpec{MountPath: "/data/copy"}},
+			expectedError:    "the source uses settings that aren't supported for copies: rclone",
+		},
+		"GivenDifferentAccessKeys_ThenExpectError": {
+			givenSource:      source,
+			givenDestination: &k8upv1.Backend{S3: &k8upv1.S3Spec{Endpoint: "http://minio:9000", Bucket: "copy", AccessKeyIDSecretRef: &corev1.SecretKeySelector{Key: "other-id"}}},
+			expectedError:    "the source and the destination set different credentials: " + cfg.AwsAccessKeyIDEnvName,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := checkRepositories(tc.givenSource, tc.givenDestination, tc.givenSource.String())
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestCopyExecutor_setupEnvVars(t *testing.T) {
+	obj := &k8upv1.Copy{
+		ObjectMeta: metav1.ObjectMeta{Name: "offsite", Namespace: "app"},
+		Spec: k8upv1.CopySpec{
+			RunnableSpec: k8upv1.RunnableSpec{Backend: &k8upv1.Backend{
+				RepoPasswordSecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "source"}, Key: "password"},
+				Local:                 &k8upv1.LocalSpec{MountPath: "/data/source"},
+			}},
+			Destination: &k8upv1.Backend{
+				RepoPasswordSecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "destination"}, Key: "password"},
+				Local:                 &k8upv1.LocalSpec{MountPath: "/data/destination"},
+			},
+		},
+	}
+	e := NewCopyExecutor(job.Config{Obj: obj, Backend: obj.Spec.Backend})
+
+	env := map[string]corev1.EnvVar{}
+	for _, envVar := range e.setupEnvVars(context.TODO()) {
+		env[envVar.Name] = envVar
+	}
+
+	assert.Equal(t, "/data/source", env[cfg.ResticFromRepositoryEnvName].Value)
+	assert.Equal(t, "source", env[cfg.ResticFromPasswordEnvName].ValueFrom.SecretKeyRef.Name)
+	assert.Equal(t, "/data/destination", env[cfg.ResticRepositoryEnvName].Value)
+	assert.Equal(t, "destination", env[cfg.ResticPasswordEnvName].ValueFrom.SecretKeyRef.Name)
+	assert.NotContains(t, env, "SKIP_INIT")
+}
+
+func TestCopyExecutor_setupEnvVars_GlobalSourcePassword(t *testing.T) {
+	defer func(password string) { cfg.Config.GlobalRepoPassword = password }(cfg.Config.GlobalRepoPassword)
+	cfg.Config.GlobalRepoPassword = "global"
+	obj := &k8upv1.Copy{
+		ObjectMeta: metav1.ObjectMeta{Name: "offsite", Namespace: "app"},
+		Spec: k8upv1.CopySpec{
+			RunnableSpec: k8upv1.RunnableSpec{Backend: &k8upv1.Backend{Local: &k8upv1.LocalSpec{MountPath: "/data/source"}}},
+			Destination:  &k8upv1.Backend{Local: &k8upv1.LocalSpec{MountPath: "/data/destination"}},
+		},
+	}
+	e := NewCopyExecutor(job.Config{Obj: obj, Backend: obj.Spec.Backend})
+
+	env := map[string]string{}
+	for _, envVar := range e.setupEnvVars(context.TODO()) {
+		env[envVar.Name] = envVar.Value
+	}
+
+	assert.Equal(t, "global", env[cfg.ResticFromPasswordEnvName])
+	assert.Equal(t, "global", env[cfg.ResticPasswordEnvName])
+}
+
+func TestAttachSourcePassword(t *testing.T) {
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "copy"}}}
+	attachSourcePassword(podSpec, &k8upv1.Backend{RepoPasswordFileSecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "source"}, Key: "password"}})
+
+	require.Len(t, podSpec.Volumes, 1)
+	assert.Equal(t, "source", podSpec.Volumes[0].Secret.SecretName)
+	assert.Equal(t, []corev1.KeyToPath{{Key: "password", Path: "password"}}, podSpec.Volumes[0].Secret.Items)
+	assert.Equal(t, []corev1.VolumeMount{{Name: "k8up-source-password", MountPath: "/etc/k8up/source-password", ReadOnly: true}}, podSpec.Containers[0].VolumeMounts)
+	assert.Equal(t, []corev1.EnvVar{{Name: cfg.ResticFromPasswordFileEnvName, Value: "/etc/k8up/source-password/password"}}, podSpec.Containers[0].Env)
+}
+
+func newFakeClient(t *testing.T, objects ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, k8upv1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).WithStatusSubresource(&k8upv1.Copy{}).Build()
+}
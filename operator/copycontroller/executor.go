@@ -0,0 +1,194 @@
+package copycontroller
+
+import (
+	"context"
+	"path"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/cfg"
+	"github.com/k8up-io/k8up/v2/operator/executor"
+	"github.com/k8up-io/k8up/v2/operator/job"
+	"github.com/k8up-io/k8up/v2/operator/utils"
+)
+
+const (
+	sourcePasswordVolumeName = "k8up-source-password"
+	sourcePasswordMountPath  = "/etc/k8up/source-password"
+	sourcePasswordFileName   = "password"
+)
+
+// CopyExecutor will execute the batch.job that copies snapshots into another repository.
+// restic opens the destination as its repository and reads the source from the RESTIC_FROM_* variables.
+type CopyExecutor struct {
+	executor.Generic
+	copy *k8upv1.Copy
+	// destination is the destination of the copy, limited to the credentials for backups.
+	destination *k8upv1.Backend
+}
+
+// NewCopyExecutor will return a new executor for copy jobs.
+func NewCopyExecutor(config job.Config) *CopyExecutor {
+	obj := config.Obj.(*k8upv1.Copy)
+	return &CopyExecutor{
+		Generic:     executor.Generic{Config: config},
+		copy:        obj,
+		destination: obj.Spec.Destination.WithCredentialsFor(k8upv1.CredentialOperationBackup),
+	}
+}
+
+// GetConcurrencyLimit returns the concurrent jobs limit
+func (c *CopyExecutor) GetConcurrencyLimit() int {
+	return cfg.Config.GlobalConcurrentCopyJobsLimit
+}
+
+// Execute creates the actual batch.job on the k8s api.
+func (c *CopyExecutor) Execute(ctx context.Context) error {
+	destination := c.destination
+	saName, err := executor.ReconcileExecutorServiceAccount(ctx, c.Client, c.copy.Namespace, destination)
+	if err != nil {
+		c.SetConditionFalseWithMessage(ctx, k8upv1.ConditionReady, k8upv1.ReasonCreationFailed, "could not create service account: %v", err)
+		return err
+	}
+
+	batchJob := &batchv1.Job{}
+	batchJob.Name = c.jobName()
+	batchJob.Namespace = c.copy.Namespace
+
+	_, err = controllerruntime.CreateOrUpdate(ctx, c.Client, batchJob, func() error {
+		mutateErr := job.MutateBatchJob(ctx, batchJob, c.copy, c.Config, c.Client)
+		if mutateErr != nil {
+			return mutateErr
+		}
+
+		batchJob.Spec.Template.Spec.Containers[0].Env = append(batchJob.Spec.Template.Spec.Containers[0].Env, c.setupEnvVars(ctx)...)
+		c.copy.Spec.AppendEnvFromToContainer(&batchJob.Spec.Template.Spec.Containers[0])
+		if destination.EnvFrom != nil {
+			batchJob.Spec.Template.Spec.Containers[0].EnvFrom = append(batchJob.Spec.Template.Spec.Containers[0].EnvFrom, destination.EnvFrom...)
+		}
+		batchJob.Spec.Template.Spec.Containers[0].VolumeMounts = append(batchJob.Spec.Template.Spec.Containers[0].VolumeMounts, c.attachTLSVolumeMounts()...)
+		executor.AttachBackend(&batchJob.Spec.Template, destination)
+		attachSourcePassword(&batchJob.Spec.Template.Spec, c.Backend)
+		batchJob.Spec.Template.Spec.ServiceAccountName = saName
+		batchJob.Spec.Template.Spec.Volumes = append(batchJob.Spec.Template.Spec.Volumes, utils.AttachEmptyDirVolumes(c.Volumes, executor.TLSMounts(destination)...)...)
+		batchJob.Labels[k8upv1.LabelDestinationRepositoryHash] = job.Sha256Hash(destination.String())
+
+		batchJob.Spec.Template.Spec.Containers[0].Args = c.setupArgs()
+
+		return nil
+	})
+	if err != nil {
+		c.SetConditionFalseWithMessage(ctx, k8upv1.ConditionReady, k8upv1.ReasonCreationFailed, "could not create job: %v", err)
+		return err
+	}
+	c.SetStarted(ctx, "the job '%v/%v' was created", batchJob.Namespace, batchJob.Name)
+	c.Eventf(batchJob, corev1.EventTypeNormal, job.EventReasonJobCreated, job.EventActionCreate, "Created job '%s'", batchJob.Name)
+	return nil
+}
+
+func (c *CopyExecutor) jobName() string {
+	return k8upv1.CopyType.String() + "-" + c.copy.Name
+}
+
+func (c *CopyExecutor) setupArgs() []string {
+	args := []string{"-varDir", cfg.Config.PodVarDir, "-copy"}
+	args = append(args, executor.BuildListArgs("-tag", c.copy.Spec.Tags)...)
+	args = append(args, executor.BuildListArgs("-host", c.copy.Spec.Hosts)...)
+	args = append(args, executor.BuildListArgs("-path", c.copy.Spec.Paths)...)
+	args = append(args, utils.AppendTLSOptionsArgs(c.destination.TLSOptions)...)
+	return args
+}
+
+// setupEnvVars sets the credentials of the source first and those of the destination afterwards,
+// the controller has made sure that they don't differ.
+// The password of the source is renamed to RESTIC_FROM_PASSWORD, the global password is used if the source doesn't set one.
+func (c *CopyExecutor) setupEnvVars(ctx context.Context) []corev1.EnvVar {
+	log := controllerruntime.LoggerFrom(ctx)
+	vars := executor.NewEnvVarConverter()
+
+	source := c.Backend
+	sourcePassword := cfg.Config.GlobalRepoPassword
+	if source != nil {
+		for key, value := range source.GetCredentialEnv() {
+			if key == cfg.ResticPasswordEnvName {
+				vars.SetEnvVarSource(cfg.ResticFromPasswordEnvName, value)
+				continue
+			}
+			vars.SetEnvVarSource(key, value)
+		}
+		vars.SetString(cfg.ResticFromRepositoryEnvName, source.String())
+		if source.HasRepoPassword() {
+			sourcePassword = ""
+		}
+	} else {
+		vars.SetString(cfg.ResticFromRepositoryEnvName, cfg.Config.GetGlobalRepository())
+	}
+	if sourcePassword != "" {
+		vars.SetString(cfg.ResticFromPasswordEnvName, sourcePassword)
+	}
+
+	destination := c.destination
+	for key, value := range destination.GetCredentialEnv() {
+		vars.SetEnvVarSource(key, value)
+	}
+	vars.SetString(cfg.ResticRepositoryEnvName, destination.String())
+
+	// The destination is initialized by the job if it doesn't exist yet, so SKIP_INIT isn't set for a managed source.
+	options := ""
+	if c.ManagedRepository != nil {
+		options = c.ManagedRepository.GetResticOptions()
+	} else if c.ClusterRepository != nil {
+		options = c.ClusterRepository.GetResticOptions()
+	}
+	executor.SetResticOptions(&vars, options, destination)
+
+	err := vars.Merge(executor.DefaultEnv(c.Obj.GetNamespace(), destination))
+	if err != nil {
+		log.Error(err, "error while merging the environment variables", "name", c.Obj.GetName(), "namespace", c.Obj.GetNamespace())
+	}
+
+	return vars.Convert()
+}
+
+func (c *CopyExecutor) cleanupOldCopies(ctx context.Context, obj *k8upv1.Copy) {
+	c.CleanupOldResources(ctx, &k8upv1.CopyList{}, obj)
+}
+
+func (c *CopyExecutor) attachTLSVolumeMounts() []corev1.VolumeMount {
+	var tlsVolumeMounts []corev1.VolumeMount
+	for _, backend := range []*k8upv1.Backend{c.Backend, c.destination} {
+		if backend != nil && !utils.ZeroLen(backend.VolumeMounts) {
+			tlsVolumeMounts = append(tlsVolumeMounts, *backend.VolumeMounts...)
+		}
+	}
+
+	// Only the destination may set TLS options, restic doesn't apply them to the source.
+	tlsVolumeMounts = append(tlsVolumeMounts, utils.TLSVolumeMounts(executor.TLSMounts(c.destination)...)...)
+
+	return utils.AttachEmptyDirVolumeMounts(cfg.Config.PodVarDir, &tlsVolumeMounts)
+}
+
+// attachSourcePassword mounts the password file of the given source into the first container of the pod spec,
+// restic reads it from RESTIC_FROM_PASSWORD_FILE.
+func attachSourcePassword(podSpec *corev1.PodSpec, source *k8upv1.Backend) {
+	if source == nil || source.RepoPasswordFileSecretRef == nil {
+		return
+	}
+	ref := source.RepoPasswordFileSecretRef
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: sourcePasswordVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: ref.Name,
+				Items:      []corev1.KeyToPath{{Key: ref.Key, Path: sourcePasswordFileName}},
+				Optional:   ref.Optional,
+			},
+		},
+	})
+	container := &podSpec.Containers[0]
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: sourcePasswordVolumeName, MountPath: sourcePasswordMountPath, ReadOnly: true})
+	container.Env = append(container.Env, corev1.EnvVar{Name: cfg.ResticFromPasswordFileEnvName, Value: path.Join(sourcePasswordMountPath, sourcePasswordFileName)})
+}
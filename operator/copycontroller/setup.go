@@ -0,0 +1,32 @@
+package copycontroller
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/reconciler"
+)
+
+// +kubebuilder:rbac:groups=k8up.io,resources=copies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=k8up.io,resources=copies/status;copies/finalizers,verbs=get;update;patch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=list;get;watch
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;delete;update
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=bind,resourceNames=k8up-executor
+
+// SetupWithManager configures the reconciler.
+func SetupWithManager(mgr ctrl.Manager) error {
+	name := "copy.k8up.io"
+	r := reconciler.NewReconciler[*k8upv1.Copy, *k8upv1.CopyList](mgr.GetClient(), &CopyReconciler{
+		Kube:     mgr.GetClient(),
+		Recorder: mgr.GetEventRecorder(name),
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&k8upv1.Copy{}).
+		Owns(&batchv1.Job{}).
+		Named(name).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Complete(r)
+}
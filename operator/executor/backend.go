@@ -0,0 +1,27 @@
+package executor
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/utils"
+)
+
+// AttachBackend configures the first container of the pod template to access the given backend:
+// it mounts the SFTP keys, the rclone configuration and the repository password and sets up the cloud identity.
+// The backend's ServiceAccount isn't attached, the executors decide which ServiceAccount their jobs run as.
+func AttachBackend(template *corev1.PodTemplateSpec, backend *k8upv1.Backend) {
+	AttachSFTPKeys(&template.Spec, backend)
+	AttachRcloneConfig(&template.Spec, backend)
+	AttachRepoPassword(&template.Spec, backend)
+	AttachCloudIdentity(template, backend)
+}
+
+// TLSMounts returns the TLS options of the given backend followed by the given additional ones.
+// Their referenced Secrets and ConfigMaps are mounted into the job's pod.
+func TLSMounts(backend *k8upv1.Backend, additional ...utils.TLSMount) []utils.TLSMount {
+	if backend == nil {
+		return additional
+	}
+	return append([]utils.TLSMount{{Options: backend.TLSOptions}}, additional...)
+}
@@ -0,0 +1,66 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/cfg"
+	"github.com/k8up-io/k8up/v2/operator/utils"
+)
+
+func TestAttachBackend(t *testing.T) {
+	template := &corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "prune"}}}}
+	AttachBackend(template, &k8upv1.Backend{
+		RepoPasswordFileSecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "password"}, Key: "file"},
+		Rclone: &k8upv1.RcloneSpec{
+			Remote:          "dropbox",
+			ConfigSecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "rclone"}, Key: "config"},
+		},
+	})
+
+	var secretNames []string
+	for _, volume := range template.Spec.Volumes {
+		secretNames = append(secretNames, volume.Secret.SecretName)
+	}
+	assert.ElementsMatch(t, []string{"password", "rclone"}, secretNames)
+	assert.Len(t, template.Spec.Containers[0].VolumeMounts, 2)
+	assert.Contains(t, template.Spec.Containers[0].Env, corev1.EnvVar{Name: cfg.RcloneConfigEnvName, Value: "/etc/k8up/rclone/rclone.conf"})
+}
+
+func TestAttachBackend_NoBackend(t *testing.T) {
+	template := &corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "prune"}}}}
+	AttachBackend(template, nil)
+
+	assert.Empty(t, template.Spec.Volumes)
+	assert.Empty(t, template.Spec.Containers[0].VolumeMounts)
+	assert.Empty(t, template.Spec.Containers[0].Env)
+}
+
+func TestTLSMounts(t *testing.T) {
+	backendOptions := &k8upv1.TLSOptions{CACert: "/mnt/ca/ca.crt"}
+	restoreOptions := &k8upv1.TLSOptions{ClientCert: "/mnt/tls/tls.crt"}
+	tests := map[string]struct {
+		givenBackend    *k8upv1.Backend
+		givenAdditional []utils.TLSMount
+		expectedMounts  []utils.TLSMount
+	}{
+		"GivenNoBackend_ThenExpectNoMounts": {},
+		"GivenNoBackend_WhenAdditionalMounts_ThenExpectAdditionalMounts": {
+			givenAdditional: []utils.TLSMount{{Options: restoreOptions, Prefix: "restore"}},
+			expectedMounts:  []utils.TLSMount{{Options: restoreOptions, Prefix: "restore"}},
+		},
+		"GivenBackend_WhenAdditionalMounts_ThenExpectBackendFirst": {
+			givenBackend:    &k8upv1.Backend{TLSOptions: backendOptions},
+			givenAdditional: []utils.TLSMount{{Options: restoreOptions, Prefix: "restore"}},
+			expectedMounts:  []utils.TLSMount{{Options: backendOptions}, {Options: restoreOptions, Prefix: "restore"}},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedMounts, TLSMounts(tc.givenBackend, tc.givenAdditional...))
+		})
+	}
+}
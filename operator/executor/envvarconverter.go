@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"dario.cat/mergo"
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
 	"github.com/k8up-io/k8up/v2/operator/cfg"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -63,8 +64,8 @@ func (e *EnvVarConverter) Convert() []corev1.EnvVar {
 			envVar.Value = *value.stringEnv
 		}
 		if key == cfg.ResticRepositoryEnvName {
-			// set repository env var at the end so the rest backend url
-			// can use previous set rest server credentials
+			// set repository env var at the end so that it can refer
+			// to the variables that are set before
 			repositoryVar = envVar
 			repositoryVarSet = true
 		} else {
@@ -91,14 +92,22 @@ func (e *EnvVarConverter) Merge(source EnvVarConverter) error {
 }
 
 // DefaultEnv returns an environment that contains the default values for the fields.
-func DefaultEnv(namespace string) EnvVarConverter {
+// The global S3 access keys are left out if the given backend authenticates with an S3 role,
+// restic would use them instead of the role otherwise.
+// The global repository password is left out if the backend provides its password as a file or by a command,
+// the job doesn't use it then and it shouldn't be part of the job's environment.
+func DefaultEnv(namespace string, backend *k8upv1.Backend) EnvVarConverter {
 	defaults := NewEnvVarConverter()
 
 	defaults.SetString("STATS_URL", cfg.Config.GlobalStatsURL)
 	defaults.SetString(cfg.ResticRepositoryEnvName, fmt.Sprintf("s3:%s/%s", cfg.Config.GlobalS3Endpoint, cfg.Config.GlobalS3Bucket))
-	defaults.SetString(cfg.ResticPasswordEnvName, cfg.Config.GlobalRepoPassword)
-	defaults.SetString(cfg.AwsAccessKeyIDEnvName, cfg.Config.GlobalAccessKey)
-	defaults.SetString(cfg.AwsSecretAccessKeyEnvName, cfg.Config.GlobalSecretAccessKey)
+	if backend == nil || (backend.RepoPasswordFileSecretRef == nil && backend.RepoPasswordCommand == nil) {
+		defaults.SetString(cfg.ResticPasswordEnvName, cfg.Config.GlobalRepoPassword)
+	}
+	if backend == nil || backend.S3 == nil || backend.S3.RoleARN == "" {
+		defaults.SetString(cfg.AwsAccessKeyIDEnvName, cfg.Config.GlobalAccessKey)
+		defaults.SetString(cfg.AwsSecretAccessKeyEnvName, cfg.Config.GlobalSecretAccessKey)
+	}
 	defaults.SetString("HOSTNAME", namespace)
 
 	if cfg.Config.ResticOptions != "" {
@@ -116,3 +125,14 @@ func BuildListArgs(cliArg string, valueList []string) []string {
 	}
 	return args
 }
+
+// DefaultRestoreEnv returns an environment that contains the deprecated global S3 credentials for restores.
+// They only apply to restore targets that neither reference their credentials nor get them from a ClusterRepository.
+func DefaultRestoreEnv() EnvVarConverter {
+	defaults := NewEnvVarConverter()
+
+	defaults.SetString(cfg.RestoreS3AccessKeyIDEnvName, cfg.Config.GlobalRestoreS3AccessKey)
+	defaults.SetString(cfg.RestoreS3SecretAccessKeyEnvName, cfg.Config.GlobalRestoreS3SecretAccessKey)
+
+	return defaults
+}
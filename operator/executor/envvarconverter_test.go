@@ -3,7 +3,11 @@ package executor
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/cfg"
 )
 
 func TestEnvVarConverter_Merge(t *testing.T) {
@@ -50,3 +54,56 @@ func TestEnvVarConverter_Merge(t *testing.T) {
 		t.Error("nomergesource should have been merged in.")
 	}
 }
+
+func TestDefaultEnv_GlobalAccessKeys(t *testing.T) {
+	cfg.Config.GlobalAccessKey = "access"
+	cfg.Config.GlobalSecretAccessKey = "secret"
+	defer func() {
+		cfg.Config.GlobalAccessKey = ""
+		cfg.Config.GlobalSecretAccessKey = ""
+	}()
+
+	vars := DefaultEnv("app", &k8upv1.Backend{S3: &k8upv1.S3Spec{Bucket: "backups"}})
+	assert.Equal(t, "access", *vars.Vars[cfg.AwsAccessKeyIDEnvName].stringEnv)
+	assert.Equal(t, "secret", *vars.Vars[cfg.AwsSecretAccessKeyEnvName].stringEnv)
+
+	vars = DefaultEnv("app", &k8upv1.Backend{S3: &k8upv1.S3Spec{Bucket: "backups", RoleARN: "arn:aws:iam::123456789012:role/k8up"}})
+	assert.NotContains(t, vars.Vars, cfg.AwsAccessKeyIDEnvName, "a role must not be combined with the global access keys")
+	assert.NotContains(t, vars.Vars, cfg.AwsSecretAccessKeyEnvName, "a role must not be combined with the global access keys")
+}
+
+func TestDefaultEnv_GlobalRepoPassword(t *testing.T) {
+	cfg.Config.GlobalRepoPassword = "global"
+	defer func() { cfg.Config.GlobalRepoPassword = "" }()
+
+	tests := map[string]struct {
+		givenBackend     *k8upv1.Backend
+		expectedPassword bool
+	}{
+		"GivenNoBackend_ThenExpectGlobalPassword": {
+			expectedPassword: true,
+		},
+		"GivenPasswordSecretRef_ThenExpectGlobalPasswordAsDefault": {
+			givenBackend:     &k8upv1.Backend{RepoPasswordSecretRef: &corev1.SecretKeySelector{Key: "password"}},
+			expectedPassword: true,
+		},
+		"GivenPasswordFileSecretRef_ThenExpectNoGlobalPassword": {
+			givenBackend:     &k8upv1.Backend{RepoPasswordFileSecretRef: &corev1.SecretKeySelector{Key: "password"}},
+			expectedPassword: false,
+		},
+		"GivenPasswordCommand_ThenExpectNoGlobalPassword": {
+			givenBackend:     &k8upv1.Backend{RepoPasswordCommand: &k8upv1.PasswordCommandSpec{Command: "/bin/get-password"}},
+			expectedPassword: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			vars := DefaultEnv("app", tt.givenBackend)
+			if tt.expectedPassword {
+				assert.Contains(t, vars.Vars, cfg.ResticPasswordEnvName)
+			} else {
+				assert.NotContains(t, vars.Vars, cfg.ResticPasswordEnvName)
+			}
+		})
+	}
+}
@@ -9,8 +9,10 @@ import (
 	"strings"
 
 	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/cfg"
 	"github.com/k8up-io/k8up/v2/operator/executor/cleaner"
 	"github.com/k8up-io/k8up/v2/operator/job"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	controllerruntime "sigs.k8s.io/controller-runtime"
@@ -50,6 +52,39 @@ type CleanupJobObject interface {
 	cleaner.GetJobsHistoryLimiter
 }
 
+// SetRepositoryEnv adds the environment variables of the Repository the job refers to, if any,
+// and the restic options of the given backend.
+// The operator initializes Repositories itself, so the job doesn't need to unless the backend of the Repository has changed since.
+func (g *Generic) SetRepositoryEnv(vars *EnvVarConverter, backend *k8upv1.Backend) {
+	options := ""
+	if g.ManagedRepository != nil {
+		if g.ManagedRepository.IsInitialized() {
+			vars.SetString("SKIP_INIT", "true")
+		}
+		options = g.ManagedRepository.GetResticOptions()
+	} else if g.ClusterRepository != nil {
+		options = g.ClusterRepository.GetResticOptions()
+	}
+	SetResticOptions(vars, options, backend)
+}
+
+// SetResticOptions adds the restic options of a job to the environment:
+// the given options, or the options of the operator if there are none, followed by the options of the backend.
+// restic uses the last value of an option that is given several times, so the options of the backend take precedence.
+func SetResticOptions(vars *EnvVarConverter, options string, backend *k8upv1.Backend) {
+	if options == "" {
+		options = cfg.Config.ResticOptions
+	}
+	var all []string
+	if options != "" {
+		all = append(all, options)
+	}
+	all = append(all, backend.ResticOptions()...)
+	if len(all) > 0 {
+		vars.SetString(cfg.ResticOptionsEnvName, strings.Join(all, ","))
+	}
+}
+
 func (g *Generic) CleanupOldResources(ctx context.Context, typ jobObjectList, runningJob CleanupJobObject) {
 	err := g.listOldResources(ctx, runningJob.GetNamespace(), typ)
 	if err != nil {
@@ -65,9 +100,13 @@ func (g *Generic) CleanupOldResources(ctx context.Context, typ jobObjectList, ru
 	cl := cleaner.NewObjectCleaner(g.Client, runningJob)
 	deleted, err := cl.CleanOldObjects(ctx, siblings)
 	if err != nil {
+		g.Eventf(nil, corev1.EventTypeWarning, job.EventReasonCleanupFailed, job.EventActionDelete, "Could not clean up old %s objects: %s", g.Obj.GetType(), err.Error())
 		g.SetConditionFalseWithMessage(ctx, k8upv1.ConditionScrubbed, k8upv1.ReasonDeletionFailed, "could not cleanup old resources: %s", err.Error())
 		return
 	}
+	if deleted > 0 {
+		g.Eventf(nil, corev1.EventTypeNormal, job.EventReasonCleanedUp, job.EventActionDelete, "Deleted %d old %s objects", deleted, g.Obj.GetType())
+	}
 	g.SetConditionTrueWithMessage(ctx, k8upv1.ConditionScrubbed, k8upv1.ReasonSucceeded, "Deleted %d resources", deleted)
 
 }
@@ -7,14 +7,17 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/cfg"
 	"github.com/k8up-io/k8up/v2/operator/job"
 )
 
@@ -89,7 +92,8 @@ func TestCleanupOldResources_DoesNotEvictOtherSchedulesBackups(t *testing.T) {
 		WithObjects(runningA, oldA, oldB, olderB, legacyA).
 		Build()
 
-	g := &Generic{Config: job.Config{Client: fclient, Obj: runningA}}
+	recorder := events.NewFakeRecorder(1)
+	g := &Generic{Config: job.Config{Client: fclient, Obj: runningA, Recorder: recorder}}
 	g.CleanupOldResources(context.Background(), &k8upv1.BackupList{}, runningA)
 
 	after := &k8upv1.BackupList{}
@@ -107,6 +111,7 @@ func TestCleanupOldResources_DoesNotEvictOtherSchedulesBackups(t *testing.T) {
 	assert.False(t, remaining["a-legacy"], "backup created before the Controller flag was set must still be evicted by its schedule's history limit")
 	assert.True(t, remaining["b-old"], "schedule-b's backups must not be touched by schedule-a's cleanup")
 	assert.True(t, remaining["b-older"], "schedule-b's backups must not be touched by schedule-a's cleanup")
+	assert.Equal(t, "Normal CleanedUp Deleted 2 old backup objects", <-recorder.Events)
 }
 
 // backupOwnedBy returns a Backup whose controlling OwnerReference UID is set
@@ -149,13 +154,84 @@ func newSuccessfulBackup(name, ns, scheduleUID string, createdAt time.Time) *k8u
 				Controller: ptr.To(true),
 			}},
 		},
-		Status: k8upv1.Status{
+		Status: k8upv1.BackupStatus{Status: k8upv1.Status{
 			Conditions: []metav1.Condition{{
 				Type:               k8upv1.ConditionCompleted.String(),
 				Status:             metav1.ConditionTrue,
 				Reason:             k8upv1.ReasonSucceeded.String(),
 				LastTransitionTime: metav1.Now(),
 			}},
+		}},
+	}
+}
+
+func TestSetResticOptions(t *testing.T) {
+	defer func(options string) { cfg.Config.ResticOptions = options }(cfg.Config.ResticOptions)
+	cfg.Config.ResticOptions = "s3.connections=10"
+	backend := &k8upv1.Backend{S3: &k8upv1.S3Spec{Bucket: "backup", Region: "eu-central-1", StorageClass: "STANDARD_IA"}}
+
+	tests := map[string]struct {
+		givenOptions    string
+		givenBackend    *k8upv1.Backend
+		expectedOptions string
+	}{
+		"GivenNoRepositoryOptions_ThenExpectOperatorAndBackendOptions": {
+			givenBackend:    backend,
+			expectedOptions: "s3.connections=10,s3.region=eu-central-1,s3.storage-class=STANDARD_IA",
+		},
+		"GivenRepositoryOptions_ThenExpectRepositoryAndBackendOptions": {
+			givenOptions:    "s3.storage-class=STANDARD",
+			givenBackend:    backend,
+			expectedOptions: "s3.storage-class=STANDARD,s3.region=eu-central-1,s3.storage-class=STANDARD_IA",
 		},
+		"GivenNoBackend_ThenExpectOperatorOptions": {
+			expectedOptions: "s3.connections=10",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			vars := NewEnvVarConverter()
+			SetResticOptions(&vars, tt.givenOptions, tt.givenBackend)
+			assert.Contains(t, vars.Convert(), corev1.EnvVar{Name: cfg.ResticOptionsEnvName, Value: tt.expectedOptions})
+		})
+	}
+}
+
+func TestGeneric_SetRepositoryEnv_SkipInit(t *testing.T) {
+	backend := &k8upv1.Backend{S3: &k8upv1.S3Spec{Endpoint: "https://s3.example.com", Bucket: "bucket"}}
+	tests := map[string]struct {
+		givenRepository  *k8upv1.Repository
+		expectedSkipInit bool
+	}{
+		"GivenNoRepository_ThenExpectNoSkipInit": {
+			expectedSkipInit: false,
+		},
+		"GivenInitializedRepository_ThenExpectSkipInit": {
+			givenRepository: &k8upv1.Repository{
+				Spec:   k8upv1.RepositorySpec{Backend: backend},
+				Status: k8upv1.RepositoryStatus{Initialized: true, InitializedRepository: "s3:https://s3.example.com/bucket"},
+			},
+			expectedSkipInit: true,
+		},
+		"GivenRepositoryWithChangedBackend_ThenExpectNoSkipInit": {
+			givenRepository: &k8upv1.Repository{
+				Spec:   k8upv1.RepositorySpec{Backend: backend},
+				Status: k8upv1.RepositoryStatus{Initialized: true, InitializedRepository: "s3:https://s3.example.com/previous"},
+			},
+			expectedSkipInit: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := &Generic{Config: job.Config{ManagedRepository: tt.givenRepository}}
+			vars := NewEnvVarConverter()
+			g.SetRepositoryEnv(&vars, backend)
+			skipInit := corev1.EnvVar{Name: "SKIP_INIT", Value: "true"}
+			if tt.expectedSkipInit {
+				assert.Contains(t, vars.Convert(), skipInit)
+			} else {
+				assert.NotContains(t, vars.Convert(), skipInit)
+			}
+		})
 	}
 }
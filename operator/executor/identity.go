@@ -0,0 +1,101 @@
+package executor
+
+import (
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/cfg"
+)
+
+const (
+	awsTokenVolumeName      = "k8up-aws-token"
+	awsTokenMountPath       = "/var/run/secrets/k8up/aws"
+	awsTokenFileName        = "token"
+	awsDefaultTokenAudience = "sts.amazonaws.com"
+	// awsTokenExpirationSeconds is the lifetime of the projected token, the kubelet renews it before it expires.
+	awsTokenExpirationSeconds = 3600
+
+	gcsCredentialsVolumeName = "k8up-gcs-credentials"
+	gcsCredentialsMountPath  = "/etc/k8up/gcs"
+	gcsCredentialsFileName   = "credentials.json"
+
+	azureWorkloadIdentityUseLabel           = "azure.workload.identity/use"
+	azureWorkloadIdentityClientIDAnnotation = "azure.workload.identity/client-id"
+)
+
+// AttachCloudIdentity configures the first container of the pod template to authenticate with the identity-based
+// credentials of the given backend:
+// a projected ServiceAccount token for S3 roles, the mounted credentials file for GCS and the managed identity or workload identity for Azure.
+// It does nothing if the backend doesn't use any of them.
+func AttachCloudIdentity(template *corev1.PodTemplateSpec, backend *k8upv1.Backend) {
+	podSpec := &template.Spec
+	if backend == nil || len(podSpec.Containers) == 0 {
+		return
+	}
+	container := &podSpec.Containers[0]
+
+	if backend.S3 != nil && backend.S3.RoleARN != "" {
+		audience := backend.S3.WebIdentityTokenAudience
+		if audience == "" {
+			audience = awsDefaultTokenAudience
+		}
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: awsTokenVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+						Audience:          audience,
+						ExpirationSeconds: ptr.To(int64(awsTokenExpirationSeconds)),
+						Path:              awsTokenFileName,
+					},
+				}}},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: awsTokenVolumeName, MountPath: awsTokenMountPath, ReadOnly: true})
+		container.Env = append(container.Env,
+			corev1.EnvVar{Name: cfg.AwsRoleARNEnvName, Value: backend.S3.RoleARN},
+			corev1.EnvVar{Name: cfg.AwsWebIdentityTokenFileEnvName, Value: path.Join(awsTokenMountPath, awsTokenFileName)},
+		)
+	}
+
+	if backend.GCS != nil && backend.GCS.CredentialsSecretRef != nil {
+		ref := backend.GCS.CredentialsSecretRef
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: gcsCredentialsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: ref.Name,
+					Items:      []corev1.KeyToPath{{Key: ref.Key, Path: gcsCredentialsFileName}},
+					Optional:   ref.Optional,
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: gcsCredentialsVolumeName, MountPath: gcsCredentialsMountPath, ReadOnly: true})
+		container.Env = append(container.Env, corev1.EnvVar{Name: cfg.GcsCredentialsEnvName, Value: path.Join(gcsCredentialsMountPath, gcsCredentialsFileName)})
+	}
+
+	if backend.Azure != nil && backend.Azure.ManagedIdentityClientID != "" {
+		container.Env = append(container.Env, corev1.EnvVar{Name: cfg.AzureClientIDEnvName, Value: backend.Azure.ManagedIdentityClientID})
+	}
+
+	if usesAzureWorkloadIdentity(backend) {
+		// The Azure workload identity webhook only injects the token into pods with this label.
+		if template.Labels == nil {
+			template.Labels = map[string]string{}
+		}
+		template.Labels[azureWorkloadIdentityUseLabel] = "true"
+	}
+}
+
+// usesAzureWorkloadIdentity returns true if the Azure backend has neither an account key nor a SAS token
+// and runs as a ServiceAccount that is bound to an Azure workload identity.
+func usesAzureWorkloadIdentity(backend *k8upv1.Backend) bool {
+	if backend.Azure == nil || backend.Azure.AccountKeySecretRef != nil || backend.Azure.SASTokenSecretRef != nil || backend.ServiceAccount == nil {
+		return false
+	}
+	_, ok := backend.ServiceAccount.Annotations[azureWorkloadIdentityClientIDAnnotation]
+	return ok
+}
@@ -0,0 +1,91 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/cfg"
+)
+
+func TestAttachCloudIdentity(t *testing.T) {
+	tests := map[string]struct {
+		givenBackend    *k8upv1.Backend
+		expectedEnv     []corev1.EnvVar
+		expectedVolumes []string
+		expectedLabels  map[string]string
+	}{
+		"GivenS3WithAccessKeys_ThenExpectNothing": {
+			givenBackend: &k8upv1.Backend{S3: &k8upv1.S3Spec{Bucket: "backup", AccessKeyIDSecretRef: &corev1.SecretKeySelector{Key: "id"}}},
+		},
+		"GivenS3WithRoleARN_ThenExpectProjectedToken": {
+			givenBackend: &k8upv1.Backend{S3: &k8upv1.S3Spec{Bucket: "backup", RoleARN: "arn:aws:iam::123456789012:role/k8up"}},
+			expectedEnv: []corev1.EnvVar{
+				{Name: cfg.AwsRoleARNEnvName, Value: "arn:aws:iam::123456789012:role/k8up"},
+				{Name: cfg.AwsWebIdentityTokenFileEnvName, Value: "/var/run/secrets/k8up/aws/token"},
+			},
+			expectedVolumes: []string{"k8up-aws-token"},
+		},
+		"GivenGCSWithCredentials_ThenExpectMountedCredentials": {
+			givenBackend: &k8upv1.Backend{GCS: &k8upv1.GCSSpec{Bucket: "backup", CredentialsSecretRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "gcs"}, Key: "key.json",
+			}}},
+			expectedEnv: []corev1.EnvVar{
+				{Name: cfg.GcsCredentialsEnvName, Value: "/etc/k8up/gcs/credentials.json"},
+			},
+			expectedVolumes: []string{"k8up-gcs-credentials"},
+		},
+		"GivenAzureWithManagedIdentity_ThenExpectClientID": {
+			givenBackend: &k8upv1.Backend{Azure: &k8upv1.AzureSpec{Container: "backup", ManagedIdentityClientID: "client"}},
+			expectedEnv: []corev1.EnvVar{
+				{Name: cfg.AzureClientIDEnvName, Value: "client"},
+			},
+		},
+		"GivenAzureWithWorkloadIdentity_ThenExpectPodLabel": {
+			givenBackend: &k8upv1.Backend{
+				ServiceAccount: &k8upv1.BackendServiceAccount{
+					Name:        "backup-identity",
+					Annotations: map[string]string{"azure.workload.identity/client-id": "client"},
+				},
+				Azure: &k8upv1.AzureSpec{Container: "backup"},
+			},
+			expectedLabels: map[string]string{"azure.workload.identity/use": "true"},
+		},
+		"GivenAzureWithAccountKey_ThenExpectNoPodLabel": {
+			givenBackend: &k8upv1.Backend{
+				ServiceAccount: &k8upv1.BackendServiceAccount{
+					Name:        "backup-identity",
+					Annotations: map[string]string{"azure.workload.identity/client-id": "client"},
+				},
+				Azure: &k8upv1.AzureSpec{Container: "backup", AccountKeySecretRef: &corev1.SecretKeySelector{Key: "key"}},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			template := &corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "backup"}}}}
+			AttachCloudIdentity(template, tt.givenBackend)
+
+			podSpec := &template.Spec
+			assert.Equal(t, tt.expectedLabels, template.Labels)
+
+			assert.Equal(t, tt.expectedEnv, podSpec.Containers[0].Env)
+			require.Len(t, podSpec.Volumes, len(tt.expectedVolumes))
+			for i, volume := range tt.expectedVolumes {
+				assert.Equal(t, volume, podSpec.Volumes[i].Name)
+				assert.Equal(t, volume, podSpec.Containers[0].VolumeMounts[i].Name)
+			}
+		})
+	}
+}
+
+func TestAttachCloudIdentity_TokenAudience(t *testing.T) {
+	template := &corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "backup"}}}}
+	AttachCloudIdentity(template, &k8upv1.Backend{S3: &k8upv1.S3Spec{RoleARN: "arn", WebIdentityTokenAudience: "minio"}})
+
+	require.Len(t, template.Spec.Volumes, 1)
+	assert.Equal(t, "minio", template.Spec.Volumes[0].Projected.Sources[0].ServiceAccountToken.Audience)
+}
@@ -0,0 +1,80 @@
+package executor
+
+import (
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/cfg"
+)
+
+const (
+	repoPasswordVolumeName = "k8up-repo-password"
+	repoPasswordMountPath  = "/etc/k8up/repo-password"
+	repoPasswordFileName   = "password"
+
+	passwordCommandToolsVolumeName = "k8up-password-command-tools"
+	passwordCommandToolsMountPath  = "/opt/k8up/password-command"
+	passwordCommandInitName        = "k8up-password-command"
+	passwordCommandVolumesPath     = "/etc/k8up/password-command"
+)
+
+// AttachRepoPassword configures the first container of the pod spec to read the restic repository password
+// from the mounted file or the command of the given backend, instead of the RESTIC_PASSWORD environment variable.
+// A password command with an image gets an init container that provides its tools in a shared directory.
+// It does nothing if the backend uses neither of them.
+func AttachRepoPassword(podSpec *corev1.PodSpec, backend *k8upv1.Backend) {
+	if backend == nil || len(podSpec.Containers) == 0 {
+		return
+	}
+	container := &podSpec.Containers[0]
+
+	if ref := backend.RepoPasswordFileSecretRef; ref != nil {
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: repoPasswordVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: ref.Name,
+					Items:      []corev1.KeyToPath{{Key: ref.Key, Path: repoPasswordFileName}},
+					Optional:   ref.Optional,
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: repoPasswordVolumeName, MountPath: repoPasswordMountPath, ReadOnly: true})
+		container.Env = append(container.Env, corev1.EnvVar{Name: cfg.ResticPasswordFileEnvName, Value: path.Join(repoPasswordMountPath, repoPasswordFileName)})
+	}
+
+	command := backend.RepoPasswordCommand
+	if command == nil {
+		return
+	}
+	if command.Image != "" {
+		toolsMount := corev1.VolumeMount{Name: passwordCommandToolsVolumeName, MountPath: passwordCommandToolsMountPath}
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name:         passwordCommandToolsVolumeName,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		})
+		podSpec.InitContainers = append(podSpec.InitContainers, corev1.Container{
+			Name:            passwordCommandInitName,
+			Image:           command.Image,
+			Command:         command.InitCommand,
+			VolumeMounts:    []corev1.VolumeMount{toolsMount},
+			SecurityContext: container.SecurityContext,
+		})
+		toolsMount.ReadOnly = true
+		container.VolumeMounts = append(container.VolumeMounts, toolsMount)
+	}
+	for _, volume := range command.Volumes {
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: volume.Name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: volume.PersistentVolumeClaim,
+				Secret:                volume.Secret,
+				ConfigMap:             volume.ConfigMap,
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: volume.Name, MountPath: path.Join(passwordCommandVolumesPath, volume.Name), ReadOnly: true})
+	}
+	container.Env = append(container.Env, corev1.EnvVar{Name: cfg.ResticPasswordCommandEnvName, Value: command.Command})
+}
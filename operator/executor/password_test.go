@@ -0,0 +1,59 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/cfg"
+)
+
+func TestAttachRepoPassword_File(t *testing.T) {
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "backup"}}}
+	AttachRepoPassword(podSpec, &k8upv1.Backend{RepoPasswordFileSecretRef: &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "backup-repo"}, Key: "password",
+	}})
+
+	require.Len(t, podSpec.Volumes, 1)
+	assert.Equal(t, "backup-repo", podSpec.Volumes[0].Secret.SecretName)
+	assert.Equal(t, []corev1.KeyToPath{{Key: "password", Path: "password"}}, podSpec.Volumes[0].Secret.Items)
+	require.Len(t, podSpec.Containers[0].VolumeMounts, 1)
+	assert.Equal(t, "/etc/k8up/repo-password", podSpec.Containers[0].VolumeMounts[0].MountPath)
+	assert.Equal(t, []corev1.EnvVar{{Name: cfg.ResticPasswordFileEnvName, Value: "/etc/k8up/repo-password/password"}}, podSpec.Containers[0].Env)
+	assert.Empty(t, podSpec.InitContainers)
+}
+
+func TestAttachRepoPassword_Command(t *testing.T) {
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "backup"}}}
+	AttachRepoPassword(podSpec, &k8upv1.Backend{RepoPasswordCommand: &k8upv1.PasswordCommandSpec{
+		Command:     "/opt/k8up/password-command/kms-client decrypt",
+		Image:       "registry.example.com/kms-client",
+		InitCommand: []string{"cp", "/bin/kms-client", "/opt/k8up/password-command/"},
+		Volumes:     []k8upv1.RunnableVolumeSpec{{Name: "token", Secret: &corev1.SecretVolumeSource{SecretName: "kms-token"}}},
+	}})
+
+	require.Len(t, podSpec.InitContainers, 1)
+	assert.Equal(t, "registry.example.com/kms-client", podSpec.InitContainers[0].Image)
+	assert.Equal(t, []string{"cp", "/bin/kms-client", "/opt/k8up/password-command/"}, podSpec.InitContainers[0].Command)
+	assert.Equal(t, []corev1.VolumeMount{{Name: "k8up-password-command-tools", MountPath: "/opt/k8up/password-command"}}, podSpec.InitContainers[0].VolumeMounts)
+
+	require.Len(t, podSpec.Volumes, 2)
+	assert.NotNil(t, podSpec.Volumes[0].EmptyDir)
+	assert.Equal(t, "kms-token", podSpec.Volumes[1].Secret.SecretName)
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "k8up-password-command-tools", MountPath: "/opt/k8up/password-command", ReadOnly: true},
+		{Name: "token", MountPath: "/etc/k8up/password-command/token", ReadOnly: true},
+	}, podSpec.Containers[0].VolumeMounts)
+	assert.Equal(t, []corev1.EnvVar{{Name: cfg.ResticPasswordCommandEnvName, Value: "/opt/k8up/password-command/kms-client decrypt"}}, podSpec.Containers[0].Env)
+}
+
+func TestAttachRepoPassword_SecretRef(t *testing.T) {
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "backup"}}}
+	AttachRepoPassword(podSpec, &k8upv1.Backend{RepoPasswordSecretRef: &corev1.SecretKeySelector{Key: "password"}})
+
+	assert.Empty(t, podSpec.Volumes)
+	assert.Empty(t, podSpec.Containers[0].Env)
+}
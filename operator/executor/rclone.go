@@ -0,0 +1,45 @@
+package executor
+
+import (
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/cfg"
+)
+
+const (
+	rcloneVolumeName     = "k8up-rclone"
+	rcloneMountPath      = "/etc/k8up/rclone"
+	rcloneConfigFileName = "rclone.conf"
+)
+
+// AttachRcloneConfig mounts the rclone configuration of the given backend into the first container of the pod spec
+// and points rclone to it by the RCLONE_CONFIG environment variable.
+// It does nothing if the backend isn't an rclone backend.
+//
+// The configuration is mounted read-only, rclone can't persist refreshed OAuth tokens in it.
+func AttachRcloneConfig(podSpec *corev1.PodSpec, backend *k8upv1.Backend) {
+	if backend == nil || backend.Rclone == nil || backend.Rclone.ConfigSecretRef == nil || len(podSpec.Containers) == 0 {
+		return
+	}
+	ref := backend.Rclone.ConfigSecretRef
+	container := &podSpec.Containers[0]
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: rcloneVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: ref.Name,
+				Items:      []corev1.KeyToPath{{Key: ref.Key, Path: rcloneConfigFileName}},
+				Optional:   ref.Optional,
+			},
+		},
+	})
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      rcloneVolumeName,
+		MountPath: rcloneMountPath,
+		ReadOnly:  true,
+	})
+	container.Env = append(container.Env, corev1.EnvVar{Name: cfg.RcloneConfigEnvName, Value: path.Join(rcloneMountPath, rcloneConfigFileName)})
+}
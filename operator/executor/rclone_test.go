@@ -0,0 +1,35 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/cfg"
+)
+
+func TestAttachRcloneConfig(t *testing.T) {
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "backup"}}}
+	AttachRcloneConfig(podSpec, &k8upv1.Backend{Rclone: &k8upv1.RcloneSpec{
+		Remote:          "dropbox",
+		ConfigSecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "rclone"}, Key: "config"},
+	}})
+
+	require.Len(t, podSpec.Volumes, 1)
+	assert.Equal(t, "rclone", podSpec.Volumes[0].Secret.SecretName)
+	assert.Equal(t, []corev1.KeyToPath{{Key: "config", Path: "rclone.conf"}}, podSpec.Volumes[0].Secret.Items)
+	require.Len(t, podSpec.Containers[0].VolumeMounts, 1)
+	assert.Equal(t, "/etc/k8up/rclone", podSpec.Containers[0].VolumeMounts[0].MountPath)
+	assert.Equal(t, []corev1.EnvVar{{Name: cfg.RcloneConfigEnvName, Value: "/etc/k8up/rclone/rclone.conf"}}, podSpec.Containers[0].Env)
+}
+
+func TestAttachRcloneConfig_NoRcloneBackend(t *testing.T) {
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "backup"}}}
+	AttachRcloneConfig(podSpec, &k8upv1.Backend{S3: &k8upv1.S3Spec{Bucket: "backup"}})
+
+	assert.Empty(t, podSpec.Volumes)
+	assert.Empty(t, podSpec.Containers[0].Env)
+}
@@ -0,0 +1,101 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/cfg"
+)
+
+// +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;update
+
+// ServiceAccountName returns the name of the ServiceAccount configured by the given backend,
+// or the given default if the backend doesn't configure one.
+func ServiceAccountName(backend *k8upv1.Backend, defaultName string) string {
+	if backend == nil || backend.ServiceAccount == nil || backend.ServiceAccount.Name == "" {
+		return defaultName
+	}
+	return backend.ServiceAccount.Name
+}
+
+// ReconcileServiceAccount creates the ServiceAccount with the given name in the given namespace if it doesn't exist
+// and adds the annotations the given backend configures for its ServiceAccount.
+// Existing annotations that the backend doesn't configure are kept.
+func ReconcileServiceAccount(ctx context.Context, c client.Client, namespace, name string, backend *k8upv1.Backend) error {
+	sa := &corev1.ServiceAccount{}
+	sa.Name = name
+	sa.Namespace = namespace
+	_, err := controllerutil.CreateOrUpdate(ctx, c, sa, func() error {
+		if backend == nil || backend.ServiceAccount == nil || backend.ServiceAccount.Name != name {
+			return nil
+		}
+		for key, value := range backend.ServiceAccount.Annotations {
+			if sa.Annotations == nil {
+				sa.Annotations = map[string]string{}
+			}
+			sa.Annotations[key] = value
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("cannot create or update service account %q: %w", name, err)
+	}
+	return nil
+}
+
+// AttachServiceAccount reconciles the ServiceAccount the given backend configures and runs the pod as it.
+// It doesn't change the pod spec if the backend doesn't configure a ServiceAccount.
+func AttachServiceAccount(ctx context.Context, c client.Client, podSpec *corev1.PodSpec, namespace string, backend *k8upv1.Backend) error {
+	name := ServiceAccountName(backend, "")
+	if name == "" {
+		return nil
+	}
+	if err := ReconcileServiceAccount(ctx, c, namespace, name, backend); err != nil {
+		return err
+	}
+	podSpec.ServiceAccountName = name
+	return nil
+}
+
+// ReconcileExecutorServiceAccount creates the ServiceAccount that jobs run as if they access the cluster,
+// e.g. to execute commands in pods or to synchronize Snapshots, and binds it to the 'k8up-executor' ClusterRole.
+// Backends that configure their own ServiceAccount get a RoleBinding of their own.
+// It returns the name of the ServiceAccount.
+func ReconcileExecutorServiceAccount(ctx context.Context, c client.Client, namespace string, backend *k8upv1.Backend) (string, error) {
+	saName := ServiceAccountName(backend, cfg.Config.ServiceAccount)
+	if err := ReconcileServiceAccount(ctx, c, namespace, saName, backend); err != nil {
+		return "", err
+	}
+
+	roleBinding := &rbacv1.RoleBinding{}
+	roleBinding.Name = cfg.Config.PodExecRoleName + "-namespaced"
+	if saName != cfg.Config.ServiceAccount {
+		roleBinding.Name = cfg.Config.PodExecRoleName + "-" + saName
+	}
+	roleBinding.Namespace = namespace
+	_, err := controllerutil.CreateOrUpdate(ctx, c, roleBinding, func() error {
+		roleBinding.Subjects = []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Namespace: namespace,
+				Name:      saName,
+			},
+		}
+		roleBinding.RoleRef = rbacv1.RoleRef{
+			Kind:     "ClusterRole",
+			Name:     "k8up-executor",
+			APIGroup: "rbac.authorization.k8s.io",
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("cannot create or update role binding %q: %w", roleBinding.Name, err)
+	}
+	return saName, nil
+}
@@ -0,0 +1,50 @@
+package executor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+)
+
+func TestAttachServiceAccount(t *testing.T) {
+	existing := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{
+		Name:        "backup-identity",
+		Namespace:   "app",
+		Annotations: map[string]string{"existing": "true"},
+	}}
+	c := fake.NewClientBuilder().WithObjects(existing).Build()
+	backend := &k8upv1.Backend{ServiceAccount: &k8upv1.BackendServiceAccount{
+		Name:        "backup-identity",
+		Annotations: map[string]string{"eks.amazonaws.com/role-arn": "arn:aws:iam::123456789012:role/k8up"},
+	}}
+
+	podSpec := &corev1.PodSpec{ServiceAccountName: "default"}
+	require.NoError(t, AttachServiceAccount(context.TODO(), c, podSpec, "app", backend))
+	assert.Equal(t, "backup-identity", podSpec.ServiceAccountName)
+
+	sa := &corev1.ServiceAccount{}
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "app", Name: "backup-identity"}, sa))
+	assert.Equal(t, map[string]string{
+		"existing":                   "true",
+		"eks.amazonaws.com/role-arn": "arn:aws:iam::123456789012:role/k8up",
+	}, sa.Annotations)
+}
+
+func TestAttachServiceAccount_NotConfigured(t *testing.T) {
+	c := fake.NewClientBuilder().Build()
+	podSpec := &corev1.PodSpec{ServiceAccountName: "from-pod-config"}
+	require.NoError(t, AttachServiceAccount(context.TODO(), c, podSpec, "app", &k8upv1.Backend{}))
+	assert.Equal(t, "from-pod-config", podSpec.ServiceAccountName)
+
+	list := &corev1.ServiceAccountList{}
+	require.NoError(t, c.List(context.TODO(), list))
+	assert.Empty(t, list.Items)
+}
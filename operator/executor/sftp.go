@@ -0,0 +1,69 @@
+package executor
+
+import (
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/cfg"
+)
+
+const (
+	sftpVolumeName         = "k8up-sftp"
+	sftpMountPath          = "/etc/k8up/sftp"
+	sftpPrivateKeyFileName = "id"
+	sftpKnownHostsFileName = "known_hosts"
+)
+
+// AttachSFTPKeys mounts the SSH key material of the given backend into the first container of the pod spec
+// and tells restic where to find it by environment variables.
+// It does nothing if the backend isn't an SFTP backend.
+//
+// The files are readable by everyone in the container, restic copies the private key to a file only the user can read
+// before connecting, as ssh refuses keys that others can read.
+func AttachSFTPKeys(podSpec *corev1.PodSpec, backend *k8upv1.Backend) {
+	if backend == nil || backend.SFTP == nil || len(podSpec.Containers) == 0 {
+		return
+	}
+	sftp := backend.SFTP
+	container := &podSpec.Containers[0]
+	if sftp.Command != "" {
+		container.Env = append(container.Env, corev1.EnvVar{Name: cfg.SFTPCommandEnvName, Value: sftp.Command})
+	}
+
+	var sources []corev1.VolumeProjection
+	if sftp.PrivateKeySecretRef != nil {
+		sources = append(sources, sftpKeyProjection(sftp.PrivateKeySecretRef, sftpPrivateKeyFileName))
+		container.Env = append(container.Env, corev1.EnvVar{Name: cfg.SFTPPrivateKeyFileEnvName, Value: path.Join(sftpMountPath, sftpPrivateKeyFileName)})
+	}
+	if sftp.KnownHostsSecretRef != nil {
+		sources = append(sources, sftpKeyProjection(sftp.KnownHostsSecretRef, sftpKnownHostsFileName))
+		container.Env = append(container.Env, corev1.EnvVar{Name: cfg.SFTPKnownHostsFileEnvName, Value: path.Join(sftpMountPath, sftpKnownHostsFileName)})
+	}
+	if len(sources) == 0 {
+		return
+	}
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: sftpVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{Sources: sources, DefaultMode: ptr.To(int32(0444))},
+		},
+	})
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      sftpVolumeName,
+		MountPath: sftpMountPath,
+		ReadOnly:  true,
+	})
+}
+
+func sftpKeyProjection(ref *corev1.SecretKeySelector, fileName string) corev1.VolumeProjection {
+	return corev1.VolumeProjection{
+		Secret: &corev1.SecretProjection{
+			LocalObjectReference: ref.LocalObjectReference,
+			Items:                []corev1.KeyToPath{{Key: ref.Key, Path: fileName}},
+			Optional:             ref.Optional,
+		},
+	}
+}
@@ -0,0 +1,59 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/cfg"
+)
+
+func TestAttachSFTPKeys(t *testing.T) {
+	secretRef := func(key string) *corev1.SecretKeySelector {
+		return &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "ssh"}, Key: key}
+	}
+	tests := map[string]struct {
+		givenBackend    *k8upv1.Backend
+		expectedEnv     []corev1.EnvVar
+		expectedVolumes int
+	}{
+		"GivenNoSFTPBackend_ThenExpectNothing": {
+			givenBackend: &k8upv1.Backend{S3: &k8upv1.S3Spec{Bucket: "backup"}},
+		},
+		"GivenKeys_ThenExpectMountedFiles": {
+			givenBackend: &k8upv1.Backend{SFTP: &k8upv1.SFTPSpec{
+				Host:                "example.com",
+				PrivateKeySecretRef: secretRef("id"),
+				KnownHostsSecretRef: secretRef("known_hosts"),
+			}},
+			expectedEnv: []corev1.EnvVar{
+				{Name: cfg.SFTPPrivateKeyFileEnvName, Value: "/etc/k8up/sftp/id"},
+				{Name: cfg.SFTPKnownHostsFileEnvName, Value: "/etc/k8up/sftp/known_hosts"},
+			},
+			expectedVolumes: 1,
+		},
+		"GivenOnlyCommand_ThenExpectNoVolume": {
+			givenBackend: &k8upv1.Backend{SFTP: &k8upv1.SFTPSpec{Host: "example.com", Command: "ssh example.com -s sftp"}},
+			expectedEnv: []corev1.EnvVar{
+				{Name: cfg.SFTPCommandEnvName, Value: "ssh example.com -s sftp"},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "backup"}}}
+			AttachSFTPKeys(podSpec, tt.givenBackend)
+
+			assert.Equal(t, tt.expectedEnv, podSpec.Containers[0].Env)
+			require.Len(t, podSpec.Volumes, tt.expectedVolumes)
+			require.Len(t, podSpec.Containers[0].VolumeMounts, tt.expectedVolumes)
+			if tt.expectedVolumes > 0 {
+				assert.Len(t, podSpec.Volumes[0].Projected.Sources, 2)
+				assert.True(t, podSpec.Containers[0].VolumeMounts[0].ReadOnly)
+			}
+		})
+	}
+}
@@ -0,0 +1,318 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"dario.cat/mergo"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/cfg"
+)
+
+// +kubebuilder:rbac:groups=k8up.io,resources=clusterrepositories,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// The copies of the Secrets of ClusterRepositories are managed in the namespaces of the jobs,
+// which requires the permission to manage Secrets in all namespaces.
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;create;update;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get
+
+// LabelClusterRepository is set on the Secrets the operator copies from its own namespace into the namespaces of jobs
+// that use a ClusterRepository.
+// The label's value is the name of the ClusterRepository.
+const LabelClusterRepository = "k8up.io/cluster-repository"
+
+// FindClusterRepository returns the ClusterRepository that applies to the given namespace.
+// A ClusterRepository whose NamespaceSelector matches the namespace takes precedence over one without selector.
+// If several ClusterRepositories apply equally, the first one by name is returned.
+// It returns nil if no ClusterRepository applies to the namespace.
+func FindClusterRepository(ctx context.Context, c client.Client, namespace string) (*k8upv1.ClusterRepository, error) {
+	ns := &corev1.Namespace{}
+	if err := c.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		return nil, fmt.Errorf("cannot get namespace %q: %w", namespace, err)
+	}
+	list := &k8upv1.ClusterRepositoryList{}
+	if err := c.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("cannot list cluster repositories: %w", err)
+	}
+	sort.Slice(list.Items, func(i, j int) bool {
+		return list.Items[i].Name < list.Items[j].Name
+	})
+
+	var fallback *k8upv1.ClusterRepository
+	for i := range list.Items {
+		repository := &list.Items[i]
+		selected, err := repository.SelectsNamespace(ns.Labels)
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespace selector of cluster repository %q: %w", repository.Name, err)
+		}
+		if !selected {
+			continue
+		}
+		if repository.Spec.NamespaceSelector != nil {
+			return repository, nil
+		}
+		if fallback == nil {
+			fallback = repository
+		}
+	}
+	return fallback, nil
+}
+
+// ApplyClusterRepository completes the backend of the given spec with the ClusterRepository that applies to the namespace.
+// A spec without storage type gets the whole backend of the ClusterRepository, a spec with the same storage type only the fields it leaves empty,
+// e.g. the URL of a rest-server or the credentials of a Swift container.
+// The namespace is appended to the path of a rest-server with namespacedPath.
+// The credentials the spec gets from the ClusterRepository are copied into a Secret of the job object, see ClusterRepositorySecretName.
+// Specs that refer to a Repository are left as they are.
+// If no ClusterRepository applies, the deprecated global S3 settings of the operator are used instead.
+// The ClusterRepository is returned, or nil if there is none.
+func ApplyClusterRepository(ctx context.Context, c client.Client, obj k8upv1.JobObject, spec *k8upv1.RunnableSpec) (*k8upv1.ClusterRepository, error) {
+	namespace := obj.GetNamespace()
+	clusterRepository, err := FindClusterRepository(ctx, c, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if spec.RepositoryRef != nil {
+		return clusterRepository, nil
+	}
+	if clusterRepository == nil || clusterRepository.Spec.Backend == nil {
+		applyGlobalS3(spec)
+		return clusterRepository, nil
+	}
+
+	defaults := clusterRepository.Spec.Backend.DeepCopy()
+	if err := copyClusterRepositorySecrets(ctx, c, clusterRepository, obj, secretKeySelectorsOf(defaults)); err != nil {
+		return nil, err
+	}
+	if err := copyClusterRepositoryCACert(ctx, c, clusterRepository, obj, defaults.TLSOptions); err != nil {
+		return nil, err
+	}
+	defaults.Rest = defaults.Rest.WithNamespacedPath(namespace)
+	spec.Backend = mergeBackend(spec.Backend, defaults)
+	return clusterRepository, nil
+}
+
+// ApplyClusterRestoreS3 completes the given S3 restore target with the RestoreS3 settings of the ClusterRepository.
+// If the ClusterRepository doesn't configure them, the deprecated global restore S3 settings of the operator are used instead.
+func ApplyClusterRestoreS3(ctx context.Context, c client.Client, clusterRepository *k8upv1.ClusterRepository, obj k8upv1.JobObject, s3 *k8upv1.S3Spec) error {
+	if clusterRepository == nil || clusterRepository.Spec.RestoreS3 == nil {
+		if s3.Endpoint == "" {
+			s3.Endpoint = cfg.Config.GlobalRestoreS3Endpoint
+		}
+		if s3.Bucket == "" {
+			s3.Bucket = cfg.Config.GlobalRestoreS3Bucket
+		}
+		return nil
+	}
+
+	defaults := clusterRepository.Spec.RestoreS3.DeepCopy()
+	if err := copyClusterRepositorySecrets(ctx, c, clusterRepository, obj, secretKeySelectorsOf(defaults)); err != nil {
+		return err
+	}
+	// Merging two S3Specs never returns an error, they are of the same type.
+	_ = mergo.Merge(s3, defaults)
+	return nil
+}
+
+// ClusterRepositorySecretName returns the name of the Secret in the namespace of the given job object
+// that holds the copies of the Secrets of its ClusterRepository.
+// The Secret is owned by the job object and only exists until the job object has finished,
+// so that the credentials of a ClusterRepository don't stay in the namespaces it applies to.
+func ClusterRepositorySecretName(obj k8upv1.JobObject) string {
+	return "k8up-clusterrepository-" + obj.GetType().String() + "-" + obj.GetName()
+}
+
+// deleteClusterRepositorySecret deletes the copies of the Secrets of the ClusterRepository that the given job object used.
+func deleteClusterRepositorySecret(ctx context.Context, c client.Client, obj k8upv1.JobObject) error {
+	secret := &corev1.Secret{}
+	secret.Name = ClusterRepositorySecretName(obj)
+	secret.Namespace = obj.GetNamespace()
+	if err := c.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("cannot remove copied secrets of cluster repository: %w", err)
+	}
+	return nil
+}
+
+func mergeBackend(backend, defaults *k8upv1.Backend) *k8upv1.Backend {
+	if backend == nil {
+		return defaults
+	}
+	if backend.HasRepoPassword() {
+		// The password of the job isn't mixed with the one of the defaults,
+		// restic prefers the password file and command over the password in the environment.
+		defaults.RepoPasswordSecretRef, defaults.RepoPasswordFileSecretRef, defaults.RepoPasswordCommand = nil, nil, nil
+	}
+	switch {
+	case !backend.HasStorageType():
+		// Merging two Backends never returns an error, they are of the same type.
+		_ = mergo.Merge(backend, defaults)
+	default:
+		mergeStorage(backend.Local, defaults.Local)
+		mergeStorage(backend.S3, defaults.S3)
+		mergeStorage(backend.GCS, defaults.GCS)
+		mergeStorage(backend.Azure, defaults.Azure)
+		mergeStorage(backend.Swift, defaults.Swift)
+		mergeStorage(backend.B2, defaults.B2)
+		mergeStorage(backend.Rest, defaults.Rest)
+		mergeStorage(backend.SFTP, defaults.SFTP)
+		mergeStorage(backend.Rclone, defaults.Rclone)
+	}
+	if !backend.HasRepoPassword() {
+		backend.RepoPasswordSecretRef = defaults.RepoPasswordSecretRef
+		backend.RepoPasswordFileSecretRef = defaults.RepoPasswordFileSecretRef
+		backend.RepoPasswordCommand = defaults.RepoPasswordCommand
+	}
+	return backend
+}
+
+// mergeStorage completes the given storage spec with the fields of the defaults that it leaves empty.
+// Nothing is merged if the ClusterRepository has another storage type.
+func mergeStorage[T any](spec, defaults *T) {
+	if spec == nil || defaults == nil {
+		return
+	}
+	// Merging two values of the same struct type never returns an error.
+	_ = mergo.Merge(spec, defaults)
+}
+
+// applyGlobalS3 completes the given spec with the deprecated global S3 settings of the operator.
+// The global credentials are plain values, they are added to the environment of the job by executor.DefaultEnv.
+func applyGlobalS3(spec *k8upv1.RunnableSpec) {
+	if !cfg.Config.HasGlobalRepository() {
+		return
+	}
+	if spec.Backend == nil {
+		spec.Backend = &k8upv1.Backend{}
+	}
+	if !spec.Backend.HasStorageType() {
+		spec.Backend.S3 = &k8upv1.S3Spec{}
+	}
+	if spec.Backend.S3 == nil {
+		return
+	}
+	if spec.Backend.S3.Endpoint == "" {
+		spec.Backend.S3.Endpoint = cfg.Config.GlobalS3Endpoint
+	}
+	if spec.Backend.S3.Bucket == "" {
+		spec.Backend.S3.Bucket = cfg.Config.GlobalS3Bucket
+	}
+}
+
+// copyClusterRepositorySecrets copies the keys referenced by the given selectors from the Secrets in the operator's namespace
+// into the Secret of the given job object, and changes the selectors to refer to the copies.
+// Pods can only refer to Secrets in their own namespace.
+func copyClusterRepositorySecrets(ctx context.Context, c client.Client, clusterRepository *k8upv1.ClusterRepository, obj k8upv1.JobObject, selectors []*corev1.SecretKeySelector) error {
+	if len(selectors) == 0 {
+		return nil
+	}
+	data := map[string][]byte{}
+	sources := map[string]*corev1.Secret{}
+	for _, selector := range selectors {
+		source, ok := sources[selector.Name]
+		if !ok {
+			source = &corev1.Secret{}
+			if err := c.Get(ctx, types.NamespacedName{Namespace: cfg.Config.OperatorNamespace, Name: selector.Name}, source); err != nil {
+				return fmt.Errorf("cannot get secret %q of cluster repository %q: %w", selector.Name, clusterRepository.Name, err)
+			}
+			sources[selector.Name] = source
+		}
+		value, ok := source.Data[selector.Key]
+		if !ok {
+			return fmt.Errorf("secret %q of cluster repository %q has no key %q", selector.Name, clusterRepository.Name, selector.Key)
+		}
+		key := selector.Name + "." + selector.Key
+		data[key] = value
+		selector.Name = ClusterRepositorySecretName(obj)
+		selector.Key = key
+	}
+
+	return writeClusterRepositorySecret(ctx, c, clusterRepository, obj, data)
+}
+
+// copyClusterRepositoryCACert copies the certificate authority that the given TLS options reference in a ConfigMap in the operator's namespace
+// into the Secret of the given job object, and changes the options to refer to the copy in the Secret instead.
+// Pods can only refer to ConfigMaps in their own namespace.
+func copyClusterRepositoryCACert(ctx context.Context, c client.Client, clusterRepository *k8upv1.ClusterRepository, obj k8upv1.JobObject, tlsOptions *k8upv1.TLSOptions) error {
+	if tlsOptions == nil || tlsOptions.CACertConfigMapRef == nil {
+		return nil
+	}
+	selector := tlsOptions.CACertConfigMapRef
+	source := &corev1.ConfigMap{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: cfg.Config.OperatorNamespace, Name: selector.Name}, source); err != nil {
+		return fmt.Errorf("cannot get config map %q of cluster repository %q: %w", selector.Name, clusterRepository.Name, err)
+	}
+	value, ok := source.BinaryData[selector.Key]
+	if data, isString := source.Data[selector.Key]; isString {
+		value, ok = []byte(data), true
+	}
+	if !ok {
+		return fmt.Errorf("config map %q of cluster repository %q has no key %q", selector.Name, clusterRepository.Name, selector.Key)
+	}
+	key := "configmap." + selector.Name + "." + selector.Key
+	if err := writeClusterRepositorySecret(ctx, c, clusterRepository, obj, map[string][]byte{key: value}); err != nil {
+		return err
+	}
+	tlsOptions.CACertSecretRef = &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: ClusterRepositorySecretName(obj)}, Key: key}
+	tlsOptions.CACertConfigMapRef = nil
+	return nil
+}
+
+// writeClusterRepositorySecret adds the given data to the Secret of the given job object.
+// The Secret is owned by the job object, so that it's deleted with it.
+// Nothing is written once the job object has finished, its Secret has been removed then.
+func writeClusterRepositorySecret(ctx context.Context, c client.Client, clusterRepository *k8upv1.ClusterRepository, obj k8upv1.JobObject, data map[string][]byte) error {
+	if obj.GetStatus().HasFinished() {
+		return nil
+	}
+	secret := &corev1.Secret{}
+	secret.Name = ClusterRepositorySecretName(obj)
+	secret.Namespace = obj.GetNamespace()
+	_, err := controllerutil.CreateOrUpdate(ctx, c, secret, func() error {
+		secret.Labels = labels.Merge(secret.Labels, labels.Set{
+			LabelClusterRepository:  clusterRepository.Name,
+			k8upv1.LabelK8upOwnedBy: obj.GetType().String() + "_" + obj.GetName(),
+		})
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		for key, value := range data {
+			secret.Data[key] = value
+		}
+		return controllerutil.SetOwnerReference(obj, secret, c.Scheme())
+	})
+	if err != nil {
+		return fmt.Errorf("cannot copy secrets of cluster repository %q: %w", clusterRepository.Name, err)
+	}
+	return nil
+}
+
+// secretKeySelectorsOf returns all SecretKeySelectors in the fields of the given struct pointer, including nested structs.
+func secretKeySelectorsOf(obj interface{}) []*corev1.SecretKeySelector {
+	var selectors []*corev1.SecretKeySelector
+	value := reflect.ValueOf(obj)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return nil
+	}
+	if selector, ok := obj.(*corev1.SecretKeySelector); ok {
+		return []*corev1.SecretKeySelector{selector}
+	}
+	value = value.Elem()
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		if field.Kind() == reflect.Pointer && field.CanInterface() {
+			selectors = append(selectors, secretKeySelectorsOf(field.Interface())...)
+		}
+	}
+	return selectors
+}
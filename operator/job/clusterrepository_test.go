@@ -0,0 +1,313 @@
+package job
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/cfg"
+)
+
+func TestFindClusterRepository(t *testing.T) {
+	defaultRepository := newClusterRepository("default", nil)
+	teamRepository := newClusterRepository("team-a", &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}})
+
+	tests := map[string]struct {
+		givenLabels  map[string]string
+		givenObjects []client.Object
+		expectedName string
+	}{
+		"GivenNoClusterRepository_ThenExpectNil": {},
+		"GivenRepositoryWithoutSelector_ThenExpectItForAllNamespaces": {
+			givenLabels:  map[string]string{"team": "b"},
+			givenObjects: []client.Object{defaultRepository, teamRepository},
+			expectedName: "default",
+		},
+		"GivenMatchingSelector_ThenExpectItToTakePrecedence": {
+			givenLabels:  map[string]string{"team": "a"},
+			givenObjects: []client.Object{defaultRepository, teamRepository},
+			expectedName: "team-a",
+		},
+		"GivenOnlyNonMatchingSelector_ThenExpectNil": {
+			givenLabels:  map[string]string{"team": "b"},
+			givenObjects: []client.Object{teamRepository},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app", Labels: tt.givenLabels}}
+			c := newFakeClient(t, append(tt.givenObjects, ns)...)
+
+			repository, err := FindClusterRepository(context.TODO(), c, "app")
+			require.NoError(t, err)
+			if tt.expectedName == "" {
+				assert.Nil(t, repository)
+				return
+			}
+			require.NotNil(t, repository)
+			assert.Equal(t, tt.expectedName, repository.Name)
+		})
+	}
+}
+
+func TestApplyClusterRepository(t *testing.T) {
+	cfg.Config.OperatorNamespace = "k8up-system"
+	defer func() { cfg.Config.OperatorNamespace = "" }()
+
+	clusterRepository := newClusterRepository("default", nil)
+	clusterRepository.Spec.Backend = &k8upv1.Backend{
+		RepoPasswordSecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "s3"}, Key: "password"},
+		S3: &k8upv1.S3Spec{
+			Endpoint:             "https://s3.example.com",
+			Bucket:               "default",
+			AccessKeyIDSecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "s3"}, Key: "username"},
+		},
+	}
+	source := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "s3", Namespace: "k8up-system"},
+		Data:       map[string][]byte{"username": []byte("user"), "password": []byte("secret")},
+	}
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}}
+	c := newFakeClient(t, clusterRepository, source, ns)
+
+	backup := newBackup("app")
+	spec := &k8upv1.RunnableSpec{Backend: &k8upv1.Backend{S3: &k8upv1.S3Spec{Bucket: "app"}}}
+	applied, err := ApplyClusterRepository(context.TODO(), c, backup, spec)
+	require.NoError(t, err)
+	require.NotNil(t, applied)
+
+	assert.Equal(t, "s3:https://s3.example.com/app", spec.Backend.String(), "the bucket of the job takes precedence")
+	assert.Equal(t, "k8up-clusterrepository-backup-backup", spec.Backend.S3.AccessKeyIDSecretRef.Name)
+	assert.Equal(t, "s3.username", spec.Backend.S3.AccessKeyIDSecretRef.Key)
+	assert.Equal(t, "s3.password", spec.Backend.RepoPasswordSecretRef.Key)
+	assert.Equal(t, "s3", clusterRepository.Spec.Backend.S3.AccessKeyIDSecretRef.Name, "the ClusterRepository isn't changed")
+
+	copied := &corev1.Secret{}
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "app", Name: "k8up-clusterrepository-backup-backup"}, copied))
+	assert.Equal(t, map[string][]byte{"s3.username": []byte("user"), "s3.password": []byte("secret")}, copied.Data)
+	assert.Equal(t, "default", copied.Labels[LabelClusterRepository])
+	require.Len(t, copied.OwnerReferences, 1)
+	assert.Equal(t, "backup", copied.OwnerReferences[0].Name, "the copy is deleted with the job object")
+}
+
+func TestApplyClusterRepository_CACertConfigMap(t *testing.T) {
+	cfg.Config.OperatorNamespace = "k8up-system"
+	defer func() { cfg.Config.OperatorNamespace = "" }()
+
+	clusterRepository := newClusterRepository("default", nil)
+	clusterRepository.Spec.Backend.TLSOptions = &k8upv1.TLSOptions{
+		CACertConfigMapRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "ca"}, Key: "ca.crt"},
+	}
+	source := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "ca", Namespace: "k8up-system"},
+		Data:       map[string]string{"ca.crt": "certificate"},
+	}
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}}
+	c := newFakeClient(t, clusterRepository, source, ns)
+
+	spec := &k8upv1.RunnableSpec{}
+	_, err := ApplyClusterRepository(context.TODO(), c, newBackup("app"), spec)
+	require.NoError(t, err)
+
+	assert.Nil(t, spec.Backend.TLSOptions.CACertConfigMapRef, "the ConfigMap isn't in the namespace of the job")
+	require.NotNil(t, spec.Backend.TLSOptions.CACertSecretRef)
+	assert.Equal(t, "k8up-clusterrepository-backup-backup", spec.Backend.TLSOptions.CACertSecretRef.Name)
+	assert.Equal(t, "configmap.ca.ca.crt", spec.Backend.TLSOptions.CACertSecretRef.Key)
+	assert.NotNil(t, clusterRepository.Spec.Backend.TLSOptions.CACertConfigMapRef, "the ClusterRepository isn't changed")
+
+	copied := &corev1.Secret{}
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "app", Name: "k8up-clusterrepository-backup-backup"}, copied))
+	assert.Equal(t, map[string][]byte{"configmap.ca.ca.crt": []byte("certificate")}, copied.Data)
+}
+
+func TestApplyClusterRepository_FinishedJobObject(t *testing.T) {
+	cfg.Config.OperatorNamespace = "k8up-system"
+	defer func() { cfg.Config.OperatorNamespace = "" }()
+
+	clusterRepository := newClusterRepository("default", nil)
+	clusterRepository.Spec.Backend.S3.AccessKeyIDSecretRef = &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "s3"}, Key: "username"}
+	source := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "s3", Namespace: "k8up-system"},
+		Data:       map[string][]byte{"username": []byte("user")},
+	}
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}}
+	c := newFakeClient(t, clusterRepository, source, ns)
+
+	backup := newBackup("app")
+	backup.Status.SetSucceeded("done")
+	backup.Status.SetFinished("done")
+	spec := &k8upv1.RunnableSpec{}
+	_, err := ApplyClusterRepository(context.TODO(), c, backup, spec)
+	require.NoError(t, err)
+
+	assert.Equal(t, "k8up-clusterrepository-backup-backup", spec.Backend.S3.AccessKeyIDSecretRef.Name)
+	err = c.Get(context.TODO(), types.NamespacedName{Namespace: "app", Name: "k8up-clusterrepository-backup-backup"}, &corev1.Secret{})
+	assert.True(t, errors.IsNotFound(err), "the credentials aren't copied for a job object that has finished")
+}
+
+func TestConfig_SetSucceeded_RemovesClusterRepositorySecret(t *testing.T) {
+	backup := newBackup("app")
+	copied := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "k8up-clusterrepository-backup-backup", Namespace: "app"}}
+	c := newFakeClient(t, backup, copied)
+
+	config := NewConfig(c, backup, "s3:https://s3.example.com/default")
+	config.ClusterRepository = newClusterRepository("default", nil)
+	status := backup.GetStatus()
+	config.SetSucceeded(context.TODO(), "backup", &status, "succeeded")
+
+	err := c.Get(context.TODO(), types.NamespacedName{Namespace: "app", Name: "k8up-clusterrepository-backup-backup"}, &corev1.Secret{})
+	assert.True(t, errors.IsNotFound(err), "the copied credentials are removed once the job has finished")
+}
+
+func TestApplyClusterRepository_GlobalS3(t *testing.T) {
+	cfg.Config.GlobalS3Endpoint = "https://global.example.com"
+	cfg.Config.GlobalS3Bucket = "global"
+	defer func() {
+		cfg.Config.GlobalS3Endpoint = ""
+		cfg.Config.GlobalS3Bucket = ""
+	}()
+	c := newFakeClient(t, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}})
+
+	spec := &k8upv1.RunnableSpec{}
+	applied, err := ApplyClusterRepository(context.TODO(), c, newBackup("app"), spec)
+	require.NoError(t, err)
+	assert.Nil(t, applied)
+	assert.Equal(t, "s3:https://global.example.com/global", spec.Backend.String())
+}
+
+func newBackup(namespace string) *k8upv1.Backup {
+	return &k8upv1.Backup{ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: namespace}}
+}
+
+func newClusterRepository(name string, selector *metav1.LabelSelector) *k8upv1.ClusterRepository {
+	return &k8upv1.ClusterRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: k8upv1.ClusterRepositorySpec{
+			Backend:           &k8upv1.Backend{S3: &k8upv1.S3Spec{Endpoint: "https://s3.example.com", Bucket: name}},
+			NamespaceSelector: selector,
+		},
+	}
+}
+
+func newFakeClient(t *testing.T, objects ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, k8upv1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
+
+func Test_mergeBackend_RepoPassword(t *testing.T) {
+	defaults := func() *k8upv1.Backend {
+		return &k8upv1.Backend{
+			RepoPasswordSecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cluster"}, Key: "password"},
+			S3:                    &k8upv1.S3Spec{Endpoint: "https://s3.example.com", Bucket: "default"},
+		}
+	}
+	tests := map[string]struct {
+		givenBackend     *k8upv1.Backend
+		expectedPassword *k8upv1.Backend
+	}{
+		"GivenNoPassword_ThenExpectPasswordOfDefaults": {
+			givenBackend:     &k8upv1.Backend{S3: &k8upv1.S3Spec{Bucket: "app"}},
+			expectedPassword: &k8upv1.Backend{RepoPasswordSecretRef: defaults().RepoPasswordSecretRef},
+		},
+		"GivenNoStorageTypeWithPasswordCommand_ThenExpectOnlyPasswordCommand": {
+			givenBackend:     &k8upv1.Backend{RepoPasswordCommand: &k8upv1.PasswordCommandSpec{Command: "/bin/get-password"}},
+			expectedPassword: &k8upv1.Backend{RepoPasswordCommand: &k8upv1.PasswordCommandSpec{Command: "/bin/get-password"}},
+		},
+		"GivenPasswordFile_ThenExpectOnlyPasswordFile": {
+			givenBackend: &k8upv1.Backend{
+				RepoPasswordFileSecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "app"}, Key: "password"},
+				S3:                        &k8upv1.S3Spec{Bucket: "app"},
+			},
+			expectedPassword: &k8upv1.Backend{
+				RepoPasswordFileSecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "app"}, Key: "password"},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			backend := mergeBackend(tt.givenBackend, defaults())
+			assert.Equal(t, tt.expectedPassword.RepoPasswordSecretRef, backend.RepoPasswordSecretRef)
+			assert.Equal(t, tt.expectedPassword.RepoPasswordFileSecretRef, backend.RepoPasswordFileSecretRef)
+			assert.Equal(t, tt.expectedPassword.RepoPasswordCommand, backend.RepoPasswordCommand)
+		})
+	}
+}
+
+func Test_mergeBackend_Storage(t *testing.T) {
+	secretRef := func(key string) *corev1.SecretKeySelector {
+		return &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cluster"}, Key: key}
+	}
+	tests := map[string]struct {
+		givenBackend       *k8upv1.Backend
+		givenDefaults      *k8upv1.Backend
+		expectedBackend    *k8upv1.Backend
+		expectedRepository string
+	}{
+		"GivenRestWithoutURL_ThenExpectURLOfDefaults": {
+			givenBackend:       &k8upv1.Backend{Rest: &k8upv1.RestServerSpec{Path: "app"}},
+			givenDefaults:      &k8upv1.Backend{Rest: &k8upv1.RestServerSpec{URL: "https://rest.example.com", PasswordSecretRef: secretRef("rest-password")}},
+			expectedBackend:    &k8upv1.Backend{Rest: &k8upv1.RestServerSpec{URL: "https://rest.example.com", Path: "app", PasswordSecretRef: secretRef("rest-password")}},
+			expectedRepository: "rest:https://rest.example.com/app",
+		},
+		"GivenSwiftWithoutCredentials_ThenExpectCredentialsOfDefaults": {
+			givenBackend: &k8upv1.Backend{Swift: &k8upv1.SwiftSpec{Container: "app"}},
+			givenDefaults: &k8upv1.Backend{Swift: &k8upv1.SwiftSpec{
+				Container:         "default",
+				Path:              "k8up",
+				AuthURLSecretRef:  secretRef("auth-url"),
+				UsernameSecretRef: secretRef("username"),
+				PasswordSecretRef: secretRef("password"),
+			}},
+			expectedBackend: &k8upv1.Backend{Swift: &k8upv1.SwiftSpec{
+				Container:         "app",
+				Path:              "k8up",
+				AuthURLSecretRef:  secretRef("auth-url"),
+				UsernameSecretRef: secretRef("username"),
+				PasswordSecretRef: secretRef("password"),
+			}},
+			expectedRepository: "swift:app:k8up",
+		},
+		"GivenOtherStorageType_ThenExpectBackendUnchanged": {
+			givenBackend:       &k8upv1.Backend{Rest: &k8upv1.RestServerSpec{URL: "https://rest.example.com"}},
+			givenDefaults:      &k8upv1.Backend{S3: &k8upv1.S3Spec{Endpoint: "https://s3.example.com", Bucket: "default"}},
+			expectedBackend:    &k8upv1.Backend{Rest: &k8upv1.RestServerSpec{URL: "https://rest.example.com"}},
+			expectedRepository: "rest:https://rest.example.com",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			backend := mergeBackend(tt.givenBackend, tt.givenDefaults)
+			assert.Equal(t, tt.expectedBackend, backend)
+			assert.Equal(t, tt.expectedRepository, backend.String())
+		})
+	}
+}
+
+func TestApplyClusterRepository_NamespacedPath(t *testing.T) {
+	clusterRepository := newClusterRepository("default", nil)
+	clusterRepository.Spec.Backend = &k8upv1.Backend{
+		Rest: &k8upv1.RestServerSpec{URL: "https://rest.example.com", Path: "k8up", NamespacedPath: true},
+	}
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}}
+	c := newFakeClient(t, clusterRepository, ns)
+
+	spec := &k8upv1.RunnableSpec{}
+	_, err := ApplyClusterRepository(context.TODO(), c, newBackup("app"), spec)
+	require.NoError(t, err)
+
+	assert.Equal(t, "rest:https://rest.example.com/k8up/app", spec.Backend.String())
+	assert.Equal(t, "k8up", clusterRepository.Spec.Backend.Rest.Path, "the ClusterRepository isn't changed")
+}
@@ -0,0 +1,40 @@
+package job
+
+import "k8s.io/apimachinery/pkg/runtime"
+
+// Reasons of the Events the operator records about its objects.
+const (
+	EventReasonJobCreated          = "JobCreated"
+	EventReasonWaiting             = "Waiting"
+	EventReasonSucceeded           = "Succeeded"
+	EventReasonPartiallySucceeded  = "PartiallySucceeded"
+	EventReasonFailed              = "Failed"
+	EventReasonPreBackupPodTimeout = "PreBackupPodTimeout"
+	EventReasonCleanedUp           = "CleanedUp"
+	EventReasonCleanupFailed       = "CleanupFailed"
+	EventReasonScheduleFailed      = "ScheduleFailed"
+)
+
+// Actions of the Events the operator records about its objects.
+const (
+	EventActionCreate   = "Create"
+	EventActionWait     = "Wait"
+	EventActionComplete = "Complete"
+	EventActionDelete   = "Delete"
+	EventActionSchedule = "Schedule"
+)
+
+// Eventf records an Event about the job object.
+// related is an optional secondary object the Event refers to, e.g. a batch Job that was created.
+// It does nothing if the Config has no Recorder.
+func (c Config) Eventf(related runtime.Object, eventtype, reason, action, note string, args ...interface{}) {
+	c.EventfFor(c.Obj, related, eventtype, reason, action, note, args...)
+}
+
+// EventfFor records an Event about the given object, see Eventf.
+func (c Config) EventfFor(regarding, related runtime.Object, eventtype, reason, action, note string, args ...interface{}) {
+	if c.Recorder == nil {
+		return
+	}
+	c.Recorder.Eventf(regarding, related, eventtype, reason, action, note, args...)
+}
@@ -16,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -34,6 +35,21 @@ type Config struct {
 	Client     client.Client
 	Obj        k8upv1.JobObject
 	Repository string
+	// Backend is the backend the job uses, completed with the Repository or ClusterRepository
+	// and limited to the credentials of the job's operation.
+	// It's resolved apart from the spec of Obj, because updating the status of Obj resets its spec to the stored one.
+	Backend *k8upv1.Backend
+	// Volumes are the volumes of the job's spec and of the Repository it refers to.
+	Volumes *[]k8upv1.RunnableVolumeSpec
+	// ManagedRepository is the Repository the job refers to.
+	// It's nil if the job configures its backend itself.
+	ManagedRepository *k8upv1.Repository
+	// ClusterRepository is the ClusterRepository that applies to the job's namespace.
+	// It's nil if there is none.
+	ClusterRepository *k8upv1.ClusterRepository
+	// Recorder records Events about the job object.
+	// No Events are recorded if it's nil.
+	Recorder events.EventRecorder
 }
 
 // NewConfig returns a new configuration.
@@ -45,6 +61,80 @@ func NewConfig(client client.Client, obj k8upv1.JobObject, repository string) Co
 	}
 }
 
+// ResolveRepository fetches the Repository the given spec refers to and configures the spec to use it.
+// It returns nil if the spec doesn't refer to a Repository.
+func ResolveRepository(ctx context.Context, c client.Client, namespace string, spec *k8upv1.RunnableSpec) (*k8upv1.Repository, error) {
+	if spec.RepositoryRef == nil {
+		return nil, nil
+	}
+	repository := &k8upv1.Repository{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: spec.RepositoryRef.Name}, repository); err != nil {
+		return nil, fmt.Errorf("cannot get repository %q: %w", spec.RepositoryRef.Name, err)
+	}
+	repository.ApplyTo(spec)
+	return repository, nil
+}
+
+// NewRepositoryConfig returns the configuration of the job for the given job object, whose spec is given as well.
+// The backend of the spec is completed with the Repository it refers to or the ClusterRepository that applies to the namespace,
+// and is limited to the credentials of the given operation.
+// The spec itself isn't changed, the resolved backend and volumes are set in the configuration.
+// The job uses the global repository if the spec has no backend.
+func NewRepositoryConfig(ctx context.Context, c client.Client, obj k8upv1.JobObject, spec *k8upv1.RunnableSpec, operation k8upv1.CredentialOperation) (Config, error) {
+	resolved := spec.DeepCopy()
+	managedRepository, err := ResolveRepository(ctx, c, obj.GetNamespace(), resolved)
+	if err != nil {
+		return Config{}, err
+	}
+	clusterRepository, err := ApplyClusterRepository(ctx, c, obj, resolved)
+	if err != nil {
+		return Config{}, err
+	}
+	backend := resolved.Backend.WithCredentialsFor(operation)
+
+	config := NewConfig(c, obj, RepositoryOf(backend))
+	config.Backend = backend
+	config.Volumes = resolved.Volumes
+	config.ManagedRepository = managedRepository
+	config.ClusterRepository = clusterRepository
+	return config, nil
+}
+
+// RepositoryOf returns the restic repository of the given backend, or the global repository if there's no backend.
+func RepositoryOf(backend *k8upv1.Backend) string {
+	if backend == nil {
+		return cfg.Config.GetGlobalRepository()
+	}
+	return backend.String()
+}
+
+// IsSharedRepository returns true if the job uses the repository of its ClusterRepository or the global repository.
+// These repositories contain the snapshots of all namespaces they apply to.
+// The repository of a ClusterRepository that appends the namespace to the path of a rest-server isn't shared.
+func (c Config) IsSharedRepository() bool {
+	if cfg.Config.HasGlobalRepository() && c.Repository == cfg.Config.GetGlobalRepository() {
+		return true
+	}
+	return c.ClusterRepository != nil && c.ClusterRepository.Spec.Backend != nil && c.Repository == c.ClusterRepository.Spec.Backend.String()
+}
+
+// IsRepositoryInitialized returns false if the job refers to a Repository that the operator hasn't initialized yet,
+// or whose backend has changed since.
+func (c Config) IsRepositoryInitialized() bool {
+	return c.ManagedRepository == nil || c.ManagedRepository.IsInitialized()
+}
+
+// RepositoryReady returns true if the job can use its repository.
+// If the job refers to a Repository that the operator hasn't initialized yet, the Ready condition of the job object is set to false instead.
+func (c Config) RepositoryReady(ctx context.Context) bool {
+	if c.IsRepositoryInitialized() {
+		return true
+	}
+	controllerruntime.LoggerFrom(ctx).Info("Delaying job until the repository is initialized", "repository", c.ManagedRepository.Name)
+	c.SetConditionFalseWithMessage(ctx, k8upv1.ConditionReady, k8upv1.ReasonRepositoryNotInitialized, "repository %q is not initialized yet", c.ManagedRepository.Name)
+	return false
+}
+
 // MutateBatchJob mutates the given Job with generic spec applicable to all K8up-spawned Jobs.
 func MutateBatchJob(ctx context.Context, batchJob *batchv1.Job, jobObj k8upv1.JobObject, config Config, c client.Client) error {
 	batchJob.Labels = labels.Merge(batchJob.Labels, labels.Set{
@@ -103,12 +193,13 @@ func MutateBatchJob(ctx context.Context, batchJob *batchv1.Job, jobObj k8upv1.Jo
 	return controllerruntime.SetControllerReference(jobObj, batchJob, config.Client.Scheme())
 }
 
-func ReconcileJobStatus(ctx context.Context, key types.NamespacedName, client client.Client, obj k8upv1.JobObject) error {
+// ReconcileJobStatus fetches the batch Job with the given key and updates the status of the job object accordingly.
+func (c Config) ReconcileJobStatus(ctx context.Context, key types.NamespacedName) error {
 	log := controllerruntime.LoggerFrom(ctx)
 	log.V(1).Info("reconciling job", "key", key)
 
 	batchJob := &batchv1.Job{}
-	err := client.Get(ctx, key, batchJob)
+	err := c.Client.Get(ctx, key, batchJob)
 	if err != nil {
 		if !errors.IsNotFound(err) {
 			return fmt.Errorf("unable to get job: %w", err)
@@ -117,30 +208,31 @@ func ReconcileJobStatus(ctx context.Context, key types.NamespacedName, client cl
 		return nil
 	}
 
-	UpdateStatus(ctx, batchJob, obj)
+	c.UpdateStatus(ctx, batchJob)
 
 	log.V(1).Info("updating status")
-	if err := client.Status().Update(ctx, obj); err != nil {
+	if err := c.Client.Status().Update(ctx, c.Obj); err != nil {
 		return fmt.Errorf("obj status update failed: %w", err)
 	}
 	return nil
 }
 
-// UpdateStatus retrieves status of batchJob and sets status of obj accordingly.
-func UpdateStatus(ctx context.Context, batchJob *batchv1.Job, obj k8upv1.JobObject) {
+// UpdateStatus retrieves status of batchJob and sets status of the job object accordingly.
+func (c Config) UpdateStatus(ctx context.Context, batchJob *batchv1.Job) {
 	// update status conditions based on Job status
+	obj := c.Obj
 	objStatus := obj.GetStatus()
 	message := fmt.Sprintf("job '%s' has %d active, %d succeeded and %d failed pods",
 		batchJob.Name, batchJob.Status.Active, batchJob.Status.Succeeded, batchJob.Status.Failed)
 
 	if HasSucceeded(batchJob.Status.Conditions) {
-		SetSucceeded(ctx, batchJob.Name, batchJob.Namespace, obj.GetType(), &objStatus, message)
+		c.SetSucceeded(ctx, batchJob.Name, &objStatus, message)
 		if scheduleName, ok := obj.GetLabels()[k8upv1.LabelK8upScheduleName]; ok {
 			monitoring.SetScheduleLastJobStatus(batchJob.Namespace, scheduleName, obj.GetType(), true)
 		}
 	}
 	if HasFailed(batchJob.Status.Conditions) {
-		SetFailed(ctx, batchJob.Name, batchJob.Namespace, obj.GetType(), &objStatus, message)
+		c.SetFailed(ctx, batchJob.Name, &objStatus, message)
 		if scheduleName, ok := obj.GetLabels()[k8upv1.LabelK8upScheduleName]; ok {
 			monitoring.SetScheduleLastJobStatus(batchJob.Namespace, scheduleName, obj.GetType(), false)
 		}
@@ -165,29 +257,49 @@ func HasStarted(conditions []batchv1.JobCondition) bool {
 	return successCond == nil && failedCond == nil
 }
 
-func SetSucceeded(ctx context.Context, name, ns string, typ k8upv1.JobType, objStatus *k8upv1.Status, message string) {
+// SetSucceeded marks the given status of the job object as succeeded.
+// The name is the name of the batch Job(s) that completed.
+func (c Config) SetSucceeded(ctx context.Context, name string, objStatus *k8upv1.Status, message string) {
 	log := controllerruntime.LoggerFrom(ctx)
 
 	if !objStatus.HasSucceeded() {
 		// only increase success counter if new condition
-		monitoring.IncSuccessCounters(ns, typ)
+		monitoring.IncSuccessCounters(c.Obj.GetNamespace(), c.Obj.GetType())
 		log.Info("Job succeeded")
+		c.Eventf(nil, corev1.EventTypeNormal, EventReasonSucceeded, EventActionComplete, "Job '%s' completed successfully", name)
+		c.removeClusterRepositorySecret(ctx)
 	}
 	objStatus.SetSucceeded(message)
 	objStatus.SetFinished(fmt.Sprintf("job '%s' completed successfully", name))
 }
-func SetFailed(ctx context.Context, name, ns string, typ k8upv1.JobType, objStatus *k8upv1.Status, message string) {
+
+// SetFailed marks the given status of the job object as failed.
+// The name is the name of the batch Job(s) that failed.
+func (c Config) SetFailed(ctx context.Context, name string, objStatus *k8upv1.Status, message string) {
 	log := controllerruntime.LoggerFrom(ctx)
 
 	if !objStatus.HasFailed() {
 		// only increase fail counter if new condition
-		monitoring.IncFailureCounters(ns, typ)
+		monitoring.IncFailureCounters(c.Obj.GetNamespace(), c.Obj.GetType())
 		log.Info("Job failed")
+		c.Eventf(nil, corev1.EventTypeWarning, EventReasonFailed, EventActionComplete, "Job '%s' has failed: %s", name, message)
+		c.removeClusterRepositorySecret(ctx)
 	}
 	objStatus.SetFailed(message)
 	objStatus.SetFinished(fmt.Sprintf("job '%s' has failed", name))
 }
 
+// removeClusterRepositorySecret deletes the copies of the Secrets of the ClusterRepository once the job object has finished.
+// The copies are deleted with the job object as well, so an error is only logged.
+func (c Config) removeClusterRepositorySecret(ctx context.Context) {
+	if c.ClusterRepository == nil {
+		return
+	}
+	if err := deleteClusterRepositorySecret(ctx, c.Client, c.Obj); err != nil {
+		controllerruntime.LoggerFrom(ctx).Error(err, "could not remove copied secrets of cluster repository")
+	}
+}
+
 // FindStatusCondition finds the condition with the given type in the batchv1.JobCondition slice.
 // Returns nil if not found.
 func FindStatusCondition(conditions []batchv1.JobCondition, conditionType batchv1.JobConditionType) *batchv1.JobCondition {
@@ -1,9 +1,18 @@
 package job
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/cfg"
 )
 
 func TestSha256Hash(t *testing.T) {
@@ -28,3 +37,89 @@ func TestSha256Hash(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_SetSucceeded_GivenRepeatedCalls_ThenExpectSingleEvent(t *testing.T) {
+	recorder := events.NewFakeRecorder(2)
+	config := Config{Obj: &k8upv1.Check{}, Recorder: recorder}
+	status := k8upv1.Status{}
+
+	config.SetSucceeded(context.TODO(), "check-1", &status, "done")
+	config.SetSucceeded(context.TODO(), "check-1", &status, "done")
+
+	assert.True(t, status.HasSucceeded())
+	assert.Len(t, recorder.Events, 1)
+	assert.Equal(t, "Normal Succeeded Job 'check-1' completed successfully", <-recorder.Events)
+}
+
+func TestConfig_SetFailed_GivenRepeatedCalls_ThenExpectSingleEvent(t *testing.T) {
+	recorder := events.NewFakeRecorder(2)
+	config := Config{Obj: &k8upv1.Check{}, Recorder: recorder}
+	status := k8upv1.Status{}
+
+	config.SetFailed(context.TODO(), "check-1", &status, "1 failed pods")
+	config.SetFailed(context.TODO(), "check-1", &status, "1 failed pods")
+
+	assert.True(t, status.HasFailed())
+	assert.Len(t, recorder.Events, 1)
+	assert.Equal(t, "Warning Failed Job 'check-1' has failed: 1 failed pods", <-recorder.Events)
+}
+
+func TestConfig_Eventf_GivenNoRecorder_ThenExpectNoPanic(t *testing.T) {
+	config := Config{Obj: &k8upv1.Check{}}
+	assert.NotPanics(t, func() {
+		config.Eventf(nil, "Normal", EventReasonWaiting, EventActionWait, "waiting")
+	})
+}
+
+func TestNewRepositoryConfig(t *testing.T) {
+	repository := &k8upv1.Repository{
+		ObjectMeta: metav1.ObjectMeta{Name: "backups", Namespace: "app"},
+		Spec:       k8upv1.RepositorySpec{Backend: &k8upv1.Backend{S3: &k8upv1.S3Spec{Endpoint: "https://s3.example.com", Bucket: "app"}}},
+	}
+	c := newFakeClient(t, repository, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}})
+	obj := &k8upv1.Check{ObjectMeta: metav1.ObjectMeta{Name: "check", Namespace: "app"}}
+	obj.Spec.RepositoryRef = &corev1.LocalObjectReference{Name: "backups"}
+
+	config, err := NewRepositoryConfig(context.TODO(), c, obj, &obj.Spec.RunnableSpec, k8upv1.CredentialOperationMaintenance)
+	require.NoError(t, err)
+
+	assert.Equal(t, "s3:https://s3.example.com/app", config.Repository)
+	assert.Equal(t, "s3:https://s3.example.com/app", config.Backend.String())
+	assert.Nil(t, obj.Spec.Backend, "the spec must not be changed")
+	require.NotNil(t, config.ManagedRepository)
+	assert.Nil(t, config.ClusterRepository)
+	assert.False(t, config.RepositoryReady(context.TODO()), "the repository isn't initialized yet")
+	assert.Equal(t, k8upv1.ReasonRepositoryNotInitialized.String(), meta.FindStatusCondition(obj.Status.Conditions, k8upv1.ConditionReady.String()).Reason)
+}
+
+func TestRepositoryOf(t *testing.T) {
+	cfg.Config.GlobalS3Endpoint = "https://global.example.com"
+	cfg.Config.GlobalS3Bucket = "global"
+	defer func() {
+		cfg.Config.GlobalS3Endpoint = ""
+		cfg.Config.GlobalS3Bucket = ""
+	}()
+
+	assert.Equal(t, "s3:https://global.example.com/global", RepositoryOf(nil))
+	assert.Equal(t, "/data", RepositoryOf(&k8upv1.Backend{Local: &k8upv1.LocalSpec{MountPath: "/data"}}))
+}
+
+func TestConfig_IsSharedRepository(t *testing.T) {
+	cfg.Config.GlobalS3Endpoint = "https://global.example.com"
+	cfg.Config.GlobalS3Bucket = "global"
+	defer func() {
+		cfg.Config.GlobalS3Endpoint = ""
+		cfg.Config.GlobalS3Bucket = ""
+	}()
+	clusterRepository := &k8upv1.ClusterRepository{Spec: k8upv1.ClusterRepositorySpec{Backend: &k8upv1.Backend{
+		S3: &k8upv1.S3Spec{Endpoint: "https://s3.example.com", Bucket: "shared"},
+	}}}
+	namespacedRepository := &k8upv1.ClusterRepository{Spec: k8upv1.ClusterRepositorySpec{Backend: &k8upv1.Backend{
+		Rest: &k8upv1.RestServerSpec{URL: "https://rest.example.com", NamespacedPath: true},
+	}}}
+
+	assert.True(t, Config{Repository: "s3:https://global.example.com/global"}.IsSharedRepository())
+	assert.True(t, Config{Repository: "s3:https://s3.example.com/shared", ClusterRepository: clusterRepository}.IsSharedRepository())
+	assert.False(t, Config{Repository: "s3:https://s3.example.com/app", ClusterRepository: clusterRepository}.IsSharedRepository())
+	assert.False(t, Config{Repository: "rest:https://rest.example.com/app", ClusterRepository: namespacedRepository}.IsSharedRepository())
+}
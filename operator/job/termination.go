@@ -0,0 +1,33 @@
+package job
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// LastTerminationMessage returns the most recent termination message of the first container of the given Pods.
+// Pods of failed jobs may have been restarted, only the latest attempt counts.
+func LastTerminationMessage(pods []corev1.Pod) (*corev1.Pod, string) {
+	var lastPod *corev1.Pod
+	var last *corev1.ContainerStateTerminated
+	for i := range pods {
+		pod := &pods[i]
+		for _, status := range pod.Status.ContainerStatuses {
+			if len(pod.Spec.Containers) == 0 || status.Name != pod.Spec.Containers[0].Name {
+				continue
+			}
+			for _, terminated := range []*corev1.ContainerStateTerminated{status.State.Terminated, status.LastTerminationState.Terminated} {
+				if terminated == nil || terminated.Message == "" {
+					continue
+				}
+				if last == nil || last.FinishedAt.Before(&terminated.FinishedAt) {
+					last = terminated
+					lastPod = pod
+				}
+			}
+		}
+	}
+	if last == nil {
+		return nil, ""
+	}
+	return lastPod, last.Message
+}
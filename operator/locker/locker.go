@@ -3,6 +3,7 @@ package locker
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 
 	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
@@ -31,6 +32,15 @@ type Locker interface {
 	// The given runnable runs synchronously within a locked mutex, so it should be as short as possible as it blocks other runnables.
 	// It returns false with nil error if the precondition isn't met, false with an error if precondition cannot be determined, or true with the error returned by the runnable.
 	TryRunExclusively(ctx context.Context, runnable func(ctx context.Context) error) (bool, error)
+
+	// TryRunWith attempts to run a non-exclusive Restic job that also writes into the given destination repository, e.g. a copy.
+	// The given runnable is executed if, for both repositories:
+	//   - concurrency limit for the same type of job is not yet reached
+	//   - and no other exclusive job is running (batch Job with Active pods > 0)
+	//
+	// Both repositories are locked while the runnable runs, in the order of their names, so that jobs in opposite directions don't deadlock.
+	// It returns false with nil error if the preconditions aren't met, false with an error if preconditions cannot be determined, or true with the error returned by the runnable.
+	TryRunWith(ctx context.Context, config job.Config, concurrencyLimit int, destination string, runnable func(ctx context.Context) error) (bool, error)
 }
 
 type lockerImpl struct {
@@ -88,6 +98,38 @@ func (l *lockerImpl) TryRunExclusively(ctx context.Context, runnable func(ctx co
 	return false, nil
 }
 
+func (l *lockerImpl) TryRunWith(ctx context.Context, config job.Config, concurrencyLimit int, destination string, runnable func(ctx context.Context) error) (bool, error) {
+	log := controllerruntime.LoggerFrom(ctx).WithName("locker").WithValues("repository", l.repository, "destination", destination)
+	lockers := []*lockerImpl{l}
+	if other := GetForRepository(l.kube, destination).(*lockerImpl); other != l {
+		lockers = append(lockers, other)
+	}
+	sort.Slice(lockers, func(i, j int) bool {
+		return lockers[i].repository < lockers[j].repository
+	})
+	for _, locker := range lockers {
+		locker.m.Lock()
+	}
+	defer func() {
+		for i := len(lockers) - 1; i >= 0; i-- {
+			lockers[i].m.Unlock()
+		}
+		log.V(1).Info("Unlocked")
+	}()
+	log.V(1).Info("Locked")
+
+	for _, locker := range lockers {
+		runningJobs, err := locker.fetchRunningJobs(ctx)
+		if err != nil {
+			return false, fmt.Errorf("cannot determine if jobs are running: %w", err)
+		}
+		if isExclusiveJobRunning(runningJobs) || isConcurrencyLimitReached(runningJobs, config.Obj.GetType(), concurrencyLimit) {
+			return false, nil
+		}
+	}
+	return true, runnable(ctx)
+}
+
 // isExclusiveJobRunning will return true if there's currently an exclusive job running.
 func isExclusiveJobRunning(jobs []batchv1.Job) bool {
 	for _, batchJob := range jobs {
@@ -129,19 +171,22 @@ var jobListFn = func(locker *lockerImpl, ctx context.Context, listOptions ...cli
 	return list, err
 }
 
+// fetchRunningJobs returns the jobs with active Pods that use the repository, including the jobs that write into it as their destination.
 func (l *lockerImpl) fetchRunningJobs(ctx context.Context) ([]batchv1.Job, error) {
-	matchLabels := client.MatchingLabels{
-		job.K8uplabel:              "true",
-		k8upv1.LabelRepositoryHash: job.Sha256Hash(l.repository),
-	}
-	list, err := jobListFn(l, ctx, matchLabels)
-	if err != nil {
-		return []batchv1.Job{}, fmt.Errorf("cannot get list of jobs: %w", err)
-	}
 	filtered := make([]batchv1.Job, 0)
-	for _, batchJob := range list.Items {
-		if batchJob.Status.Active > 0 {
-			filtered = append(filtered, batchJob)
+	for _, label := range []string{k8upv1.LabelRepositoryHash, k8upv1.LabelDestinationRepositoryHash} {
+		matchLabels := client.MatchingLabels{
+			job.K8uplabel: "true",
+			label:         job.Sha256Hash(l.repository),
+		}
+		list, err := jobListFn(l, ctx, matchLabels)
+		if err != nil {
+			return []batchv1.Job{}, fmt.Errorf("cannot get list of jobs: %w", err)
+		}
+		for _, batchJob := range list.Items {
+			if batchJob.Status.Active > 0 {
+				filtered = append(filtered, batchJob)
+			}
 		}
 	}
 	return filtered, nil
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/job"
 	"github.com/stretchr/testify/assert"
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -63,8 +64,9 @@ func TestLocker_IsConcurrentJobsLimitReached(t *testing.T) {
 
 func TestLockerImpl_fetchRunningJobs(t *testing.T) {
 	tests := map[string]struct {
-		givenJobs    []batchv1.Job
-		expectedJobs []batchv1.Job
+		givenJobs     []batchv1.Job
+		givenCopyJobs []batchv1.Job
+		expectedJobs  []batchv1.Job
 	}{
 		"NoneRunning": {
 			givenJobs: []batchv1.Job{
@@ -82,6 +84,19 @@ func TestLockerImpl_fetchRunningJobs(t *testing.T) {
 				{Status: batchv1.JobStatus{Active: 1}},
 			},
 		},
+		"CopyIntoRepositoryRunning": {
+			givenJobs: []batchv1.Job{
+				{Status: batchv1.JobStatus{Active: 1}},
+			},
+			givenCopyJobs: []batchv1.Job{
+				{ObjectMeta: metav1.ObjectMeta{Name: "copy"}, Status: batchv1.JobStatus{Active: 1}},
+				{Status: batchv1.JobStatus{Active: 0}},
+			},
+			expectedJobs: []batchv1.Job{
+				{Status: batchv1.JobStatus{Active: 1}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "copy"}, Status: batchv1.JobStatus{Active: 1}},
+			},
+		},
 	}
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -90,8 +105,12 @@ func TestLockerImpl_fetchRunningJobs(t *testing.T) {
 				// reset function, just to be safe
 				jobListFn = oldFn
 			}()
-			jobListFn = func(*lockerImpl, context.Context, ...client.ListOption) (batchv1.JobList, error) {
-				// fake response
+			jobListFn = func(_ *lockerImpl, _ context.Context, listOptions ...client.ListOption) (batchv1.JobList, error) {
+				// fake response, depending on whether the jobs of the repository or the copies into it are listed
+				matchLabels := listOptions[0].(client.MatchingLabels)
+				if _, ok := matchLabels[k8upv1.LabelDestinationRepositoryHash]; ok {
+					return batchv1.JobList{Items: tc.givenCopyJobs}, nil
+				}
 				return batchv1.JobList{Items: tc.givenJobs}, nil
 			}
 			l := &lockerImpl{repository: "repository"}
@@ -101,3 +120,55 @@ func TestLockerImpl_fetchRunningJobs(t *testing.T) {
 		})
 	}
 }
+
+func TestLockerImpl_TryRunWith(t *testing.T) {
+	exclusive := batchv1.Job{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{job.K8upExclusive: "true"}}, Status: batchv1.JobStatus{Active: 1}}
+	tests := map[string]struct {
+		givenSourceJobs      []batchv1.Job
+		givenDestinationJobs []batchv1.Job
+		expectRun            bool
+	}{
+		"NoneRunning_ExpectRun": {
+			expectRun: true,
+		},
+		"ExclusiveOnSource_ExpectNoRun": {
+			givenSourceJobs: []batchv1.Job{exclusive},
+		},
+		"ExclusiveOnDestination_ExpectNoRun": {
+			givenDestinationJobs: []batchv1.Job{exclusive},
+		},
+		"NonExclusiveOnDestination_ExpectRun": {
+			givenDestinationJobs: []batchv1.Job{{Status: batchv1.JobStatus{Active: 1}}},
+			expectRun:            true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			oldFn := jobListFn
+			defer func() {
+				jobListFn = oldFn
+			}()
+			jobListFn = func(locker *lockerImpl, _ context.Context, listOptions ...client.ListOption) (batchv1.JobList, error) {
+				matchLabels := listOptions[0].(client.MatchingLabels)
+				if _, ok := matchLabels[k8upv1.LabelRepositoryHash]; !ok {
+					return batchv1.JobList{}, nil
+				}
+				if locker.repository == "s3:destination" {
+					return batchv1.JobList{Items: tc.givenDestinationJobs}, nil
+				}
+				return batchv1.JobList{Items: tc.givenSourceJobs}, nil
+			}
+			defer repositoryLockers.Clear()
+
+			didRun := false
+			l := GetForRepository(nil, "s3:source")
+			ran, err := l.TryRunWith(context.TODO(), job.Config{Obj: &k8upv1.Copy{}}, 0, "s3:destination", func(context.Context) error {
+				didRun = true
+				return nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectRun, ran)
+			assert.Equal(t, tc.expectRun, didRun)
+		})
+	}
+}
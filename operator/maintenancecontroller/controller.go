@@ -0,0 +1,111 @@
+package maintenancecontroller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/job"
+	"github.com/k8up-io/k8up/v2/operator/locker"
+)
+
+// MaintenanceReconciler reconciles a Maintenance object
+type MaintenanceReconciler struct {
+	Kube     client.Client
+	Recorder events.EventRecorder
+}
+
+func (r *MaintenanceReconciler) NewObject() *k8upv1.Maintenance {
+	return &k8upv1.Maintenance{}
+}
+
+func (r *MaintenanceReconciler) NewObjectList() *k8upv1.MaintenanceList {
+	return &k8upv1.MaintenanceList{}
+}
+
+func (r *MaintenanceReconciler) Provision(ctx context.Context, obj *k8upv1.Maintenance) (controllerruntime.Result, error) {
+	log := controllerruntime.LoggerFrom(ctx)
+
+	config, err := job.NewRepositoryConfig(ctx, r.Kube, obj, &obj.Spec.RunnableSpec, k8upv1.CredentialOperationMaintenance)
+	if err != nil {
+		return controllerruntime.Result{}, err
+	}
+	config.Recorder = r.Recorder
+
+	executor := NewMaintenanceExecutor(config)
+
+	jobKey := types.NamespacedName{
+		Namespace: obj.GetNamespace(),
+		Name:      executor.jobName(),
+	}
+	if err := config.ReconcileJobStatus(ctx, jobKey); err != nil {
+		return controllerruntime.Result{}, err
+	}
+
+	if obj.Status.HasStarted() {
+		log.V(1).Info("maintenance just started, waiting")
+		return controllerruntime.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+
+	if obj.Status.HasFinished() {
+		if err := r.updateResults(ctx, obj, jobKey); err != nil {
+			return controllerruntime.Result{}, err
+		}
+		executor.cleanupOldMaintenances(ctx, obj)
+		return controllerruntime.Result{}, nil
+	}
+
+	if !config.RepositoryReady(ctx) {
+		return controllerruntime.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	lock := locker.GetForRepository(r.Kube, config.Repository)
+	didRun, err := lock.TryRunExclusively(ctx, executor.Execute)
+	if !didRun && err == nil {
+		log.Info("Delaying maintenance task, another job is running")
+		config.Eventf(nil, corev1.EventTypeNormal, job.EventReasonWaiting, job.EventActionWait, "Waiting for other jobs of repository to finish")
+	}
+	return controllerruntime.Result{RequeueAfter: time.Second * 30}, err
+}
+
+func (r *MaintenanceReconciler) Deprovision(_ context.Context, _ *k8upv1.Maintenance) (controllerruntime.Result, error) {
+	return controllerruntime.Result{}, nil
+}
+
+// updateResults copies the summary that the job's container reported in its termination message into the status.
+// This is done for failed jobs as well, as the output of restic explains why the operation failed.
+func (r *MaintenanceReconciler) updateResults(ctx context.Context, obj *k8upv1.Maintenance, jobKey types.NamespacedName) error {
+	if obj.Status.Summary != "" {
+		return nil
+	}
+	pods := &corev1.PodList{}
+	if err := r.Kube.List(ctx, pods, client.InNamespace(jobKey.Namespace), client.MatchingLabels{batchv1.JobNameLabel: jobKey.Name}); err != nil {
+		return fmt.Errorf("list pods of job %q: %w", jobKey.Name, err)
+	}
+	pod, message := job.LastTerminationMessage(pods.Items)
+	if message == "" {
+		return nil
+	}
+	results := k8upv1.MaintenanceResults{}
+	if err := json.Unmarshal([]byte(message), &results); err != nil {
+		controllerruntime.LoggerFrom(ctx).V(1).Info("ignoring termination message that doesn't contain maintenance results", "pod", pod.Name, "error", err.Error())
+		return nil
+	}
+	if results.Summary == "" {
+		return nil
+	}
+	obj.Status.MaintenanceResults = results
+	if err := r.Kube.Status().Update(ctx, obj); err != nil {
+		return fmt.Errorf("maintenance status update failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package maintenancecontroller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/cfg"
+	"github.com/k8up-io/k8up/v2/operator/job"
+)
+
+func TestMaintenanceReconciler_updateResults(t *testing.T) {
+	obj := &k8upv1.Maintenance{ObjectMeta: metav1.ObjectMeta{Name: "repair", Namespace: "app"}}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "maintenance-repair-abcde", Namespace: "app", Labels: map[string]string{batchv1.JobNameLabel: "maintenance-repair"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "maintenance"}}},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  "maintenance",
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{FinishedAt: metav1.Now(), Message: `{"summary":"saving new index files\ndone"}`}},
+		}}},
+	}
+	r := &MaintenanceReconciler{Kube: newFakeClient(t, obj, pod)}
+
+	require.NoError(t, r.updateResults(context.TODO(), obj, types.NamespacedName{Namespace: "app", Name: "maintenance-repair"}))
+
+	assert.Equal(t, "saving new index files\ndone", obj.Status.Summary)
+	stored := &k8upv1.Maintenance{}
+	require.NoError(t, r.Kube.Get(context.TODO(), client.ObjectKeyFromObject(obj), stored))
+	assert.Equal(t, "saving new index files\ndone", stored.Status.Summary)
+}
+
+func TestMaintenanceExecutor_setupArgs(t *testing.T) {
+	pack := "9b5bd2ba3f1e3b2a36b6fb4e8c5bd9a3c1a7e3c2f4b1a0d9e8c7b6a5f4e3d2c1"
+	e := NewMaintenanceExecutor(job.Config{Obj: &k8upv1.Maintenance{Spec: k8upv1.MaintenanceSpec{
+		Operation: k8upv1.MaintenanceRepairPacks,
+		Packs:     []string{pack},
+	}}})
+
+	assert.Equal(t, []string{"-varDir", cfg.Config.PodVarDir, "-maintenance", "repair-packs", "-pack", pack}, e.setupArgs())
+}
+
+func newFakeClient(t *testing.T, objects ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, k8upv1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).WithStatusSubresource(&k8upv1.Maintenance{}).Build()
+}
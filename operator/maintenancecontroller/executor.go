@@ -0,0 +1,134 @@
+package maintenancecontroller
+
+import (
+	"context"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/cfg"
+	"github.com/k8up-io/k8up/v2/operator/executor"
+	"github.com/k8up-io/k8up/v2/operator/job"
+	"github.com/k8up-io/k8up/v2/operator/utils"
+)
+
+// MaintenanceExecutor will execute the batch.job that repairs or upgrades a repository.
+type MaintenanceExecutor struct {
+	executor.Generic
+	maintenance *k8upv1.Maintenance
+}
+
+// NewMaintenanceExecutor will return a new executor for maintenance jobs.
+func NewMaintenanceExecutor(config job.Config) *MaintenanceExecutor {
+	return &MaintenanceExecutor{
+		Generic:     executor.Generic{Config: config},
+		maintenance: config.Obj.(*k8upv1.Maintenance),
+	}
+}
+
+// Exclusive should return true for jobs that can't run while other jobs run.
+func (*MaintenanceExecutor) Exclusive() bool {
+	return true
+}
+
+// Execute creates the actual batch.job on the k8s api.
+func (m *MaintenanceExecutor) Execute(ctx context.Context) error {
+	batchJob := &batchv1.Job{}
+	batchJob.Name = m.jobName()
+	batchJob.Namespace = m.maintenance.Namespace
+
+	_, err := controllerruntime.CreateOrUpdate(ctx, m.Client, batchJob, func() error {
+		mutateErr := job.MutateBatchJob(ctx, batchJob, m.maintenance, m.Config, m.Client)
+		if mutateErr != nil {
+			return mutateErr
+		}
+
+		batchJob.Spec.Template.Spec.Containers[0].Env = append(batchJob.Spec.Template.Spec.Containers[0].Env, m.setupEnvVars(ctx)...)
+		m.maintenance.Spec.AppendEnvFromToContainer(&batchJob.Spec.Template.Spec.Containers[0])
+		batchJob.Spec.Template.Spec.Containers[0].VolumeMounts = append(batchJob.Spec.Template.Spec.Containers[0].VolumeMounts, m.attachTLSVolumeMounts()...)
+		executor.AttachBackend(&batchJob.Spec.Template, m.Backend)
+		if err := executor.AttachServiceAccount(ctx, m.Client, &batchJob.Spec.Template.Spec, batchJob.Namespace, m.Backend); err != nil {
+			return err
+		}
+		batchJob.Spec.Template.Spec.Volumes = append(batchJob.Spec.Template.Spec.Volumes, utils.AttachEmptyDirVolumes(m.Volumes, executor.TLSMounts(m.Backend)...)...)
+		batchJob.Labels[job.K8upExclusive] = "true"
+
+		// Repaired snapshots are synchronized into Snapshot resources, which requires the permissions of the executor.
+		if batchJob.Spec.Template.Spec.ServiceAccountName == "" {
+			batchJob.Spec.Template.Spec.ServiceAccountName = cfg.Config.ServiceAccount
+		}
+
+		batchJob.Spec.Template.Spec.Containers[0].Args = m.setupArgs()
+
+		return nil
+	})
+	if err != nil {
+		m.SetConditionFalseWithMessage(ctx, k8upv1.ConditionReady, k8upv1.ReasonCreationFailed, "could not create job: %v", err)
+		return err
+	}
+	m.SetStarted(ctx, "the job '%v/%v' was created", batchJob.Namespace, batchJob.Name)
+	m.Eventf(batchJob, corev1.EventTypeNormal, job.EventReasonJobCreated, job.EventActionCreate, "Created job '%s'", batchJob.Name)
+	return nil
+}
+
+func (m *MaintenanceExecutor) jobName() string {
+	return k8upv1.MaintenanceType.String() + "-" + m.maintenance.Name
+}
+
+func (m *MaintenanceExecutor) setupArgs() []string {
+	spec := m.maintenance.Spec
+	args := []string{"-varDir", cfg.Config.PodVarDir, "-maintenance", string(spec.Operation)}
+	if spec.ReadAllPacks {
+		args = append(args, "-readAllPacks")
+	}
+	if spec.Forget {
+		args = append(args, "-forget")
+	}
+	args = append(args, executor.BuildListArgs("-pack", spec.Packs)...)
+	if m.Backend != nil {
+		args = append(args, utils.AppendTLSOptionsArgs(m.Backend.TLSOptions)...)
+	}
+	return args
+}
+
+func (m *MaintenanceExecutor) setupEnvVars(ctx context.Context) []corev1.EnvVar {
+	log := controllerruntime.LoggerFrom(ctx)
+	vars := executor.NewEnvVarConverter()
+
+	if m.Backend != nil {
+		for key, value := range m.Backend.GetCredentialEnv() {
+			vars.SetEnvVarSource(key, value)
+		}
+		vars.SetString(cfg.ResticRepositoryEnvName, m.Backend.String())
+	}
+
+	if cfg.Config.SkipSnapshotSync {
+		vars.SetString("BACKUP_SKIP_SNAPSHOT_SYNC", "true")
+	}
+
+	m.SetRepositoryEnv(&vars, m.Backend)
+
+	err := vars.Merge(executor.DefaultEnv(m.Obj.GetNamespace(), m.Backend))
+	if err != nil {
+		log.Error(err, "error while merging the environment variables", "name", m.Obj.GetName(), "namespace", m.Obj.GetNamespace())
+	}
+
+	return vars.Convert()
+}
+
+func (m *MaintenanceExecutor) cleanupOldMaintenances(ctx context.Context, maintenance *k8upv1.Maintenance) {
+	m.CleanupOldResources(ctx, &k8upv1.MaintenanceList{}, maintenance)
+}
+
+func (m *MaintenanceExecutor) attachTLSVolumeMounts() []corev1.VolumeMount {
+	var tlsVolumeMounts []corev1.VolumeMount
+	if m.Backend != nil && !utils.ZeroLen(m.Backend.VolumeMounts) {
+		tlsVolumeMounts = append(tlsVolumeMounts, *m.Backend.VolumeMounts...)
+	}
+
+	tlsVolumeMounts = append(tlsVolumeMounts, utils.TLSVolumeMounts(executor.TLSMounts(m.Backend)...)...)
+
+	return utils.AttachEmptyDirVolumeMounts(cfg.Config.PodVarDir, &tlsVolumeMounts)
+}
@@ -0,0 +1,30 @@
+package maintenancecontroller
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/reconciler"
+)
+
+// +kubebuilder:rbac:groups=k8up.io,resources=maintenances,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=k8up.io,resources=maintenances/status;maintenances/finalizers,verbs=get;update;patch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=list;get;watch
+
+// SetupWithManager configures the reconciler.
+func SetupWithManager(mgr ctrl.Manager) error {
+	name := "maintenance.k8up.io"
+	r := reconciler.NewReconciler[*k8upv1.Maintenance, *k8upv1.MaintenanceList](mgr.GetClient(), &MaintenanceReconciler{
+		Kube:     mgr.GetClient(),
+		Recorder: mgr.GetEventRecorder(name),
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&k8upv1.Maintenance{}).
+		Owns(&batchv1.Job{}).
+		Named(name).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Complete(r)
+}
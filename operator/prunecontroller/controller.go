@@ -2,20 +2,23 @@ package prunecontroller
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
-	"github.com/k8up-io/k8up/v2/operator/cfg"
 	"github.com/k8up-io/k8up/v2/operator/job"
 	"github.com/k8up-io/k8up/v2/operator/locker"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // PruneReconciler reconciles a Prune object
 type PruneReconciler struct {
-	Kube client.Client
+	Kube     client.Client
+	Recorder events.EventRecorder
 }
 
 func (r *PruneReconciler) NewObject() *k8upv1.Prune {
@@ -29,18 +32,18 @@ func (r *PruneReconciler) NewObjectList() *k8upv1.PruneList {
 func (r *PruneReconciler) Provision(ctx context.Context, obj *k8upv1.Prune) (controllerruntime.Result, error) {
 	log := controllerruntime.LoggerFrom(ctx)
 
-	repository := cfg.Config.GetGlobalRepository()
-	if obj.Spec.Backend != nil {
-		repository = obj.Spec.Backend.String()
+	config, err := job.NewRepositoryConfig(ctx, r.Kube, obj, &obj.Spec.RunnableSpec, k8upv1.CredentialOperationMaintenance)
+	if err != nil {
+		return controllerruntime.Result{}, err
 	}
-	config := job.NewConfig(r.Kube, obj, repository)
+	config.Recorder = r.Recorder
 	executor := NewPruneExecutor(config)
 
 	jobKey := types.NamespacedName{
 		Namespace: obj.GetNamespace(),
 		Name:      executor.jobName(),
 	}
-	if err := job.ReconcileJobStatus(ctx, jobKey, r.Kube, obj); err != nil {
+	if err := config.ReconcileJobStatus(ctx, jobKey); err != nil {
 		return controllerruntime.Result{}, err
 	}
 
@@ -53,14 +56,42 @@ func (r *PruneReconciler) Provision(ctx context.Context, obj *k8upv1.Prune) (con
 		return controllerruntime.Result{}, nil
 	}
 
-	lock := locker.GetForRepository(r.Kube, repository)
+	if err := checkHostnames(config, obj.Spec.Retention.Hostnames); err != nil {
+		// The job can't run until the Prune is changed.
+		log.Info("Not creating prune job", "reason", err.Error())
+		config.SetConditionFalseWithMessage(ctx, k8upv1.ConditionReady, k8upv1.ReasonCreationFailed, "cannot prune: %v", err)
+		return controllerruntime.Result{}, nil
+	}
+
+	if !config.RepositoryReady(ctx) {
+		return controllerruntime.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	lock := locker.GetForRepository(r.Kube, config.Repository)
 	didRun, err := lock.TryRunExclusively(ctx, executor.Execute)
 	if !didRun && err == nil {
 		log.Info("Delaying prune task, another job is running")
+		config.Eventf(nil, corev1.EventTypeNormal, job.EventReasonWaiting, job.EventActionWait, "Waiting for other jobs of repository to finish")
 	}
 	return controllerruntime.Result{RequeueAfter: time.Second * 8}, err
 }
 
+// checkHostnames returns an error if the Prune applies its retention to the snapshots of other namespaces in a shared repository.
+// The repositories of ClusterRepositories and the global repository contain the snapshots of all namespaces,
+// a Prune may only remove those of its own namespace from them.
+func checkHostnames(config job.Config, hostnames []string) error {
+	if !config.IsSharedRepository() {
+		return nil
+	}
+	namespace := config.Obj.GetNamespace()
+	for _, hostname := range hostnames {
+		if hostname != namespace {
+			return fmt.Errorf("the hostname %q isn't the namespace %q, the repository %q is shared with other namespaces", hostname, namespace, config.Repository)
+		}
+	}
+	return nil
+}
+
 func (r *PruneReconciler) Deprovision(_ context.Context, _ *k8upv1.Prune) (controllerruntime.Result, error) {
 	return controllerruntime.Result{}, nil
 }
@@ -0,0 +1,55 @@
+package prunecontroller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/job"
+)
+
+func TestCheckHostnames(t *testing.T) {
+	clusterRepository := &k8upv1.ClusterRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Spec:       k8upv1.ClusterRepositorySpec{Backend: &k8upv1.Backend{S3: &k8upv1.S3Spec{Endpoint: "https://s3.example.com", Bucket: "shared"}}},
+	}
+	tests := map[string]struct {
+		givenRepository string
+		givenHostnames  []string
+		expectedError   string
+	}{
+		"GivenOwnRepository_WhenOtherHostnames_ThenExpectNoError": {
+			givenRepository: "s3:https://s3.example.com/app",
+			givenHostnames:  []string{"app", "db"},
+		},
+		"GivenClusterRepository_WhenNoHostnames_ThenExpectNoError": {
+			givenRepository: "s3:https://s3.example.com/shared",
+		},
+		"GivenClusterRepository_WhenOwnNamespace_ThenExpectNoError": {
+			givenRepository: "s3:https://s3.example.com/shared",
+			givenHostnames:  []string{"app"},
+		},
+		"GivenClusterRepository_WhenOtherNamespace_ThenExpectError": {
+			givenRepository: "s3:https://s3.example.com/shared",
+			givenHostnames:  []string{"app", "db"},
+			expectedError:   `the hostname "db" isn't the namespace "app", the repository "s3:https://s3.example.com/shared" is shared with other namespaces`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := job.Config{
+				Obj:               &k8upv1.Prune{ObjectMeta: metav1.ObjectMeta{Name: "prune", Namespace: "app"}},
+				Repository:        tt.givenRepository,
+				ClusterRepository: clusterRepository,
+			}
+			err := checkHostnames(config, tt.givenHostnames)
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedError)
+		})
+	}
+}
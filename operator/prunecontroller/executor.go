@@ -46,7 +46,11 @@ func (p *PruneExecutor) Execute(ctx context.Context) error {
 		batchJob.Spec.Template.Spec.Containers[0].Env = append(batchJob.Spec.Template.Spec.Containers[0].Env, p.setupEnvVars(ctx, p.prune)...)
 		p.prune.Spec.AppendEnvFromToContainer(&batchJob.Spec.Template.Spec.Containers[0])
 		batchJob.Spec.Template.Spec.Containers[0].VolumeMounts = append(batchJob.Spec.Template.Spec.Containers[0].VolumeMounts, p.attachTLSVolumeMounts()...)
-		batchJob.Spec.Template.Spec.Volumes = append(batchJob.Spec.Template.Spec.Volumes, utils.AttachEmptyDirVolumes(p.prune.Spec.Volumes)...)
+		executor.AttachBackend(&batchJob.Spec.Template, p.Backend)
+		if err := executor.AttachServiceAccount(ctx, p.Client, &batchJob.Spec.Template.Spec, batchJob.Namespace, p.Backend); err != nil {
+			return err
+		}
+		batchJob.Spec.Template.Spec.Volumes = append(batchJob.Spec.Template.Spec.Volumes, utils.AttachEmptyDirVolumes(p.Volumes, executor.TLSMounts(p.Backend)...)...)
 		batchJob.Labels[job.K8upExclusive] = "true"
 
 		if batchJob.Spec.Template.Spec.ServiceAccountName == "" {
@@ -63,6 +67,7 @@ func (p *PruneExecutor) Execute(ctx context.Context) error {
 	}
 
 	p.SetStarted(ctx, "the job '%v/%v' was created", batchJob.Namespace, batchJob.Name)
+	p.Eventf(batchJob, corev1.EventTypeNormal, job.EventReasonJobCreated, job.EventActionCreate, "Created job '%s'", batchJob.Name)
 	return nil
 }
 
@@ -75,8 +80,11 @@ func (p *PruneExecutor) setupArgs() []string {
 	if len(p.prune.Spec.Retention.Tags) > 0 {
 		args = append(args, executor.BuildListArgs("--tag", p.prune.Spec.Retention.Tags)...)
 	}
-	if p.prune.Spec.Backend != nil {
-		args = append(args, utils.AppendTLSOptionsArgs(p.prune.Spec.Backend.TLSOptions)...)
+	if len(p.prune.Spec.Retention.Hostnames) > 0 {
+		args = append(args, executor.BuildListArgs("--host", p.prune.Spec.Retention.Hostnames)...)
+	}
+	if p.Backend != nil {
+		args = append(args, utils.AppendTLSOptionsArgs(p.Backend.TLSOptions)...)
 	}
 
 	return args
@@ -108,7 +116,7 @@ func (p *PruneExecutor) setupEnvVars(ctx context.Context, prune *k8upv1.Prune) [
 	if prune.Spec.Retention.KeepDaily > 0 {
 		vars.SetString("KEEP_DAILY", strconv.Itoa(prune.Spec.Retention.KeepDaily))
 	} else {
-		vars.SetString("KEEP_DAILY", "14")
+		vars.SetString("KEEP_DAILY", strconv.Itoa(k8upv1.DefaultKeepDaily))
 	}
 
 	if prune.Spec.Retention.KeepWeekly > 0 {
@@ -127,11 +135,28 @@ func (p *PruneExecutor) setupEnvVars(ctx context.Context, prune *k8upv1.Prune) [
 		vars.SetString("KEEP_TAGS", strings.Join(prune.Spec.Retention.KeepTags, ","))
 	}
 
-	if prune.Spec.Backend != nil {
-		for key, value := range prune.Spec.Backend.GetCredentialEnv() {
+	for name, value := range map[string]string{
+		"KEEP_WITHIN":         prune.Spec.Retention.KeepWithin,
+		"KEEP_WITHIN_HOURLY":  prune.Spec.Retention.KeepWithinHourly,
+		"KEEP_WITHIN_DAILY":   prune.Spec.Retention.KeepWithinDaily,
+		"KEEP_WITHIN_WEEKLY":  prune.Spec.Retention.KeepWithinWeekly,
+		"KEEP_WITHIN_MONTHLY": prune.Spec.Retention.KeepWithinMonthly,
+		"KEEP_WITHIN_YEARLY":  prune.Spec.Retention.KeepWithinYearly,
+	} {
+		if value != "" {
+			vars.SetString(name, value)
+		}
+	}
+
+	if prune.Spec.Retention.GroupBy != "" {
+		vars.SetString("GROUP_BY", prune.Spec.Retention.GroupBy)
+	}
+
+	if p.Backend != nil {
+		for key, value := range p.Backend.GetCredentialEnv() {
 			vars.SetEnvVarSource(key, value)
 		}
-		vars.SetString(cfg.ResticRepositoryEnvName, prune.Spec.Backend.String())
+		vars.SetString(cfg.ResticRepositoryEnvName, p.Backend.String())
 	}
 
 	vars.SetString("PROM_URL", cfg.Config.PromURL)
@@ -140,7 +165,9 @@ func (p *PruneExecutor) setupEnvVars(ctx context.Context, prune *k8upv1.Prune) [
 		vars.SetString("BACKUP_SKIP_SNAPSHOT_SYNC", "true")
 	}
 
-	err := vars.Merge(executor.DefaultEnv(p.Obj.GetNamespace()))
+	p.SetRepositoryEnv(&vars, p.Backend)
+
+	err := vars.Merge(executor.DefaultEnv(p.Obj.GetNamespace(), p.Backend))
 	if err != nil {
 		log.Error(err, "error while merging the environment variables", "name", p.Obj.GetName(), "namespace", p.Obj.GetNamespace())
 	}
@@ -150,9 +177,11 @@ func (p *PruneExecutor) setupEnvVars(ctx context.Context, prune *k8upv1.Prune) [
 
 func (p *PruneExecutor) attachTLSVolumeMounts() []corev1.VolumeMount {
 	var tlsVolumeMounts []corev1.VolumeMount
-	if p.prune.Spec.Backend != nil && !utils.ZeroLen(p.prune.Spec.Backend.VolumeMounts) {
-		tlsVolumeMounts = append(tlsVolumeMounts, *p.prune.Spec.Backend.VolumeMounts...)
+	if p.Backend != nil && !utils.ZeroLen(p.Backend.VolumeMounts) {
+		tlsVolumeMounts = append(tlsVolumeMounts, *p.Backend.VolumeMounts...)
 	}
 
+	tlsVolumeMounts = append(tlsVolumeMounts, utils.TLSVolumeMounts(executor.TLSMounts(p.Backend)...)...)
+
 	return utils.AttachEmptyDirVolumeMounts(cfg.Config.PodVarDir, &tlsVolumeMounts)
 }
@@ -0,0 +1,51 @@
+package prunecontroller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/cfg"
+	"github.com/k8up-io/k8up/v2/operator/job"
+)
+
+func TestPruneExecutor_setupArgs(t *testing.T) {
+	obj := &k8upv1.Prune{
+		ObjectMeta: metav1.ObjectMeta{Name: "daily", Namespace: "app"},
+		Spec: k8upv1.PruneSpec{Retention: k8upv1.RetentionPolicy{
+			Tags:      []string{"daily"},
+			Hostnames: []string{"app", "db"},
+		}},
+	}
+	e := NewPruneExecutor(job.Config{Obj: obj})
+
+	assert.Equal(t, []string{"-varDir", cfg.Config.PodVarDir, "-prune", "--tag", "daily", "--host", "app", "--host", "db"}, e.setupArgs())
+}
+
+func TestPruneExecutor_setupEnvVars(t *testing.T) {
+	obj := &k8upv1.Prune{
+		ObjectMeta: metav1.ObjectMeta{Name: "daily", Namespace: "app"},
+		Spec: k8upv1.PruneSpec{Retention: k8upv1.RetentionPolicy{
+			KeepLast:        5,
+			KeepWithin:      "7d",
+			KeepWithinDaily: "1m",
+			GroupBy:         "host,tags",
+		}},
+	}
+	e := NewPruneExecutor(job.Config{Obj: obj})
+
+	env := map[string]string{}
+	for _, envVar := range e.setupEnvVars(context.TODO(), obj) {
+		env[envVar.Name] = envVar.Value
+	}
+
+	assert.Equal(t, "5", env["KEEP_LAST"])
+	assert.Equal(t, "14", env["KEEP_DAILY"])
+	assert.Equal(t, "7d", env["KEEP_WITHIN"])
+	assert.Equal(t, "1m", env["KEEP_WITHIN_DAILY"])
+	assert.Equal(t, "host,tags", env["GROUP_BY"])
+	assert.NotContains(t, env, "KEEP_WITHIN_HOURLY")
+}
@@ -16,7 +16,8 @@ import (
 func SetupWithManager(mgr ctrl.Manager) error {
 	name := "prune.k8up.io"
 	r := reconciler.NewReconciler[*k8upv1.Prune, *k8upv1.PruneList](mgr.GetClient(), &PruneReconciler{
-		Kube: mgr.GetClient(),
+		Kube:     mgr.GetClient(),
+		Recorder: mgr.GetEventRecorder(name),
 	})
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&k8upv1.Prune{}).
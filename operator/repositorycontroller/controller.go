@@ -0,0 +1,122 @@
+package repositorycontroller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/job"
+)
+
+// RepositoryReconciler reconciles a Repository object
+type RepositoryReconciler struct {
+	Kube     client.Client
+	Recorder events.EventRecorder
+}
+
+func (r *RepositoryReconciler) NewObject() *k8upv1.Repository {
+	return &k8upv1.Repository{}
+}
+
+func (r *RepositoryReconciler) NewObjectList() *k8upv1.RepositoryList {
+	return &k8upv1.RepositoryList{}
+}
+
+func (r *RepositoryReconciler) Provision(ctx context.Context, obj *k8upv1.Repository) (controllerruntime.Result, error) {
+	log := controllerruntime.LoggerFrom(ctx)
+
+	if obj.Spec.Backend == nil {
+		return controllerruntime.Result{}, fmt.Errorf("repository %q has no backend configured", obj.Name)
+	}
+
+	config := job.NewConfig(r.Kube, obj, obj.Spec.Backend.String())
+	config.Recorder = r.Recorder
+	executor := NewRepositoryExecutor(config)
+
+	result := controllerruntime.Result{}
+	if !obj.IsInitialized() {
+		// The backend may have changed to a repository that hasn't been initialized yet.
+		obj.Status.Initialized = false
+		batchJob := &batchv1.Job{}
+		err := r.Kube.Get(ctx, types.NamespacedName{Namespace: obj.Namespace, Name: executor.jobName()}, batchJob)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return controllerruntime.Result{}, fmt.Errorf("unable to get job: %w", err)
+		}
+		switch {
+		case err == nil && batchJob.Labels[k8upv1.LabelRepositoryHash] != job.Sha256Hash(config.Repository):
+			// Remove the job of the previous backend so that the current one is initialized with the next reconciliation.
+			log.Info("Backend of repository changed, initializing it again")
+			if err := r.Kube.Delete(ctx, batchJob, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+				return controllerruntime.Result{}, fmt.Errorf("unable to delete job of previous backend: %w", err)
+			}
+			result.RequeueAfter = 5 * time.Second
+		case apierrors.IsNotFound(err):
+			log.Info("Initializing repository")
+			if err := executor.Execute(ctx); err != nil {
+				return controllerruntime.Result{}, err
+			}
+			result.RequeueAfter = 5 * time.Second
+		case job.HasSucceeded(batchJob.Status.Conditions):
+			config.UpdateStatus(ctx, batchJob)
+			obj.Status.Initialized = true
+			obj.Status.InitializedRepository = config.Repository
+		case job.HasFailed(batchJob.Status.Conditions):
+			// Remove the failed job so that the initialization is retried with the next reconciliation.
+			config.UpdateStatus(ctx, batchJob)
+			log.Info("Repository initialization failed, retrying later")
+			if err := r.Kube.Delete(ctx, batchJob, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+				return controllerruntime.Result{}, fmt.Errorf("unable to delete failed job: %w", err)
+			}
+			result.RequeueAfter = time.Minute
+		default:
+			result.RequeueAfter = 5 * time.Second
+		}
+	}
+
+	if err := r.updateStatistics(ctx, obj); err != nil {
+		return controllerruntime.Result{}, err
+	}
+	return result, r.Kube.Status().Update(ctx, obj)
+}
+
+func (r *RepositoryReconciler) Deprovision(_ context.Context, _ *k8upv1.Repository) (controllerruntime.Result, error) {
+	return controllerruntime.Result{}, nil
+}
+
+// updateStatistics sets the snapshot count and the time of the last successful check of the given repository.
+func (r *RepositoryReconciler) updateStatistics(ctx context.Context, obj *k8upv1.Repository) error {
+	snapshots := &k8upv1.SnapshotList{}
+	repositoryHash := job.Sha256Hash(obj.Spec.Backend.String())
+	if err := r.Kube.List(ctx, snapshots, client.InNamespace(obj.Namespace), client.MatchingLabels{k8upv1.LabelRepositoryHash: repositoryHash}); err != nil {
+		return fmt.Errorf("cannot list snapshots: %w", err)
+	}
+	obj.Status.SnapshotCount = len(snapshots.Items)
+
+	checks := &k8upv1.CheckList{}
+	if err := r.Kube.List(ctx, checks, client.InNamespace(obj.Namespace)); err != nil {
+		return fmt.Errorf("cannot list checks: %w", err)
+	}
+	for _, check := range checks.Items {
+		if check.Spec.RepositoryRef == nil || check.Spec.RepositoryRef.Name != obj.Name {
+			continue
+		}
+		completed := meta.FindStatusCondition(check.Status.Conditions, k8upv1.ConditionCompleted.String())
+		if completed == nil || completed.Reason != k8upv1.ReasonSucceeded.String() {
+			continue
+		}
+		if obj.Status.LastCheckTime == nil || obj.Status.LastCheckTime.Before(&completed.LastTransitionTime) {
+			obj.Status.LastCheckTime = completed.LastTransitionTime.DeepCopy()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package repositorycontroller
+
+import (
+	"context"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/cfg"
+	"github.com/k8up-io/k8up/v2/operator/executor"
+	"github.com/k8up-io/k8up/v2/operator/job"
+	"github.com/k8up-io/k8up/v2/operator/utils"
+)
+
+// RepositoryExecutor will execute the batch.job that initializes a repository.
+type RepositoryExecutor struct {
+	executor.Generic
+	repository *k8upv1.Repository
+	// spec is the RunnableSpec that results from applying the repository, so that it can be treated like any other job.
+	spec *k8upv1.RunnableSpec
+}
+
+// NewRepositoryExecutor will return a new executor for repository init jobs.
+func NewRepositoryExecutor(config job.Config) *RepositoryExecutor {
+	repository := config.Obj.(*k8upv1.Repository)
+	spec := &k8upv1.RunnableSpec{}
+	repository.ApplyTo(spec)
+	spec.Backend = spec.Backend.WithCredentialsFor(k8upv1.CredentialOperationMaintenance)
+	return &RepositoryExecutor{
+		Generic:    executor.Generic{Config: config},
+		repository: repository,
+		spec:       spec,
+	}
+}
+
+// Execute creates the actual batch.job on the k8s api.
+func (r *RepositoryExecutor) Execute(ctx context.Context) error {
+	batchJob := &batchv1.Job{}
+	batchJob.Name = r.jobName()
+	batchJob.Namespace = r.repository.Namespace
+
+	_, err := controllerruntime.CreateOrUpdate(ctx, r.Client, batchJob, func() error {
+		mutateErr := job.MutateBatchJob(ctx, batchJob, r.repository, r.Config, r.Client)
+		if mutateErr != nil {
+			return mutateErr
+		}
+
+		batchJob.Spec.Template.Spec.Containers[0].Env = append(batchJob.Spec.Template.Spec.Containers[0].Env, r.setupEnvVars(ctx)...)
+		r.spec.AppendEnvFromToContainer(&batchJob.Spec.Template.Spec.Containers[0])
+		batchJob.Spec.Template.Spec.Containers[0].VolumeMounts = append(batchJob.Spec.Template.Spec.Containers[0].VolumeMounts, r.attachTLSVolumeMounts()...)
+		executor.AttachBackend(&batchJob.Spec.Template, r.spec.Backend)
+		if err := executor.AttachServiceAccount(ctx, r.Client, &batchJob.Spec.Template.Spec, batchJob.Namespace, r.spec.Backend); err != nil {
+			return err
+		}
+		batchJob.Spec.Template.Spec.Volumes = append(batchJob.Spec.Template.Spec.Volumes, utils.AttachEmptyDirVolumes(r.spec.Volumes, executor.TLSMounts(r.spec.Backend)...)...)
+
+		batchJob.Spec.Template.Spec.Containers[0].Args = r.setupArgs()
+
+		return nil
+	})
+	if err != nil {
+		r.SetConditionFalseWithMessage(ctx, k8upv1.ConditionReady, k8upv1.ReasonCreationFailed, "could not create job: %v", err)
+		return err
+	}
+	r.SetStarted(ctx, "the job '%v/%v' was created", batchJob.Namespace, batchJob.Name)
+	r.Eventf(batchJob, corev1.EventTypeNormal, job.EventReasonJobCreated, job.EventActionCreate, "Created job '%s'", batchJob.Name)
+	return nil
+}
+
+func (r *RepositoryExecutor) jobName() string {
+	return k8upv1.RepositoryType.String() + "-" + r.repository.Name
+}
+
+func (r *RepositoryExecutor) setupArgs() []string {
+	args := []string{"-varDir", cfg.Config.PodVarDir, "-init"}
+	args = append(args, utils.AppendTLSOptionsArgs(r.spec.Backend.TLSOptions)...)
+	return args
+}
+
+func (r *RepositoryExecutor) setupEnvVars(ctx context.Context) []corev1.EnvVar {
+	log := controllerruntime.LoggerFrom(ctx)
+	vars := executor.NewEnvVarConverter()
+
+	for key, value := range r.spec.Backend.GetCredentialEnv() {
+		vars.SetEnvVarSource(key, value)
+	}
+	vars.SetString(cfg.ResticRepositoryEnvName, r.spec.Backend.String())
+	executor.SetResticOptions(&vars, r.repository.GetResticOptions(), r.spec.Backend)
+
+	err := vars.Merge(executor.DefaultEnv(r.Obj.GetNamespace(), r.spec.Backend))
+	if err != nil {
+		log.Error(err, "error while merging the environment variables", "name", r.Obj.GetName(), "namespace", r.Obj.GetNamespace())
+	}
+
+	return vars.Convert()
+}
+
+func (r *RepositoryExecutor) attachTLSVolumeMounts() []corev1.VolumeMount {
+	var tlsVolumeMounts []corev1.VolumeMount
+	if !utils.ZeroLen(r.spec.Backend.VolumeMounts) {
+		tlsVolumeMounts = append(tlsVolumeMounts, *r.spec.Backend.VolumeMounts...)
+	}
+
+	tlsVolumeMounts = append(tlsVolumeMounts, utils.TLSVolumeMounts(executor.TLSMounts(r.spec.Backend)...)...)
+
+	return utils.AttachEmptyDirVolumeMounts(cfg.Config.PodVarDir, &tlsVolumeMounts)
+}
@@ -0,0 +1,70 @@
+package repositorycontroller
+
+import (
+	"context"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/reconciler"
+)
+
+// +kubebuilder:rbac:groups=k8up.io,resources=repositories,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=k8up.io,resources=repositories/status;repositories/finalizers,verbs=get;update;patch
+// +kubebuilder:rbac:groups=k8up.io,resources=snapshots;checks,verbs=get;list;watch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+
+// SetupWithManager configures the reconciler.
+func SetupWithManager(mgr ctrl.Manager) error {
+	name := "repository.k8up.io"
+	kube := mgr.GetClient()
+	r := reconciler.NewReconciler[*k8upv1.Repository, *k8upv1.RepositoryList](kube, &RepositoryReconciler{
+		Kube:     kube,
+		Recorder: mgr.GetEventRecorder(name),
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&k8upv1.Repository{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Owns(&batchv1.Job{}).
+		Watches(&k8upv1.Snapshot{}, handler.EnqueueRequestsFromMapFunc(snapshotToRepositories(kube))).
+		Watches(&k8upv1.Check{}, handler.EnqueueRequestsFromMapFunc(checkToRepository)).
+		Named(name).
+		Complete(r)
+}
+
+// snapshotToRepositories enqueues the Repositories in the Snapshot's namespace that have the same restic repository.
+func snapshotToRepositories(kube client.Client) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		snapshot, ok := obj.(*k8upv1.Snapshot)
+		if !ok || snapshot.Spec.Repository == nil {
+			return nil
+		}
+		repositories := &k8upv1.RepositoryList{}
+		if err := kube.List(ctx, repositories, client.InNamespace(snapshot.Namespace)); err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "cannot list repositories")
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, repository := range repositories.Items {
+			if repository.Spec.Backend != nil && repository.Spec.Backend.String() == *snapshot.Spec.Repository {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: repository.Namespace, Name: repository.Name}})
+			}
+		}
+		return requests
+	}
+}
+
+// checkToRepository enqueues the Repository the Check refers to.
+func checkToRepository(_ context.Context, obj client.Object) []reconcile.Request {
+	check, ok := obj.(*k8upv1.Check)
+	if !ok || check.Spec.RepositoryRef == nil {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: check.Namespace, Name: check.Spec.RepositoryRef.Name}}}
+}
@@ -0,0 +1,56 @@
+package repositorykeycontroller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+)
+
+func TestRepositoryKeyReconciler_updateResults(t *testing.T) {
+	obj := &k8upv1.RepositoryKey{ObjectMeta: metav1.ObjectMeta{Name: "rotate", Namespace: "app"}}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "repositorykey-rotate-abcde", Namespace: "app", Labels: map[string]string{batchv1.JobNameLabel: "repositorykey-rotate"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "repositorykey"}}},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  "repositorykey",
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{FinishedAt: metav1.Now(), Message: `{"keyID":"4a1b2c3d","removedKeyID":"5e6f7a8b"}`}},
+		}}},
+	}
+	r := &RepositoryKeyReconciler{Kube: newFakeClient(t, obj, pod)}
+
+	require.NoError(t, r.updateResults(context.TODO(), obj, types.NamespacedName{Namespace: "app", Name: "repositorykey-rotate"}))
+
+	assert.Equal(t, k8upv1.RepositoryKeyResults{KeyID: "4a1b2c3d", RemovedKeyID: "5e6f7a8b"}, obj.Status.RepositoryKeyResults)
+	stored := &k8upv1.RepositoryKey{}
+	require.NoError(t, r.Kube.Get(context.TODO(), client.ObjectKeyFromObject(obj), stored))
+	assert.Equal(t, "4a1b2c3d", stored.Status.KeyID)
+}
+
+func TestAttachNewPassword(t *testing.T) {
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "repositorykey"}}}
+	attachNewPassword(podSpec, &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "repo"}, Key: "new-password"})
+
+	require.Len(t, podSpec.Volumes, 1)
+	assert.Equal(t, "repo", podSpec.Volumes[0].Secret.SecretName)
+	assert.Equal(t, []corev1.KeyToPath{{Key: "new-password", Path: "password"}}, podSpec.Volumes[0].Secret.Items)
+	assert.Equal(t, []corev1.VolumeMount{{Name: "k8up-new-password", MountPath: "/etc/k8up/new-password", ReadOnly: true}}, podSpec.Containers[0].VolumeMounts)
+}
+
+func newFakeClient(t *testing.T, objects ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, k8upv1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).WithStatusSubresource(&k8upv1.RepositoryKey{}).Build()
+}
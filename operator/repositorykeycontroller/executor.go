@@ -0,0 +1,148 @@
+package repositorykeycontroller
+
+import (
+	"context"
+	"path"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/cfg"
+	"github.com/k8up-io/k8up/v2/operator/executor"
+	"github.com/k8up-io/k8up/v2/operator/job"
+	"github.com/k8up-io/k8up/v2/operator/utils"
+)
+
+const (
+	newPasswordVolumeName = "k8up-new-password"
+	newPasswordMountPath  = "/etc/k8up/new-password"
+	newPasswordFileName   = "password"
+)
+
+// RepositoryKeyExecutor will execute the batch.job that rotates the password of a repository.
+type RepositoryKeyExecutor struct {
+	executor.Generic
+	repositoryKey *k8upv1.RepositoryKey
+}
+
+// NewRepositoryKeyExecutor will return a new executor for repository key jobs.
+func NewRepositoryKeyExecutor(config job.Config) *RepositoryKeyExecutor {
+	return &RepositoryKeyExecutor{
+		Generic:       executor.Generic{Config: config},
+		repositoryKey: config.Obj.(*k8upv1.RepositoryKey),
+	}
+}
+
+// Exclusive should return true for jobs that can't run while other jobs run.
+func (*RepositoryKeyExecutor) Exclusive() bool {
+	return true
+}
+
+// Execute creates the actual batch.job on the k8s api.
+func (r *RepositoryKeyExecutor) Execute(ctx context.Context) error {
+	batchJob := &batchv1.Job{}
+	batchJob.Name = r.jobName()
+	batchJob.Namespace = r.repositoryKey.Namespace
+
+	_, err := controllerruntime.CreateOrUpdate(ctx, r.Client, batchJob, func() error {
+		mutateErr := job.MutateBatchJob(ctx, batchJob, r.repositoryKey, r.Config, r.Client)
+		if mutateErr != nil {
+			return mutateErr
+		}
+
+		batchJob.Spec.Template.Spec.Containers[0].Env = append(batchJob.Spec.Template.Spec.Containers[0].Env, r.setupEnvVars(ctx)...)
+		r.repositoryKey.Spec.AppendEnvFromToContainer(&batchJob.Spec.Template.Spec.Containers[0])
+		batchJob.Spec.Template.Spec.Containers[0].VolumeMounts = append(batchJob.Spec.Template.Spec.Containers[0].VolumeMounts, r.attachTLSVolumeMounts()...)
+		executor.AttachBackend(&batchJob.Spec.Template, r.Backend)
+		if err := executor.AttachServiceAccount(ctx, r.Client, &batchJob.Spec.Template.Spec, batchJob.Namespace, r.Backend); err != nil {
+			return err
+		}
+		batchJob.Spec.Template.Spec.Volumes = append(batchJob.Spec.Template.Spec.Volumes, utils.AttachEmptyDirVolumes(r.Volumes, executor.TLSMounts(r.Backend)...)...)
+		attachNewPassword(&batchJob.Spec.Template.Spec, r.repositoryKey.Spec.NewPasswordSecretRef)
+		batchJob.Labels[job.K8upExclusive] = "true"
+
+		batchJob.Spec.Template.Spec.Containers[0].Args = r.setupArgs()
+
+		return nil
+	})
+	if err != nil {
+		r.SetConditionFalseWithMessage(ctx, k8upv1.ConditionReady, k8upv1.ReasonCreationFailed, "could not create job: %v", err)
+		return err
+	}
+	r.SetStarted(ctx, "the job '%v/%v' was created", batchJob.Namespace, batchJob.Name)
+	r.Eventf(batchJob, corev1.EventTypeNormal, job.EventReasonJobCreated, job.EventActionCreate, "Created job '%s'", batchJob.Name)
+	return nil
+}
+
+func (r *RepositoryKeyExecutor) jobName() string {
+	return k8upv1.RepositoryKeyType.String() + "-" + r.repositoryKey.Name
+}
+
+func (r *RepositoryKeyExecutor) setupArgs() []string {
+	args := []string{"-varDir", cfg.Config.PodVarDir, "-rotateKey", "-newPasswordFile", path.Join(newPasswordMountPath, newPasswordFileName)}
+	if r.Backend != nil {
+		args = append(args, utils.AppendTLSOptionsArgs(r.Backend.TLSOptions)...)
+	}
+	return args
+}
+
+func (r *RepositoryKeyExecutor) setupEnvVars(ctx context.Context) []corev1.EnvVar {
+	log := controllerruntime.LoggerFrom(ctx)
+	vars := executor.NewEnvVarConverter()
+
+	if r.Backend != nil {
+		for key, value := range r.Backend.GetCredentialEnv() {
+			vars.SetEnvVarSource(key, value)
+		}
+		vars.SetString(cfg.ResticRepositoryEnvName, r.Backend.String())
+	}
+
+	r.SetRepositoryEnv(&vars, r.Backend)
+
+	err := vars.Merge(executor.DefaultEnv(r.Obj.GetNamespace(), r.Backend))
+	if err != nil {
+		log.Error(err, "error while merging the environment variables", "name", r.Obj.GetName(), "namespace", r.Obj.GetNamespace())
+	}
+
+	return vars.Convert()
+}
+
+func (r *RepositoryKeyExecutor) cleanupOldRepositoryKeys(ctx context.Context, repositoryKey *k8upv1.RepositoryKey) {
+	r.CleanupOldResources(ctx, &k8upv1.RepositoryKeyList{}, repositoryKey)
+}
+
+func (r *RepositoryKeyExecutor) attachTLSVolumeMounts() []corev1.VolumeMount {
+	var tlsVolumeMounts []corev1.VolumeMount
+	if r.Backend != nil && !utils.ZeroLen(r.Backend.VolumeMounts) {
+		tlsVolumeMounts = append(tlsVolumeMounts, *r.Backend.VolumeMounts...)
+	}
+
+	tlsVolumeMounts = append(tlsVolumeMounts, utils.TLSVolumeMounts(executor.TLSMounts(r.Backend)...)...)
+
+	return utils.AttachEmptyDirVolumeMounts(cfg.Config.PodVarDir, &tlsVolumeMounts)
+}
+
+// attachNewPassword mounts the new password into the first container of the pod spec, restic reads it from the file.
+func attachNewPassword(podSpec *corev1.PodSpec, ref *corev1.SecretKeySelector) {
+	if ref == nil {
+		return
+	}
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: newPasswordVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName:  ref.Name,
+				Items:       []corev1.KeyToPath{{Key: ref.Key, Path: newPasswordFileName}},
+				DefaultMode: ptr.To(int32(0444)),
+			},
+		},
+	})
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      newPasswordVolumeName,
+		MountPath: newPasswordMountPath,
+		ReadOnly:  true,
+	})
+}
@@ -0,0 +1,30 @@
+package repositorykeycontroller
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/reconciler"
+)
+
+// +kubebuilder:rbac:groups=k8up.io,resources=repositorykeys,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=k8up.io,resources=repositorykeys/status;repositorykeys/finalizers,verbs=get;update;patch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=list;get;watch
+
+// SetupWithManager configures the reconciler.
+func SetupWithManager(mgr ctrl.Manager) error {
+	name := "repositorykey.k8up.io"
+	r := reconciler.NewReconciler[*k8upv1.RepositoryKey, *k8upv1.RepositoryKeyList](mgr.GetClient(), &RepositoryKeyReconciler{
+		Kube:     mgr.GetClient(),
+		Recorder: mgr.GetEventRecorder(name),
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&k8upv1.RepositoryKey{}).
+		Owns(&batchv1.Job{}).
+		Named(name).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Complete(r)
+}
@@ -5,17 +5,20 @@ import (
 	"time"
 
 	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
-	"github.com/k8up-io/k8up/v2/operator/cfg"
 	"github.com/k8up-io/k8up/v2/operator/job"
 	"github.com/k8up-io/k8up/v2/operator/locker"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // RestoreReconciler reconciles a Restore object
 type RestoreReconciler struct {
-	Kube client.Client
+	Kube     client.Client
+	Recorder events.EventRecorder
 }
 
 func (r *RestoreReconciler) NewObject() *k8upv1.Restore {
@@ -29,18 +32,44 @@ func (r *RestoreReconciler) NewObjectList() *k8upv1.RestoreList {
 func (r *RestoreReconciler) Provision(ctx context.Context, obj *k8upv1.Restore) (controllerruntime.Result, error) {
 	log := controllerruntime.LoggerFrom(ctx)
 
-	repository := cfg.Config.GetGlobalRepository()
-	if obj.Spec.Backend != nil {
-		repository = obj.Spec.Backend.String()
+	spec := obj.Spec.RunnableSpec.DeepCopy()
+	snapshotID, snapshotRepository, err := resolveSnapshotRef(ctx, r.Kube, obj, spec)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return controllerruntime.Result{}, err
+		}
+		if !obj.Status.HasStarted() && !obj.Status.HasFinished() {
+			// There's nothing to wait for, a restore has to refer to an existing Snapshot.
+			log.Info("Snapshot of restore not found", "snapshot", obj.Spec.SnapshotRef.Name)
+			config := job.NewConfig(r.Kube, obj, "")
+			config.Recorder = r.Recorder
+			config.Eventf(nil, corev1.EventTypeWarning, k8upv1.ReasonSnapshotNotFound.String(), job.EventActionCreate, "Snapshot %q not found", obj.Spec.SnapshotRef.Name)
+			config.SetConditionFalseWithMessage(ctx, k8upv1.ConditionReady, k8upv1.ReasonSnapshotNotFound, "snapshot %q not found", obj.Spec.SnapshotRef.Name)
+			return controllerruntime.Result{}, nil
+		}
+		// The Snapshot may have been pruned after the restore has been started.
 	}
-	config := job.NewConfig(r.Kube, obj, repository)
+
+	config, err := job.NewRepositoryConfig(ctx, r.Kube, obj, spec, k8upv1.CredentialOperationBackup)
+	if err != nil {
+		return controllerruntime.Result{}, err
+	}
+	config.Recorder = r.Recorder
 	executor := NewRestoreExecutor(config)
+	if snapshotID != "" {
+		executor.snapshot = snapshotID
+	}
+	if executor.restoreMethod != nil && executor.restoreMethod.S3 != nil {
+		if err := job.ApplyClusterRestoreS3(ctx, r.Kube, config.ClusterRepository, obj, executor.restoreMethod.S3); err != nil {
+			return controllerruntime.Result{}, err
+		}
+	}
 
 	jobKey := types.NamespacedName{
 		Namespace: obj.GetNamespace(),
 		Name:      executor.jobName(),
 	}
-	if err := job.ReconcileJobStatus(ctx, jobKey, r.Kube, obj); err != nil {
+	if err := config.ReconcileJobStatus(ctx, jobKey); err != nil {
 		return controllerruntime.Result{}, err
 	}
 
@@ -54,10 +83,28 @@ func (r *RestoreReconciler) Provision(ctx context.Context, obj *k8upv1.Restore)
 		return controllerruntime.Result{}, nil
 	}
 
-	lock := locker.GetForRepository(r.Kube, repository)
+	if snapshotRepository != "" && snapshotRepository != config.Repository {
+		log.Info("Not creating restore job, the repository of the snapshot isn't used", "snapshotRepository", snapshotRepository, "repository", config.Repository)
+		if configuresRepository(&obj.Spec.RunnableSpec) {
+			config.SetConditionFalseWithMessage(ctx, k8upv1.ConditionReady, k8upv1.ReasonSnapshotRepositoryMismatch,
+				"snapshot %q belongs to the repository %q, but the restore uses %q", obj.Spec.SnapshotRef.Name, snapshotRepository, config.Repository)
+			return controllerruntime.Result{}, nil
+		}
+		// Only S3 backends are derived from the Snapshot, the others have to come from a Repository or the ClusterRepository.
+		config.SetConditionFalseWithMessage(ctx, k8upv1.ConditionReady, k8upv1.ReasonCreationFailed,
+			"cannot derive the backend of the snapshot's repository %q, configure the backend or repositoryRef of the restore", snapshotRepository)
+		return controllerruntime.Result{}, nil
+	}
+
+	if !config.RepositoryReady(ctx) {
+		return controllerruntime.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	lock := locker.GetForRepository(r.Kube, config.Repository)
 	didRun, err := lock.TryRun(ctx, config, executor.GetConcurrencyLimit(), executor.Execute)
 	if !didRun && err == nil {
 		log.Info("Skipping job due to exclusivity or concurrency limit")
+		config.Eventf(nil, corev1.EventTypeNormal, job.EventReasonWaiting, job.EventActionWait, "Waiting for other jobs of repository to finish or for the concurrency limit")
 	}
 	return controllerruntime.Result{RequeueAfter: time.Second * 30}, err
 }
@@ -8,6 +8,8 @@ import (
 
 	"github.com/stretchr/testify/suite"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -36,6 +38,24 @@ func (ts *RestoreTestSuite) TestReconciliation() {
 	ts.expectAJobEventually()
 }
 
+func (ts *RestoreTestSuite) TestReconciliation_GivenMissingSnapshotRef_ThenExpectNotReady() {
+	ts.GivenRestore = NewRestoreResource(ts.RestoreName, ts.NS)
+	ts.GivenRestore.Spec.SnapshotRef = &corev1.LocalObjectReference{Name: "missing"}
+	ts.EnsureResources(ts.GivenRestore)
+
+	result := ts.whenReconcile()
+
+	ts.Assert().Zero(result.RequeueAfter)
+	ready := meta.FindStatusCondition(ts.GivenRestore.Status.Conditions, k8upv1.ConditionReady.String())
+	ts.Require().NotNil(ready)
+	ts.Assert().Equal(metav1.ConditionFalse, ready.Status)
+	ts.Assert().Equal(k8upv1.ReasonSnapshotNotFound.String(), ready.Reason)
+
+	jobs := new(batchv1.JobList)
+	ts.Require().NoError(ts.Client.List(ts.Ctx, jobs, client.InNamespace(ts.NS)))
+	ts.Assert().Empty(jobs.Items)
+}
+
 func (ts *RestoreTestSuite) BeforeTest(suiteName, testName string) {
 	ts.RestoreName = "restore-integration-test"
 }
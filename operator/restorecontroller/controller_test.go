@@ -0,0 +1,85 @@
+package restorecontroller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+)
+
+func TestRestoreReconciler_Provision_GivenSnapshotOfUnknownRepository_ThenExpectNotReady(t *testing.T) {
+	snapshot := &k8upv1.Snapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: "snapshot", Namespace: "app"},
+		Spec: k8upv1.SnapshotSpec{
+			ID:         ptr.To("162e7a85acbc14de93dad31a3699331cb32187ff0d7bd2227b7c4362a1d13a42"),
+			Repository: ptr.To("rest:https://rest.example.com/app"),
+		},
+	}
+	restore := &k8upv1.Restore{
+		ObjectMeta: metav1.ObjectMeta{Name: "restore", Namespace: "app"},
+		Spec: k8upv1.RestoreSpec{
+			SnapshotRef:   &corev1.LocalObjectReference{Name: "snapshot"},
+			RestoreMethod: &k8upv1.RestoreMethod{Folder: &k8upv1.FolderRestore{}},
+		},
+	}
+	c := newFakeClient(t, snapshot, restore, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}})
+	r := &RestoreReconciler{Kube: c}
+
+	result, err := r.Provision(context.TODO(), restore)
+	require.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter, "the restore can't run until it's changed")
+
+	ready := meta.FindStatusCondition(restore.Status.Conditions, k8upv1.ConditionReady.String())
+	require.NotNil(t, ready)
+	assert.Equal(t, metav1.ConditionFalse, ready.Status)
+	assert.Equal(t, k8upv1.ReasonCreationFailed.String(), ready.Reason)
+	assert.Equal(t, `cannot derive the backend of the snapshot's repository "rest:https://rest.example.com/app", configure the backend or repositoryRef of the restore`, ready.Message)
+
+	jobs := &batchv1.JobList{}
+	require.NoError(t, c.List(context.TODO(), jobs))
+	assert.Empty(t, jobs.Items)
+}
+
+func TestRestoreReconciler_Provision_GivenBackendOfOtherRepository_ThenExpectNotReady(t *testing.T) {
+	snapshot := &k8upv1.Snapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: "snapshot", Namespace: "app"},
+		Spec: k8upv1.SnapshotSpec{
+			ID:         ptr.To("162e7a85acbc14de93dad31a3699331cb32187ff0d7bd2227b7c4362a1d13a42"),
+			Repository: ptr.To("s3:http://minio:9000/backup"),
+		},
+	}
+	restore := &k8upv1.Restore{
+		ObjectMeta: metav1.ObjectMeta{Name: "restore", Namespace: "app"},
+		Spec: k8upv1.RestoreSpec{
+			RunnableSpec: k8upv1.RunnableSpec{
+				Backend: &k8upv1.Backend{S3: &k8upv1.S3Spec{Endpoint: "http://minio:9000", Bucket: "other"}},
+			},
+			SnapshotRef:   &corev1.LocalObjectReference{Name: "snapshot"},
+			RestoreMethod: &k8upv1.RestoreMethod{Folder: &k8upv1.FolderRestore{}},
+		},
+	}
+	c := newFakeClient(t, snapshot, restore, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}})
+	r := &RestoreReconciler{Kube: c}
+
+	result, err := r.Provision(context.TODO(), restore)
+	require.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter, "the restore can't run until it's changed")
+
+	ready := meta.FindStatusCondition(restore.Status.Conditions, k8upv1.ConditionReady.String())
+	require.NotNil(t, ready)
+	assert.Equal(t, metav1.ConditionFalse, ready.Status)
+	assert.Equal(t, k8upv1.ReasonSnapshotRepositoryMismatch.String(), ready.Reason)
+	assert.Equal(t, `snapshot "snapshot" belongs to the repository "s3:http://minio:9000/backup", but the restore uses "s3:http://minio:9000/other"`, ready.Message)
+
+	jobs := &batchv1.JobList{}
+	require.NoError(t, c.List(context.TODO(), jobs))
+	assert.Empty(t, jobs.Items)
+}
@@ -25,13 +25,20 @@ const (
 type RestoreExecutor struct {
 	executor.Generic
 	restore *k8upv1.Restore
+	// snapshot is the ID of the snapshot to restore, it's resolved from the Snapshot the restore refers to.
+	snapshot string
+	// restoreMethod is a copy of the restore's method, whose S3 target is completed with the ClusterRepository.
+	restoreMethod *k8upv1.RestoreMethod
 }
 
 // NewRestoreExecutor will return a new executor for Restore jobs.
 func NewRestoreExecutor(config job.Config) *RestoreExecutor {
+	restore := config.Obj.(*k8upv1.Restore)
 	return &RestoreExecutor{
-		Generic: executor.Generic{Config: config},
-		restore: config.Obj.(*k8upv1.Restore),
+		Generic:       executor.Generic{Config: config},
+		restore:       restore,
+		snapshot:      restore.Spec.Snapshot,
+		restoreMethod: restore.Spec.RestoreMethod.DeepCopy(),
 	}
 }
 
@@ -56,6 +63,7 @@ func (r *RestoreExecutor) Execute(ctx context.Context) error {
 	}
 
 	r.SetStarted(ctx, "the job '%v/%v' was created", restoreJob.Namespace, restoreJob.Name)
+	r.Eventf(restoreJob, corev1.EventTypeNormal, job.EventReasonJobCreated, job.EventActionCreate, "Created job '%s'", restoreJob.Name)
 
 	return nil
 }
@@ -77,11 +85,15 @@ func (r *RestoreExecutor) createRestoreObject(ctx context.Context, restore *k8up
 		batchJob.Spec.Template.Spec.Containers[0].Env = append(batchJob.Spec.Template.Spec.Containers[0].Env, r.setupEnvVars(ctx, restore)...)
 		restore.Spec.AppendEnvFromToContainer(&batchJob.Spec.Template.Spec.Containers[0])
 
-		volumes, volumeMounts := r.volumeConfig(restore)
+		volumes, volumeMounts := r.volumeConfig()
 		batchJob.Spec.Template.Spec.Volumes = append(batchJob.Spec.Template.Spec.Volumes, volumes...)
-		batchJob.Spec.Template.Spec.Volumes = append(batchJob.Spec.Template.Spec.Volumes, utils.AttachEmptyDirVolumes(r.restore.Spec.Volumes)...)
+		batchJob.Spec.Template.Spec.Volumes = append(batchJob.Spec.Template.Spec.Volumes, utils.AttachEmptyDirVolumes(r.Volumes, r.tlsMounts()...)...)
 		batchJob.Spec.Template.Spec.Containers[0].VolumeMounts = append(batchJob.Spec.Template.Spec.Containers[0].VolumeMounts, volumeMounts...)
 		batchJob.Spec.Template.Spec.Containers[0].VolumeMounts = append(batchJob.Spec.Template.Spec.Containers[0].VolumeMounts, r.attachTLSVolumeMounts()...)
+		executor.AttachBackend(&batchJob.Spec.Template, r.Backend)
+		if err := executor.AttachServiceAccount(ctx, r.Client, &batchJob.Spec.Template.Spec, batchJob.Namespace, r.Backend); err != nil {
+			return err
+		}
 
 		args, argsErr := r.setupArgs(restore)
 		batchJob.Spec.Template.Spec.Containers[0].Args = args
@@ -113,8 +125,8 @@ func (r *RestoreExecutor) setupArgs(restore *k8upv1.Restore) ([]string, error) {
 		args = append(args, "--delete")
 	}
 
-	if restore.Spec.Snapshot != "" {
-		args = append(args, "-restoreSnap", restore.Spec.Snapshot)
+	if r.snapshot != "" {
+		args = append(args, "-restoreSnap", r.snapshot)
 	}
 
 	if restore.Spec.RestoreTimeFilter != "" {
@@ -122,31 +134,31 @@ func (r *RestoreExecutor) setupArgs(restore *k8upv1.Restore) ([]string, error) {
 	}
 
 	switch {
-	case restore.Spec.RestoreMethod.Folder != nil:
+	case r.restoreMethod.Folder != nil:
 		args = append(args, "-restoreType", "folder")
-	case restore.Spec.RestoreMethod.S3 != nil:
+	case r.restoreMethod.S3 != nil:
 		args = append(args, "-restoreType", "s3")
 	default:
 		return nil, fmt.Errorf("undefined restore method (-restoreType) on '%v/%v'", restore.Namespace, restore.Name)
 	}
 
-	if r.restore.Spec.Backend != nil {
-		args = append(args, utils.AppendTLSOptionsArgs(r.restore.Spec.Backend.TLSOptions)...)
+	if r.Backend != nil {
+		args = append(args, utils.AppendTLSOptionsArgs(r.Backend.TLSOptions)...)
 	}
-	if r.restore.Spec.RestoreMethod != nil {
-		args = append(args, utils.AppendTLSOptionsArgs(r.restore.Spec.RestoreMethod.TLSOptions, certPrefixName)...)
+	if r.restoreMethod != nil {
+		args = append(args, utils.AppendTLSOptionsArgs(r.restoreMethod.TLSOptions, certPrefixName)...)
 	}
 
 	return args, nil
 }
 
-func (r *RestoreExecutor) volumeConfig(restore *k8upv1.Restore) ([]corev1.Volume, []corev1.VolumeMount) {
+func (r *RestoreExecutor) volumeConfig() ([]corev1.Volume, []corev1.VolumeMount) {
 	volumes := make([]corev1.Volume, 0)
-	if restore.Spec.RestoreMethod.S3 == nil {
+	if r.restoreMethod.S3 == nil {
 		addVolume := corev1.Volume{
-			Name: restore.Spec.RestoreMethod.Folder.ClaimName,
+			Name: r.restoreMethod.Folder.ClaimName,
 			VolumeSource: corev1.VolumeSource{
-				PersistentVolumeClaim: restore.Spec.RestoreMethod.Folder.PersistentVolumeClaimVolumeSource,
+				PersistentVolumeClaim: r.restoreMethod.Folder.PersistentVolumeClaimVolumeSource,
 			},
 		}
 		volumes = append(volumes, addVolume)
@@ -168,8 +180,8 @@ func (r *RestoreExecutor) setupEnvVars(ctx context.Context, restore *k8upv1.Rest
 	log := controllerruntime.LoggerFrom(ctx)
 	vars := executor.NewEnvVarConverter()
 
-	if restore.Spec.RestoreMethod.S3 != nil {
-		for key, value := range restore.Spec.RestoreMethod.S3.RestoreEnvVars() {
+	if r.restoreMethod.S3 != nil {
+		for key, value := range r.restoreMethod.S3.RestoreEnvVars() {
 			// FIXME(mw): ugly, due to EnvVarConverter()
 			if value.Value != "" {
 				vars.SetString(key, value.Value)
@@ -177,18 +189,23 @@ func (r *RestoreExecutor) setupEnvVars(ctx context.Context, restore *k8upv1.Rest
 				vars.SetEnvVarSource(key, value.ValueFrom)
 			}
 		}
+		if err := vars.Merge(executor.DefaultRestoreEnv()); err != nil {
+			log.Error(err, "error while merging the restore environment variables", "name", restore.GetName(), "namespace", restore.GetNamespace())
+		}
 	}
-	if restore.Spec.RestoreMethod.Folder != nil {
+	if r.restoreMethod.Folder != nil {
 		vars.SetString("RESTORE_DIR", restorePath)
 	}
-	if restore.Spec.Backend != nil {
-		for key, value := range restore.Spec.Backend.GetCredentialEnv() {
+	if r.Backend != nil {
+		for key, value := range r.Backend.GetCredentialEnv() {
 			vars.SetEnvVarSource(key, value)
 		}
-		vars.SetString(cfg.ResticRepositoryEnvName, restore.Spec.Backend.String())
+		vars.SetString(cfg.ResticRepositoryEnvName, r.Backend.String())
 	}
 
-	err := vars.Merge(executor.DefaultEnv(r.Obj.GetNamespace()))
+	r.SetRepositoryEnv(&vars, r.Backend)
+
+	err := vars.Merge(executor.DefaultEnv(r.Obj.GetNamespace(), r.Backend))
 	if err != nil {
 		log.Error(err, "error while merging the environment variables", "name", r.Obj.GetName(), "namespace", r.Obj.GetNamespace())
 	}
@@ -198,12 +215,22 @@ func (r *RestoreExecutor) setupEnvVars(ctx context.Context, restore *k8upv1.Rest
 
 func (r *RestoreExecutor) attachTLSVolumeMounts() []corev1.VolumeMount {
 	var tlsVolumeMounts []corev1.VolumeMount
-	if r.restore.Spec.Backend != nil && !utils.ZeroLen(r.restore.Spec.Backend.VolumeMounts) {
-		tlsVolumeMounts = append(tlsVolumeMounts, *r.restore.Spec.Backend.VolumeMounts...)
+	if r.Backend != nil && !utils.ZeroLen(r.Backend.VolumeMounts) {
+		tlsVolumeMounts = append(tlsVolumeMounts, *r.Backend.VolumeMounts...)
 	}
-	if r.restore.Spec.RestoreMethod != nil && !utils.ZeroLen(r.restore.Spec.RestoreMethod.VolumeMounts) {
-		tlsVolumeMounts = append(tlsVolumeMounts, *r.restore.Spec.RestoreMethod.VolumeMounts...)
+	if r.restoreMethod != nil && !utils.ZeroLen(r.restoreMethod.VolumeMounts) {
+		tlsVolumeMounts = append(tlsVolumeMounts, *r.restoreMethod.VolumeMounts...)
 	}
 
+	tlsVolumeMounts = append(tlsVolumeMounts, utils.TLSVolumeMounts(r.tlsMounts()...)...)
+
 	return utils.AttachEmptyDirVolumeMounts(cfg.Config.PodVarDir, &tlsVolumeMounts)
 }
+
+// tlsMounts returns the TLS options of the backend and of the restore method, the latter are mounted below the cert prefix.
+func (r *RestoreExecutor) tlsMounts() []utils.TLSMount {
+	if r.restoreMethod == nil {
+		return executor.TLSMounts(r.Backend)
+	}
+	return executor.TLSMounts(r.Backend, utils.TLSMount{Options: r.restoreMethod.TLSOptions, Prefix: certPrefixName})
+}
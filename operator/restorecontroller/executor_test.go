@@ -24,8 +24,9 @@ type VolumeMountExpectation struct {
 	Path     string
 }
 
-func newConfig() *job.Config {
-	cfg := job.NewConfig(nil, &k8upv1.Restore{}, "")
+func newConfig(restore *k8upv1.Restore) *job.Config {
+	cfg := job.NewConfig(nil, restore, "")
+	cfg.Backend = restore.Spec.Backend
 	return &cfg
 }
 
@@ -134,7 +135,7 @@ func TestRestore_setupEnvVars(t *testing.T) {
 
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
-			e := NewRestoreExecutor(*newConfig())
+			e := NewRestoreExecutor(*newConfig(tt.GivenResource))
 			envVars := e.setupEnvVars(context.TODO(), tt.GivenResource)
 
 			actualEnvVars, actualSecretKeyRefs := extractVarsAndSecretRefs(envVars)
@@ -186,8 +187,8 @@ func TestRestore_volumeConfig(t *testing.T) {
 
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
-			e := NewRestoreExecutor(*newConfig())
-			volumes, mounts := e.volumeConfig(tt.GivenResource)
+			e := NewRestoreExecutor(*newConfig(tt.GivenResource))
+			volumes, mounts := e.volumeConfig()
 
 			assertVolumes(t, tt.ExpectedPVC, volumes)
 			assertVolumeMounts(t, tt.ExpectedVolumeMount, mounts)
@@ -250,7 +251,7 @@ func TestRestore_args(t *testing.T) {
 
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
-			e := NewRestoreExecutor(*newConfig())
+			e := NewRestoreExecutor(*newConfig(tt.GivenResource))
 			args, err := e.setupArgs(tt.GivenResource)
 
 			require.NoError(t, err)
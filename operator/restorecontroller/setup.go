@@ -16,7 +16,8 @@ import (
 func SetupWithManager(mgr controllerruntime.Manager) error {
 	name := "restore.k8up.io"
 	r := reconciler.NewReconciler[*k8upv1.Restore, *k8upv1.RestoreList](mgr.GetClient(), &RestoreReconciler{
-		Kube: mgr.GetClient(),
+		Kube:     mgr.GetClient(),
+		Recorder: mgr.GetEventRecorder(name),
 	})
 	return controllerruntime.NewControllerManagedBy(mgr).
 		For(&k8upv1.Restore{}).
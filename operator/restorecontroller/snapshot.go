@@ -0,0 +1,86 @@
+package restorecontroller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+)
+
+// +kubebuilder:rbac:groups=k8up.io,resources=snapshots;repositories,verbs=get;list;watch
+
+// resolveSnapshotRef returns the ID of the Snapshot the given restore refers to, and the repository of the Snapshot if it's known,
+// so that the caller can verify that the restore uses it.
+// If the restore configures neither backend nor repositoryRef, the given spec, a copy of the restore's, is completed from the repository of the Snapshot:
+// A Repository in the namespace with the same backend is referred to, an S3 repository is configured directly.
+// The credentials and other repository types are completed by the ClusterRepository.
+// A missing Snapshot is returned as NotFound error.
+func resolveSnapshotRef(ctx context.Context, c client.Client, restore *k8upv1.Restore, spec *k8upv1.RunnableSpec) (string, string, error) {
+	ref := restore.Spec.SnapshotRef
+	if ref == nil {
+		return "", "", nil
+	}
+	snapshot := &k8upv1.Snapshot{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: restore.Namespace, Name: ref.Name}, snapshot); err != nil {
+		return "", "", err
+	}
+	if snapshot.Spec.ID == nil {
+		return "", "", fmt.Errorf("snapshot %q has no ID", ref.Name)
+	}
+	id := *snapshot.Spec.ID
+	if snapshot.Spec.Repository == nil {
+		return id, "", nil
+	}
+	repository := *snapshot.Spec.Repository
+	if configuresRepository(spec) {
+		return id, repository, nil
+	}
+
+	repositories := &k8upv1.RepositoryList{}
+	if err := c.List(ctx, repositories, client.InNamespace(restore.Namespace)); err != nil {
+		return "", "", fmt.Errorf("cannot list repositories: %w", err)
+	}
+	for _, candidate := range repositories.Items {
+		if candidate.Spec.Backend != nil && candidate.Spec.Backend.String() == repository {
+			spec.RepositoryRef = &corev1.LocalObjectReference{Name: candidate.Name}
+			return id, repository, nil
+		}
+	}
+	if s3 := s3SpecOf(repository); s3 != nil {
+		if spec.Backend == nil {
+			spec.Backend = &k8upv1.Backend{}
+		}
+		spec.Backend.S3 = s3
+	}
+	return id, repository, nil
+}
+
+// configuresRepository returns true if the given spec refers to a Repository or configures the storage of its backend.
+func configuresRepository(spec *k8upv1.RunnableSpec) bool {
+	return spec.RepositoryRef != nil || (spec.Backend != nil && spec.Backend.HasStorageType())
+}
+
+// s3SpecOf returns the S3 backend of the given restic repository, e.g. 's3:https://s3.example.com/bucket/prefix'.
+// The endpoint is the scheme and host of the repository, the bucket is its path: the bucket followed by an optional prefix, as restic treats it.
+// It returns nil if the repository isn't an S3 repository.
+func s3SpecOf(repository string) *k8upv1.S3Spec {
+	location, isS3 := strings.CutPrefix(repository, "s3:")
+	if !isS3 {
+		return nil
+	}
+	endpoint := ""
+	if scheme, rest, hasScheme := strings.Cut(location, "://"); hasScheme {
+		endpoint, location = scheme+"://", rest
+	}
+	host, bucket, _ := strings.Cut(location, "/")
+	bucket = strings.Trim(bucket, "/")
+	if host == "" || bucket == "" {
+		return nil
+	}
+	return &k8upv1.S3Spec{Endpoint: endpoint + host, Bucket: bucket}
+}
@@ -0,0 +1,135 @@
+package restorecontroller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+)
+
+func TestResolveSnapshotRef(t *testing.T) {
+	snapshot := &k8upv1.Snapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: "snapshot", Namespace: "app"},
+		Spec: k8upv1.SnapshotSpec{
+			ID:         ptr.To("162e7a85acbc14de93dad31a3699331cb32187ff0d7bd2227b7c4362a1d13a42"),
+			Repository: ptr.To("s3:http://minio:9000/backup"),
+		},
+	}
+	repository := &k8upv1.Repository{
+		ObjectMeta: metav1.ObjectMeta{Name: "repository", Namespace: "app"},
+		Spec:       k8upv1.RepositorySpec{Backend: &k8upv1.Backend{S3: &k8upv1.S3Spec{Endpoint: "http://minio:9000", Bucket: "backup"}}},
+	}
+
+	tests := map[string]struct {
+		givenSpec                  k8upv1.RestoreSpec
+		givenObjects               []client.Object
+		expectedRepository         string
+		expectedBackend            string
+		expectedSnapshotRepository string
+	}{
+		"GivenNoBackend_ThenExpectS3BackendFromSnapshot": {
+			givenObjects:               []client.Object{snapshot},
+			expectedBackend:            "s3:http://minio:9000/backup",
+			expectedSnapshotRepository: "s3:http://minio:9000/backup",
+		},
+		"GivenRepositoryWithSameBackend_ThenExpectRepositoryRef": {
+			givenObjects:               []client.Object{snapshot, repository},
+			expectedRepository:         "repository",
+			expectedSnapshotRepository: "s3:http://minio:9000/backup",
+		},
+		"GivenBackend_ThenExpectItUnchanged": {
+			givenSpec: k8upv1.RestoreSpec{RunnableSpec: k8upv1.RunnableSpec{
+				Backend: &k8upv1.Backend{S3: &k8upv1.S3Spec{Endpoint: "http://other:9000", Bucket: "backup"}},
+			}},
+			givenObjects:               []client.Object{snapshot, repository},
+			expectedBackend:            "s3:http://other:9000/backup",
+			expectedSnapshotRepository: "s3:http://minio:9000/backup",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			restore := &k8upv1.Restore{ObjectMeta: metav1.ObjectMeta{Name: "restore", Namespace: "app"}, Spec: tt.givenSpec}
+			restore.Spec.SnapshotRef = &corev1.LocalObjectReference{Name: "snapshot"}
+
+			spec := restore.Spec.RunnableSpec.DeepCopy()
+
+			id, snapshotRepository, err := resolveSnapshotRef(context.TODO(), newFakeClient(t, tt.givenObjects...), restore, spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedSnapshotRepository, snapshotRepository)
+
+			assert.Equal(t, *snapshot.Spec.ID, id)
+			assert.Empty(t, restore.Spec.Snapshot, "the restore must not be changed")
+			if tt.expectedRepository != "" {
+				require.NotNil(t, spec.RepositoryRef)
+				assert.Equal(t, tt.expectedRepository, spec.RepositoryRef.Name)
+			} else {
+				assert.Nil(t, spec.RepositoryRef)
+			}
+			if tt.expectedBackend != "" {
+				require.NotNil(t, spec.Backend)
+				assert.Equal(t, tt.expectedBackend, spec.Backend.String())
+			}
+		})
+	}
+}
+
+func Test_s3SpecOf(t *testing.T) {
+	tests := map[string]struct {
+		givenRepository string
+		expectedSpec    *k8upv1.S3Spec
+	}{
+		"GivenBucket_ThenExpectEndpointAndBucket": {
+			givenRepository: "s3:https://s3.example.com/bucket",
+			expectedSpec:    &k8upv1.S3Spec{Endpoint: "https://s3.example.com", Bucket: "bucket"},
+		},
+		"GivenBucketWithPrefix_ThenExpectEndpointOfHost": {
+			givenRepository: "s3:https://s3.example.com/bucket/prefix",
+			expectedSpec:    &k8upv1.S3Spec{Endpoint: "https://s3.example.com", Bucket: "bucket/prefix"},
+		},
+		"GivenEndpointWithoutScheme_ThenExpectHostAsEndpoint": {
+			givenRepository: "s3:s3.amazonaws.com/bucket/prefix",
+			expectedSpec:    &k8upv1.S3Spec{Endpoint: "s3.amazonaws.com", Bucket: "bucket/prefix"},
+		},
+		"GivenNoBucket_ThenExpectNil": {
+			givenRepository: "s3:https://s3.example.com/",
+		},
+		"GivenOtherRepositoryType_ThenExpectNil": {
+			givenRepository: "rest:https://rest.example.com/app",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			spec := s3SpecOf(tt.givenRepository)
+			assert.Equal(t, tt.expectedSpec, spec)
+			if spec != nil {
+				assert.Equal(t, tt.givenRepository, spec.String(), "the backend has to refer to the repository of the snapshot")
+			}
+		})
+	}
+}
+
+func TestResolveSnapshotRef_GivenMissingSnapshot_ThenExpectNotFound(t *testing.T) {
+	restore := &k8upv1.Restore{ObjectMeta: metav1.ObjectMeta{Name: "restore", Namespace: "app"}}
+	restore.Spec.SnapshotRef = &corev1.LocalObjectReference{Name: "missing"}
+
+	_, _, err := resolveSnapshotRef(context.TODO(), newFakeClient(t), restore, &restore.Spec.RunnableSpec)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func newFakeClient(t *testing.T, objects ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, k8upv1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).WithStatusSubresource(&k8upv1.Restore{}).Build()
+}
@@ -7,6 +7,7 @@ import (
 	"dario.cat/mergo"
 	"github.com/go-logr/logr"
 	"github.com/k8up-io/k8up/v2/operator/monitoring"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/utils/strings"
 	controllerruntime "sigs.k8s.io/controller-runtime"
@@ -43,6 +44,7 @@ func (s *ScheduleHandler) Handle(ctx context.Context) error {
 
 	err = s.createJobList(ctx, scheduler.GetScheduler())
 	if err != nil {
+		s.Eventf(nil, corev1.EventTypeWarning, job.EventReasonScheduleFailed, job.EventActionSchedule, "Cannot add to cron: %v", err.Error())
 		s.SetConditionFalseWithMessage(ctx, k8upv1.ConditionReady, k8upv1.ReasonFailed, "cannot add to cron: %v", err.Error())
 		return err
 	}
@@ -98,6 +100,15 @@ func (s *ScheduleHandler) createJobList(ctx context.Context, sched scheduler.Sch
 
 			return check
 		}},
+		k8upv1.CopyType: {spec: s.schedule.Spec.Copy, ctor: func(spec k8upv1.ScheduleSpecInterface) k8upv1.JobObject {
+			cp := &k8upv1.Copy{Spec: spec.(*k8upv1.CopySchedule).CopySpec}
+
+			if cp.Spec.PodConfigRef == nil && s.schedule.Spec.PodConfigRef != nil {
+				cp.Spec.PodConfigRef = s.schedule.Spec.PodConfigRef
+			}
+
+			return cp
+		}},
 		k8upv1.RestoreType: {spec: s.schedule.Spec.Restore, ctor: func(spec k8upv1.ScheduleSpecInterface) k8upv1.JobObject {
 			restore := &k8upv1.Restore{Spec: spec.(*k8upv1.RestoreSchedule).RestoreSpec}
 
@@ -168,12 +179,60 @@ func (s *ScheduleHandler) executeCronSchedule(ctx context.Context, obj k8upv1.Jo
 	labels[k8upv1.LabelK8upScheduleName] = s.schedule.Name
 	obj.SetLabels(labels)
 
+	var rotated *k8upv1.Schedule
+	if check, ok := obj.(*k8upv1.Check); ok && s.schedule.Spec.Check != nil && s.schedule.Spec.Check.RotateReadDataSubset {
+		schedule, err := s.rotateReadDataSubset(ctx, check)
+		if err != nil {
+			log.Error(err, "Could not rotate the subset of the check", "namespace", obj.GetNamespace(), "name", obj.GetName())
+			s.Eventf(nil, corev1.EventTypeWarning, job.EventReasonScheduleFailed, job.EventActionCreate, "Could not create %s '%s': %v", obj.GetType(), obj.GetName(), err.Error())
+			return
+		}
+		rotated = schedule
+	}
+
 	err := s.Client.Create(ctx, obj.DeepCopyObject().(client.Object))
 	if err != nil {
 		log.Error(err, "Could not create new object", "type", obj.GetType(), "namespace", obj.GetNamespace(), "name", obj.GetName())
+		s.Eventf(nil, corev1.EventTypeWarning, job.EventReasonScheduleFailed, job.EventActionCreate, "Could not create %s '%s': %v", obj.GetType(), obj.GetName(), err.Error())
+		return
+	}
+	if rotated != nil {
+		if err := s.Client.Status().Update(ctx, rotated); err != nil {
+			log.Error(err, "Could not record the subset of the check", "namespace", obj.GetNamespace(), "name", obj.GetName())
+		}
 	}
 }
 
+// rotateReadDataSubset sets the subset that the given Check reads to the part after the one of the previous Check.
+// It returns the Schedule with the new subset in its status, which is updated once the Check has been created.
+// The Schedule is read again, as changes of its status don't trigger a reconciliation.
+func (s *ScheduleHandler) rotateReadDataSubset(ctx context.Context, check *k8upv1.Check) (*k8upv1.Schedule, error) {
+	schedule := &k8upv1.Schedule{}
+	if err := s.Client.Get(ctx, client.ObjectKeyFromObject(s.schedule), schedule); err != nil {
+		return nil, fmt.Errorf("cannot get schedule: %w", err)
+	}
+	if schedule.Spec.Check == nil {
+		return nil, fmt.Errorf("the schedule doesn't define a check anymore")
+	}
+	subset := nextReadDataSubset(schedule.Spec.Check.ReadDataSubset, schedule.Status.CheckReadDataSubset)
+	check.Spec.ReadDataSubset = subset
+	schedule.Status.CheckReadDataSubset = subset
+	return schedule, nil
+}
+
+// nextReadDataSubset returns the part "n/t" that follows the previous part, and starts again with the first part after the last one.
+// The configured part is returned if there's no previous part or if the number of parts has changed.
+func nextReadDataSubset(configured, previous string) string {
+	n, t, ok := k8upv1.ReadDataPart(configured)
+	if !ok {
+		return configured
+	}
+	if p, pt, ok := k8upv1.ReadDataPart(previous); ok && pt == t && p >= 1 && p <= t {
+		n = p%t + 1
+	}
+	return fmt.Sprintf("%d/%d", n, t)
+}
+
 func generateName(jobType k8upv1.JobType, prefix string) string {
 	lenRandom := 5
 	remainingLength := 63 - lenRandom - len(jobType) - 2
@@ -199,6 +258,13 @@ func (s *ScheduleHandler) mergeResourcesWithDefaults(specInstance *k8upv1.Runnab
 }
 
 func (s *ScheduleHandler) mergeBackendWithDefaults(specInstance *k8upv1.RunnableSpec) {
+	if specInstance.RepositoryRef != nil {
+		return
+	}
+	if specInstance.Backend == nil && s.schedule.Spec.RepositoryRef != nil {
+		specInstance.RepositoryRef = s.schedule.Spec.RepositoryRef.DeepCopy()
+		return
+	}
 	if specInstance.Backend == nil {
 		specInstance.Backend = s.schedule.Spec.Backend.DeepCopy()
 		return
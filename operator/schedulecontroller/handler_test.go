@@ -193,6 +193,24 @@ func TestScheduleHandler_mergeBackendWithDefaults(t *testing.T) {
 	}
 }
 
+func TestScheduleHandler_mergeBackendWithDefaults_RepositoryRef(t *testing.T) {
+	scheduleBackend := newS3Backend("https://schedule-url", "schedule-bucket")
+	schedule := ScheduleHandler{schedule: &k8upv1.Schedule{Spec: k8upv1.ScheduleSpec{
+		RepositoryRef: &corev1.LocalObjectReference{Name: "schedule-repository"},
+		Backend:       &scheduleBackend,
+	}}}
+
+	res := &k8upv1.RunnableSpec{}
+	schedule.mergeBackendWithDefaults(res)
+	assert.Nil(t, res.Backend)
+	assert.Equal(t, "schedule-repository", res.RepositoryRef.Name)
+
+	res = &k8upv1.RunnableSpec{RepositoryRef: &corev1.LocalObjectReference{Name: "resource-repository"}}
+	schedule.mergeBackendWithDefaults(res)
+	assert.Nil(t, res.Backend)
+	assert.Equal(t, "resource-repository", res.RepositoryRef.Name)
+}
+
 func TestScheduleHandler_mergePodSecurityContextWithDefaults(t *testing.T) {
 	tests := map[string]struct {
 		givenSchedulePodSecurityContext *corev1.PodSecurityContext
@@ -323,6 +341,83 @@ func TestCreateJobList_mergePodConfig(t *testing.T) {
 	}
 }
 
+func TestCreateJobList_rotateReadDataSubset(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, k8upv1.AddToScheme(scheme))
+
+	schedule := &k8upv1.Schedule{
+		ObjectMeta: metav1.ObjectMeta{Name: "schedule", Namespace: nsName},
+		Spec: k8upv1.ScheduleSpec{
+			Check: &k8upv1.CheckSchedule{
+				ScheduleCommon:       &k8upv1.ScheduleCommon{Schedule: "@weekly"},
+				CheckSpec:            k8upv1.CheckSpec{ReadDataSubset: "2/3"},
+				RotateReadDataSubset: true,
+			},
+		},
+	}
+	fclient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(schedule).
+		WithStatusSubresource(schedule).
+		Build()
+	handler := ScheduleHandler{Config: job.Config{Client: fclient}, schedule: schedule}
+
+	for range 3 {
+		assert.NoError(t, handler.createJobList(context.TODO(), &mockScheduler{}))
+	}
+
+	checks := &k8upv1.CheckList{}
+	assert.NoError(t, fclient.List(context.TODO(), checks, client.InNamespace(nsName)))
+	var subsets []string
+	for _, check := range checks.Items {
+		subsets = append(subsets, check.Spec.ReadDataSubset)
+	}
+	assert.ElementsMatch(t, []string{"2/3", "3/3", "1/3"}, subsets)
+
+	stored := &k8upv1.Schedule{}
+	assert.NoError(t, fclient.Get(context.TODO(), client.ObjectKeyFromObject(schedule), stored))
+	assert.Equal(t, "1/3", stored.Status.CheckReadDataSubset)
+}
+
+func Test_nextReadDataSubset(t *testing.T) {
+	tests := map[string]struct {
+		configured     string
+		previous       string
+		expectedSubset string
+	}{
+		"GivenNoPreviousPart_ThenExpectConfiguredPart": {
+			configured:     "1/7",
+			expectedSubset: "1/7",
+		},
+		"GivenPreviousPart_ThenExpectNextPart": {
+			configured:     "1/7",
+			previous:       "3/7",
+			expectedSubset: "4/7",
+		},
+		"GivenLastPart_ThenExpectFirstPart": {
+			configured:     "1/7",
+			previous:       "7/7",
+			expectedSubset: "1/7",
+		},
+		"GivenChangedNumberOfParts_ThenExpectConfiguredPart": {
+			configured:     "2/5",
+			previous:       "3/7",
+			expectedSubset: "2/5",
+		},
+		"GivenPercentage_ThenExpectPercentage": {
+			configured:     "10%",
+			previous:       "3/7",
+			expectedSubset: "10%",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedSubset, nextReadDataSubset(tt.configured, tt.previous))
+		})
+	}
+}
+
 func Test_generateName(t *testing.T) {
 	tests := map[string]struct {
 		jobType        k8upv1.JobType
@@ -9,13 +9,14 @@ import (
 	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
 )
 
+// The supported '@x-random' definitions are listed in k8upv1.RandomScheduleDefinitions, which the validation of Schedules uses as well.
 const (
-	ScheduleHourlyRandom   = "@hourly-random"
-	ScheduleDailyRandom    = "@daily-random"
-	ScheduleYearlyRandom   = "@yearly-random"
-	ScheduleAnnuallyRandom = "@annually-random"
-	ScheduleMonthlyRandom  = "@monthly-random"
-	ScheduleWeeklyRandom   = "@weekly-random"
+	ScheduleHourlyRandom   = k8upv1.ScheduleHourlyRandom
+	ScheduleDailyRandom    = k8upv1.ScheduleDailyRandom
+	ScheduleYearlyRandom   = k8upv1.ScheduleYearlyRandom
+	ScheduleAnnuallyRandom = k8upv1.ScheduleAnnuallyRandom
+	ScheduleMonthlyRandom  = k8upv1.ScheduleMonthlyRandom
+	ScheduleWeeklyRandom   = k8upv1.ScheduleWeeklyRandom
 )
 
 func createSeed(schedule *k8upv1.Schedule, jobType k8upv1.JobType) string {
@@ -40,8 +41,7 @@ func randomizeSchedule(seed string, originalSchedule k8upv1.ScheduleDefinition)
 		return k8upv1.ScheduleDefinition(fmt.Sprintf("%d %d * * *", minute, hour)), nil
 	case ScheduleMonthlyRandom:
 		return k8upv1.ScheduleDefinition(fmt.Sprintf("%d %d %d * *", minute, hour, dayOfMonth)), nil
-	case ScheduleAnnuallyRandom:
-	case ScheduleYearlyRandom:
+	case ScheduleAnnuallyRandom, ScheduleYearlyRandom:
 		month := remainderFromModulo(checksum, 12, 1)
 		return k8upv1.ScheduleDefinition(fmt.Sprintf("%d %d %d %d *", minute, hour, dayOfMonth, month)), nil
 	case ScheduleWeeklyRandom:
@@ -50,7 +50,6 @@ func randomizeSchedule(seed string, originalSchedule k8upv1.ScheduleDefinition)
 	default:
 		return originalSchedule, fmt.Errorf("unrecognized random schedule: '%s'", originalSchedule)
 	}
-	return originalSchedule, nil
 }
 
 // calculateChecksumFromSeed calculates a SHA1 hexadecimal checksum from the given seed.
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
 )
@@ -81,3 +82,13 @@ func Test_randomizeSchedule_VerifySchedules(t *testing.T) {
 		})
 	}
 }
+
+func Test_randomizeSchedule_SupportsAllRandomScheduleDefinitions(t *testing.T) {
+	for _, definition := range k8upv1.RandomScheduleDefinitions {
+		t.Run(definition.String(), func(t *testing.T) {
+			schedule, err := randomizeSchedule("k8up-system/my-scheduled-backup@backup", definition)
+			assert.NoError(t, err)
+			assert.Empty(t, schedule.Validate(field.NewPath("schedule")), "the randomized schedule %q has to be a valid cron expression", schedule)
+		})
+	}
+}
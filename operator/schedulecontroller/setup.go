@@ -17,7 +17,8 @@ import (
 func SetupWithManager(mgr ctrl.Manager) error {
 	name := "schedule.k8up.io"
 	r := reconciler.NewReconciler[*k8upv1.Schedule, *k8upv1.ScheduleList](mgr.GetClient(), &ScheduleReconciler{
-		Kube: mgr.GetClient(),
+		Kube:     mgr.GetClient(),
+		Recorder: mgr.GetEventRecorder(name),
 	})
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&k8upv1.Schedule{}).
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"math/rand"
+	"path"
 	"reflect"
 	"time"
 
@@ -16,8 +17,74 @@ const (
 	_dataDirName        = "k8up-dir"
 	_resticTmpDirName   = "restic-tmp-dir"
 	_resticCacheDirName = "restic-cache-dir"
+
+	_tlsMountDir       = "/etc/k8up"
+	_tlsCACertFile     = "ca.crt"
+	_tlsClientCertFile = "tls.crt"
+	_tlsClientKeyFile  = "tls.key"
 )
 
+// TLSMount are TLS options whose referenced Secrets and ConfigMaps are mounted into the job's pod.
+// Prefix is the prefix of the restic arguments as given to AppendTLSOptionsArgs, empty for the backend.
+type TLSMount struct {
+	Options *k8upv1.TLSOptions
+	Prefix  string
+}
+
+// volumeName returns the name of the volume that holds the referenced files.
+func (m TLSMount) volumeName() string {
+	if m.Prefix == "" {
+		return "k8up-tls"
+	}
+	return "k8up-" + m.Prefix + "-tls"
+}
+
+// mountPath returns the directory the referenced files are mounted at.
+func (m TLSMount) mountPath() string {
+	return path.Join(_tlsMountDir, m.volumeName())
+}
+
+// TLSVolumeMounts returns the volume mounts of the files referenced by the given TLS options.
+func TLSVolumeMounts(mounts ...TLSMount) []corev1.VolumeMount {
+	var volumeMounts []corev1.VolumeMount
+	for _, m := range mounts {
+		if !m.Options.HasReferences() {
+			continue
+		}
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: m.volumeName(), MountPath: m.mountPath(), ReadOnly: true})
+	}
+	return volumeMounts
+}
+
+// volume returns a projected volume with the referenced files.
+func (m TLSMount) volume() corev1.Volume {
+	opts := m.Options
+	var sources []corev1.VolumeProjection
+	secretFile := func(ref *corev1.SecretKeySelector, file string) {
+		if ref != nil {
+			sources = append(sources, corev1.VolumeProjection{Secret: &corev1.SecretProjection{
+				LocalObjectReference: ref.LocalObjectReference,
+				Items:                []corev1.KeyToPath{{Key: ref.Key, Path: file}},
+				Optional:             ref.Optional,
+			}})
+		}
+	}
+	secretFile(opts.CACertSecretRef, _tlsCACertFile)
+	if ref := opts.CACertConfigMapRef; ref != nil {
+		sources = append(sources, corev1.VolumeProjection{ConfigMap: &corev1.ConfigMapProjection{
+			LocalObjectReference: ref.LocalObjectReference,
+			Items:                []corev1.KeyToPath{{Key: ref.Key, Path: _tlsCACertFile}},
+			Optional:             ref.Optional,
+		}})
+	}
+	secretFile(opts.ClientCertSecretRef, _tlsClientCertFile)
+	secretFile(opts.ClientKeySecretRef, _tlsClientKeyFile)
+	return corev1.Volume{
+		Name:         m.volumeName(),
+		VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: sources}},
+	}
+}
+
 func RandomStringGenerator(n int) string {
 	characters := []rune("abcdefghijklmnopqrstuvwxyz1234567890")
 	rand.New(rand.NewSource(time.Now().UnixNano()))
@@ -51,6 +118,8 @@ func ZeroLen(v interface{}) bool {
 	return true
 }
 
+// AppendTLSOptionsArgs returns the restic arguments for the given TLS options.
+// Files referenced in Secrets or ConfigMaps are passed with the paths they are mounted at, see TLSVolumeMounts.
 func AppendTLSOptionsArgs(opts *k8upv1.TLSOptions, prefixArgName ...string) []string {
 	var args []string
 	if opts == nil {
@@ -61,6 +130,17 @@ func AppendTLSOptionsArgs(opts *k8upv1.TLSOptions, prefixArgName ...string) []st
 	for _, v := range prefixArgName {
 		prefix = v
 	}
+	mount := TLSMount{Options: opts, Prefix: prefix}
+	caCert, clientCert, clientKey := opts.CACert, opts.ClientCert, opts.ClientKey
+	if opts.CACertSecretRef != nil || opts.CACertConfigMapRef != nil {
+		caCert = path.Join(mount.mountPath(), _tlsCACertFile)
+	}
+	if opts.ClientCertSecretRef != nil {
+		clientCert = path.Join(mount.mountPath(), _tlsClientCertFile)
+	}
+	if opts.ClientKeySecretRef != nil {
+		clientKey = path.Join(mount.mountPath(), _tlsClientKeyFile)
+	}
 
 	caCertArg := "-caCert"
 	clientCertArg := "-clientCert"
@@ -71,15 +151,15 @@ func AppendTLSOptionsArgs(opts *k8upv1.TLSOptions, prefixArgName ...string) []st
 		clientKeyArg = "-" + prefix + "ClientKey"
 	}
 
-	if opts.CACert != "" {
-		args = append(args, []string{caCertArg, opts.CACert}...)
+	if caCert != "" {
+		args = append(args, []string{caCertArg, caCert}...)
 	}
-	if opts.ClientCert != "" && opts.ClientKey != "" {
+	if clientCert != "" && clientKey != "" {
 		addMoreArgs := []string{
 			clientCertArg,
-			opts.ClientCert,
+			clientCert,
 			clientKeyArg,
-			opts.ClientKey,
+			clientKey,
 		}
 		args = append(args, addMoreArgs...)
 	}
@@ -87,7 +167,9 @@ func AppendTLSOptionsArgs(opts *k8upv1.TLSOptions, prefixArgName ...string) []st
 	return args
 }
 
-func AttachEmptyDirVolumes(volumes *[]k8upv1.RunnableVolumeSpec) []corev1.Volume {
+// AttachEmptyDirVolumes returns the volumes of the job's pod: the k8up and restic directories, the given volumes
+// and the files referenced by the given TLS options.
+func AttachEmptyDirVolumes(volumes *[]k8upv1.RunnableVolumeSpec, tlsMounts ...TLSMount) []corev1.Volume {
 	k8upVolume := corev1.Volume{
 		Name:         _dataDirName,
 		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
@@ -103,12 +185,20 @@ func AttachEmptyDirVolumes(volumes *[]k8upv1.RunnableVolumeSpec) []corev1.Volume
 		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
 	}
 
+	var tlsVolumes []corev1.Volume
+	for _, m := range tlsMounts {
+		if m.Options.HasReferences() {
+			tlsVolumes = append(tlsVolumes, m.volume())
+		}
+	}
+
 	if volumes == nil {
-		return []corev1.Volume{k8upVolume, resticTmpVolume, resticCacheVolume}
+		return append([]corev1.Volume{k8upVolume, resticTmpVolume, resticCacheVolume}, tlsVolumes...)
 	}
 
 	var moreVolumes []corev1.Volume
 	moreVolumes = append(moreVolumes, k8upVolume, resticTmpVolume, resticCacheVolume)
+	moreVolumes = append(moreVolumes, tlsVolumes...)
 	for _, v := range *volumes {
 		vol := v
 
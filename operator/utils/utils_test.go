@@ -322,6 +322,27 @@ func Test_AppendTLSOptionsArgs(t *testing.T) {
 			},
 			want: []string{"-restoreCaCert", "/path/of/ca.cert", "-restoreClientCert", "/path/of/client.crt", "-restoreClientKey", "/path/of/client.key"},
 		},
+		{
+			name: "return args with mounted files when tlsOptions reference secrets",
+			args: args{
+				opts: &k8upv1.TLSOptions{
+					CACertConfigMapRef:  &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "ca"}, Key: "ca.crt"},
+					ClientCertSecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "client"}, Key: "tls.crt"},
+					ClientKeySecretRef:  &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "client"}, Key: "tls.key"},
+				},
+			},
+			want: []string{"-caCert", "/etc/k8up/k8up-tls/ca.crt", "-clientCert", "/etc/k8up/k8up-tls/tls.crt", "-clientKey", "/etc/k8up/k8up-tls/tls.key"},
+		},
+		{
+			name: "return args with mounted files when tlsOptions reference secrets (with prefix)",
+			args: args{
+				opts: &k8upv1.TLSOptions{
+					CACertSecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "ca"}, Key: "ca.crt"},
+				},
+				prefixArgName: []string{"restore"},
+			},
+			want: []string{"-restoreCaCert", "/etc/k8up/k8up-restore-tls/ca.crt"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -671,3 +692,30 @@ func Test_AttachTLSVolumeMounts(t *testing.T) {
 		})
 	}
 }
+
+func Test_TLSMount(t *testing.T) {
+	mounts := []TLSMount{
+		{Options: &k8upv1.TLSOptions{CACert: "/path/of/ca.cert"}},
+		{Options: &k8upv1.TLSOptions{
+			CACertConfigMapRef:  &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "ca"}, Key: "ca.crt"},
+			ClientCertSecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "client"}, Key: "tls.crt"},
+			ClientKeySecretRef:  &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "client"}, Key: "tls.key"},
+		}, Prefix: "restore"},
+		{},
+	}
+
+	volumes := AttachEmptyDirVolumes(nil, mounts...)
+	assert.Len(t, volumes, 4, "only options with references get a volume")
+	assert.Equal(t, corev1.Volume{
+		Name: "k8up-restore-tls",
+		VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{
+			{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "ca"}, Items: []corev1.KeyToPath{{Key: "ca.crt", Path: "ca.crt"}}}},
+			{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "client"}, Items: []corev1.KeyToPath{{Key: "tls.crt", Path: "tls.crt"}}}},
+			{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "client"}, Items: []corev1.KeyToPath{{Key: "tls.key", Path: "tls.key"}}}},
+		}}},
+	}, volumes[3])
+
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "k8up-restore-tls", MountPath: "/etc/k8up/k8up-restore-tls", ReadOnly: true},
+	}, TLSVolumeMounts(mounts...))
+}
@@ -0,0 +1,98 @@
+package webhooks
+
+import (
+	"context"
+
+	"dario.cat/mergo"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/cfg"
+)
+
+// Defaulter writes the operator's global defaults into k8up objects of type T when they are created.
+// This makes the effective configuration visible on the object instead of being resolved at the time the job runs.
+type Defaulter[T client.Object] struct {
+	setDefaults func(obj T)
+}
+
+// NewDefaulter returns a new Defaulter that applies setDefaults to each object.
+func NewDefaulter[T client.Object](setDefaults func(obj T)) *Defaulter[T] {
+	return &Defaulter[T]{setDefaults: setDefaults}
+}
+
+// Default implements admission.Defaulter.
+func (d *Defaulter[T]) Default(_ context.Context, obj T) error {
+	d.setDefaults(obj)
+	return nil
+}
+
+// DefaultBackup sets the defaults of a Backup.
+func DefaultBackup(obj *k8upv1.Backup) {
+	defaultRunnableSpec(&obj.Spec.RunnableSpec)
+}
+
+// DefaultCheck sets the defaults of a Check.
+func DefaultCheck(obj *k8upv1.Check) {
+	defaultRunnableSpec(&obj.Spec.RunnableSpec)
+}
+
+// DefaultCopy sets the defaults of a Copy.
+// The destination is left as is, it doesn't fall back to the global repository.
+func DefaultCopy(obj *k8upv1.Copy) {
+	defaultRunnableSpec(&obj.Spec.RunnableSpec)
+}
+
+// DefaultMaintenance sets the defaults of a Maintenance.
+func DefaultMaintenance(obj *k8upv1.Maintenance) {
+	defaultRunnableSpec(&obj.Spec.RunnableSpec)
+}
+
+// DefaultPrune sets the defaults of a Prune.
+func DefaultPrune(obj *k8upv1.Prune) {
+	defaultRunnableSpec(&obj.Spec.RunnableSpec)
+	defaultRetentionPolicy(&obj.Spec.Retention)
+}
+
+// DefaultRepositoryKey sets the defaults of a RepositoryKey.
+func DefaultRepositoryKey(obj *k8upv1.RepositoryKey) {
+	defaultRunnableSpec(&obj.Spec.RunnableSpec)
+}
+
+// DefaultRestore sets the defaults of a Restore.
+func DefaultRestore(obj *k8upv1.Restore) {
+	defaultRunnableSpec(&obj.Spec.RunnableSpec)
+}
+
+// DefaultArchive sets the defaults of an Archive.
+func DefaultArchive(obj *k8upv1.Archive) {
+	if obj.Spec.RestoreSpec == nil {
+		obj.Spec.RestoreSpec = &k8upv1.RestoreSpec{}
+	}
+	defaultRunnableSpec(&obj.Spec.RunnableSpec)
+}
+
+// DefaultSchedule sets the defaults of a Schedule.
+// The global default resources are merged into the Schedule's resource template,
+// the jobs created by the Schedule get their defaults once they are created.
+func DefaultSchedule(obj *k8upv1.Schedule) {
+	// Merging two ResourceRequirements never returns an error, they are of the same type.
+	_ = mergo.Merge(&obj.Spec.ResourceRequirementsTemplate, cfg.Config.GetGlobalDefaultResources())
+	if obj.Spec.Prune != nil {
+		defaultRetentionPolicy(&obj.Spec.Prune.Retention)
+	}
+}
+
+// defaultRunnableSpec merges the global default resources into the resources of the spec.
+// The backend is left as is: the global S3 endpoint and bucket only apply if no ClusterRepository applies to the namespace
+// at the time the job runs, see job.ApplyClusterRepository.
+func defaultRunnableSpec(spec *k8upv1.RunnableSpec) {
+	// Merging two ResourceRequirements never returns an error, they are of the same type.
+	_ = mergo.Merge(&spec.Resources, cfg.Config.GetGlobalDefaultResources())
+}
+
+func defaultRetentionPolicy(policy *k8upv1.RetentionPolicy) {
+	if policy.KeepDaily == 0 {
+		policy.KeepDaily = k8upv1.DefaultKeepDaily
+	}
+}
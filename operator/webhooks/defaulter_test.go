@@ -0,0 +1,116 @@
+package webhooks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/cfg"
+)
+
+func TestDefaultRunnableSpec(t *testing.T) {
+	cfg.Config.GlobalCPUResourceRequest = "10m"
+	cfg.Config.GlobalMemoryResourceLimit = "1Gi"
+	cfg.Config.GlobalS3Endpoint = "https://s3.example.com"
+	cfg.Config.GlobalS3Bucket = "bucket"
+	defer func() {
+		cfg.Config.GlobalCPUResourceRequest = ""
+		cfg.Config.GlobalMemoryResourceLimit = ""
+		cfg.Config.GlobalS3Endpoint = ""
+		cfg.Config.GlobalS3Bucket = ""
+	}()
+	givenSpec := func() k8upv1.RunnableSpec {
+		return k8upv1.RunnableSpec{
+			Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("50m")}},
+		}
+	}
+	expectedResources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("50m")},
+		Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+	}
+
+	tests := map[string]struct {
+		givenObject  k8upv1.JobObject
+		setDefaults  func(obj k8upv1.JobObject)
+		expectedSpec func(obj k8upv1.JobObject) k8upv1.RunnableSpec
+	}{
+		"GivenBackup_ThenExpectGlobalResources": {
+			givenObject:  &k8upv1.Backup{Spec: k8upv1.BackupSpec{RunnableSpec: givenSpec()}},
+			setDefaults:  func(obj k8upv1.JobObject) { DefaultBackup(obj.(*k8upv1.Backup)) },
+			expectedSpec: func(obj k8upv1.JobObject) k8upv1.RunnableSpec { return obj.(*k8upv1.Backup).Spec.RunnableSpec },
+		},
+		"GivenCheck_ThenExpectGlobalResources": {
+			givenObject:  &k8upv1.Check{Spec: k8upv1.CheckSpec{RunnableSpec: givenSpec()}},
+			setDefaults:  func(obj k8upv1.JobObject) { DefaultCheck(obj.(*k8upv1.Check)) },
+			expectedSpec: func(obj k8upv1.JobObject) k8upv1.RunnableSpec { return obj.(*k8upv1.Check).Spec.RunnableSpec },
+		},
+		"GivenPrune_ThenExpectGlobalResources": {
+			givenObject:  &k8upv1.Prune{Spec: k8upv1.PruneSpec{RunnableSpec: givenSpec()}},
+			setDefaults:  func(obj k8upv1.JobObject) { DefaultPrune(obj.(*k8upv1.Prune)) },
+			expectedSpec: func(obj k8upv1.JobObject) k8upv1.RunnableSpec { return obj.(*k8upv1.Prune).Spec.RunnableSpec },
+		},
+		"GivenRestore_ThenExpectGlobalResources": {
+			givenObject:  &k8upv1.Restore{Spec: k8upv1.RestoreSpec{RunnableSpec: givenSpec()}},
+			setDefaults:  func(obj k8upv1.JobObject) { DefaultRestore(obj.(*k8upv1.Restore)) },
+			expectedSpec: func(obj k8upv1.JobObject) k8upv1.RunnableSpec { return obj.(*k8upv1.Restore).Spec.RunnableSpec },
+		},
+		"GivenArchive_ThenExpectGlobalResources": {
+			givenObject:  &k8upv1.Archive{Spec: k8upv1.ArchiveSpec{RestoreSpec: &k8upv1.RestoreSpec{RunnableSpec: givenSpec()}}},
+			setDefaults:  func(obj k8upv1.JobObject) { DefaultArchive(obj.(*k8upv1.Archive)) },
+			expectedSpec: func(obj k8upv1.JobObject) k8upv1.RunnableSpec { return obj.(*k8upv1.Archive).Spec.RunnableSpec },
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tt.setDefaults(tt.givenObject)
+			spec := tt.expectedSpec(tt.givenObject)
+			assert.Equal(t, expectedResources, spec.Resources)
+			assert.Nil(t, spec.Backend, "the global S3 settings are resolved at the time the job runs, after the ClusterRepository")
+		})
+	}
+}
+
+func TestDefaulter_Default(t *testing.T) {
+	backup := &k8upv1.Backup{}
+	require.NoError(t, NewDefaulter(DefaultBackup).Default(context.TODO(), backup))
+	assert.Nil(t, backup.Spec.Backend)
+}
+
+func TestDefaultPrune(t *testing.T) {
+	prune := &k8upv1.Prune{Spec: k8upv1.PruneSpec{Retention: k8upv1.RetentionPolicy{KeepLast: 3}}}
+	DefaultPrune(prune)
+	assert.Equal(t, k8upv1.RetentionPolicy{KeepLast: 3, KeepDaily: k8upv1.DefaultKeepDaily}, prune.Spec.Retention)
+
+	prune = &k8upv1.Prune{Spec: k8upv1.PruneSpec{Retention: k8upv1.RetentionPolicy{KeepDaily: 7}}}
+	DefaultPrune(prune)
+	assert.Equal(t, 7, prune.Spec.Retention.KeepDaily)
+}
+
+func TestDefaultSchedule(t *testing.T) {
+	cfg.Config.GlobalCPUResourceRequest = "10m"
+	cfg.Config.GlobalMemoryResourceLimit = "1Gi"
+	defer func() {
+		cfg.Config.GlobalCPUResourceRequest = ""
+		cfg.Config.GlobalMemoryResourceLimit = ""
+	}()
+
+	schedule := &k8upv1.Schedule{Spec: k8upv1.ScheduleSpec{
+		ResourceRequirementsTemplate: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("50m")},
+		},
+		Prune: &k8upv1.PruneSchedule{},
+	}}
+	DefaultSchedule(schedule)
+
+	assert.Equal(t, corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("50m")},
+		Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+	}, schedule.Spec.ResourceRequirementsTemplate)
+	assert.Equal(t, k8upv1.DefaultKeepDaily, schedule.Spec.Prune.Retention.KeepDaily)
+	assert.Nil(t, schedule.Spec.Backend)
+}
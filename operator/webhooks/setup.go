@@ -0,0 +1,114 @@
+package webhooks
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+)
+
+// +kubebuilder:webhook:path=/mutate-k8up-io-v1-archive,mutating=true,failurePolicy=fail,sideEffects=None,groups=k8up.io,resources=archives,verbs=create,versions=v1,name=marchive.k8up.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-k8up-io-v1-backup,mutating=true,failurePolicy=fail,sideEffects=None,groups=k8up.io,resources=backups,verbs=create,versions=v1,name=mbackup.k8up.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-k8up-io-v1-check,mutating=true,failurePolicy=fail,sideEffects=None,groups=k8up.io,resources=checks,verbs=create,versions=v1,name=mcheck.k8up.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-k8up-io-v1-copy,mutating=true,failurePolicy=fail,sideEffects=None,groups=k8up.io,resources=copies,verbs=create,versions=v1,name=mcopy.k8up.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-k8up-io-v1-maintenance,mutating=true,failurePolicy=fail,sideEffects=None,groups=k8up.io,resources=maintenances,verbs=create,versions=v1,name=mmaintenance.k8up.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-k8up-io-v1-prune,mutating=true,failurePolicy=fail,sideEffects=None,groups=k8up.io,resources=prunes,verbs=create,versions=v1,name=mprune.k8up.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-k8up-io-v1-repositorykey,mutating=true,failurePolicy=fail,sideEffects=None,groups=k8up.io,resources=repositorykeys,verbs=create,versions=v1,name=mrepositorykey.k8up.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-k8up-io-v1-restore,mutating=true,failurePolicy=fail,sideEffects=None,groups=k8up.io,resources=restores,verbs=create,versions=v1,name=mrestore.k8up.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-k8up-io-v1-schedule,mutating=true,failurePolicy=fail,sideEffects=None,groups=k8up.io,resources=schedules,verbs=create,versions=v1,name=mschedule.k8up.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-k8up-io-v1-archive,mutating=false,failurePolicy=fail,sideEffects=None,groups=k8up.io,resources=archives,verbs=create;update,versions=v1,name=varchive.k8up.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-k8up-io-v1-backup,mutating=false,failurePolicy=fail,sideEffects=None,groups=k8up.io,resources=backups,verbs=create;update,versions=v1,name=vbackup.k8up.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-k8up-io-v1-clusterrepository,mutating=false,failurePolicy=fail,sideEffects=None,groups=k8up.io,resources=clusterrepositories,verbs=create;update,versions=v1,name=vclusterrepository.k8up.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-k8up-io-v1-check,mutating=false,failurePolicy=fail,sideEffects=None,groups=k8up.io,resources=checks,verbs=create;update,versions=v1,name=vcheck.k8up.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-k8up-io-v1-copy,mutating=false,failurePolicy=fail,sideEffects=None,groups=k8up.io,resources=copies,verbs=create;update,versions=v1,name=vcopy.k8up.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-k8up-io-v1-maintenance,mutating=false,failurePolicy=fail,sideEffects=None,groups=k8up.io,resources=maintenances,verbs=create;update,versions=v1,name=vmaintenance.k8up.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-k8up-io-v1-prune,mutating=false,failurePolicy=fail,sideEffects=None,groups=k8up.io,resources=prunes,verbs=create;update,versions=v1,name=vprune.k8up.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-k8up-io-v1-repository,mutating=false,failurePolicy=fail,sideEffects=None,groups=k8up.io,resources=repositories,verbs=create;update,versions=v1,name=vrepository.k8up.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-k8up-io-v1-repositorykey,mutating=false,failurePolicy=fail,sideEffects=None,groups=k8up.io,resources=repositorykeys,verbs=create;update,versions=v1,name=vrepositorykey.k8up.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-k8up-io-v1-restore,mutating=false,failurePolicy=fail,sideEffects=None,groups=k8up.io,resources=restores,verbs=create;update,versions=v1,name=vrestore.k8up.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-k8up-io-v1-schedule,mutating=false,failurePolicy=fail,sideEffects=None,groups=k8up.io,resources=schedules,verbs=create;update,versions=v1,name=vschedule.k8up.io,admissionReviewVersions=v1
+
+// SetupWithManager registers the defaulting and validating admission webhooks of all k8up job types with the manager's webhook server.
+func SetupWithManager(mgr controllerruntime.Manager) error {
+	specPath := field.NewPath("spec")
+	for _, setupFn := range []func() error{
+		func() error {
+			return controllerruntime.NewWebhookManagedBy(mgr, &k8upv1.Archive{}).
+				WithDefaulter(NewDefaulter(DefaultArchive)).
+				WithValidator(NewValidator(k8upv1.ArchiveKind, func(obj *k8upv1.Archive) field.ErrorList { return obj.Spec.Validate(specPath) }).
+					WithStorage(mgr.GetClient(), func(obj *k8upv1.Archive) field.ErrorList { return obj.Spec.ValidateStorage(specPath) })).
+				Complete()
+		},
+		func() error {
+			return controllerruntime.NewWebhookManagedBy(mgr, &k8upv1.Backup{}).
+				WithDefaulter(NewDefaulter(DefaultBackup)).
+				WithValidator(NewValidator(k8upv1.BackupKind, func(obj *k8upv1.Backup) field.ErrorList { return obj.Spec.Validate(specPath) }).
+					WithStorage(mgr.GetClient(), func(obj *k8upv1.Backup) field.ErrorList { return obj.Spec.ValidateStorage(specPath) })).
+				Complete()
+		},
+		func() error {
+			return controllerruntime.NewWebhookManagedBy(mgr, &k8upv1.Check{}).
+				WithDefaulter(NewDefaulter(DefaultCheck)).
+				WithValidator(NewValidator(k8upv1.CheckKind, func(obj *k8upv1.Check) field.ErrorList { return obj.Spec.Validate(specPath) }).
+					WithStorage(mgr.GetClient(), func(obj *k8upv1.Check) field.ErrorList { return obj.Spec.ValidateStorage(specPath) })).
+				Complete()
+		},
+		func() error {
+			return controllerruntime.NewWebhookManagedBy(mgr, &k8upv1.ClusterRepository{}).
+				WithValidator(NewValidator(k8upv1.ClusterRepositoryKind, func(obj *k8upv1.ClusterRepository) field.ErrorList { return obj.Spec.Validate(specPath) })).
+				Complete()
+		},
+		func() error {
+			return controllerruntime.NewWebhookManagedBy(mgr, &k8upv1.Copy{}).
+				WithDefaulter(NewDefaulter(DefaultCopy)).
+				WithValidator(NewValidator(k8upv1.CopyKind, func(obj *k8upv1.Copy) field.ErrorList { return obj.Spec.Validate(specPath) }).
+					WithStorage(mgr.GetClient(), func(obj *k8upv1.Copy) field.ErrorList { return obj.Spec.ValidateStorage(specPath) })).
+				Complete()
+		},
+		func() error {
+			return controllerruntime.NewWebhookManagedBy(mgr, &k8upv1.Maintenance{}).
+				WithDefaulter(NewDefaulter(DefaultMaintenance)).
+				WithValidator(NewValidator(k8upv1.MaintenanceKind, func(obj *k8upv1.Maintenance) field.ErrorList { return obj.Spec.Validate(specPath) }).
+					WithStorage(mgr.GetClient(), func(obj *k8upv1.Maintenance) field.ErrorList { return obj.Spec.ValidateStorage(specPath) })).
+				Complete()
+		},
+		func() error {
+			return controllerruntime.NewWebhookManagedBy(mgr, &k8upv1.Prune{}).
+				WithDefaulter(NewDefaulter(DefaultPrune)).
+				WithValidator(NewValidator(k8upv1.PruneKind, func(obj *k8upv1.Prune) field.ErrorList { return obj.Spec.Validate(specPath) }).
+					WithStorage(mgr.GetClient(), func(obj *k8upv1.Prune) field.ErrorList { return obj.Spec.ValidateStorage(specPath) })).
+				Complete()
+		},
+		func() error {
+			return controllerruntime.NewWebhookManagedBy(mgr, &k8upv1.Repository{}).
+				WithValidator(NewValidator(k8upv1.RepositoryKind, func(obj *k8upv1.Repository) field.ErrorList { return obj.Spec.Validate(specPath) })).
+				Complete()
+		},
+		func() error {
+			return controllerruntime.NewWebhookManagedBy(mgr, &k8upv1.RepositoryKey{}).
+				WithDefaulter(NewDefaulter(DefaultRepositoryKey)).
+				WithValidator(NewValidator(k8upv1.RepositoryKeyKind, func(obj *k8upv1.RepositoryKey) field.ErrorList { return obj.Spec.Validate(specPath) }).
+					WithStorage(mgr.GetClient(), func(obj *k8upv1.RepositoryKey) field.ErrorList { return obj.Spec.ValidateStorage(specPath) })).
+				Complete()
+		},
+		func() error {
+			return controllerruntime.NewWebhookManagedBy(mgr, &k8upv1.Restore{}).
+				WithDefaulter(NewDefaulter(DefaultRestore)).
+				WithValidator(NewValidator(k8upv1.RestoreKind, func(obj *k8upv1.Restore) field.ErrorList { return obj.Spec.Validate(specPath) }).
+					WithStorage(mgr.GetClient(), func(obj *k8upv1.Restore) field.ErrorList { return obj.Spec.ValidateStorage(specPath) })).
+				Complete()
+		},
+		func() error {
+			return controllerruntime.NewWebhookManagedBy(mgr, &k8upv1.Schedule{}).
+				WithDefaulter(NewDefaulter(DefaultSchedule)).
+				WithValidator(NewValidator(k8upv1.ScheduleKind, func(obj *k8upv1.Schedule) field.ErrorList { return obj.Spec.Validate(specPath) }).
+					WithStorage(mgr.GetClient(), func(obj *k8upv1.Schedule) field.ErrorList { return obj.Spec.ValidateStorage(specPath) })).
+				Complete()
+		},
+	} {
+		if err := setupFn(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package webhooks
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/cfg"
+	"github.com/k8up-io/k8up/v2/operator/job"
+)
+
+// Validator validates k8up objects of type T when they are created or updated.
+type Validator[T client.Object] struct {
+	kind            string
+	validate        func(obj T) field.ErrorList
+	client          client.Client
+	validateStorage func(obj T) field.ErrorList
+}
+
+// NewValidator returns a new Validator that rejects objects of the given kind if validate returns any errors.
+func NewValidator[T client.Object](kind string, validate func(obj T) field.ErrorList) *Validator[T] {
+	return &Validator[T]{kind: kind, validate: validate}
+}
+
+// WithStorage makes the Validator also reject objects for which validateStorage returns errors about backends without storage type,
+// unless a ClusterRepository or the global repository of the operator applies to the object's namespace and completes them.
+func (v *Validator[T]) WithStorage(c client.Client, validateStorage func(obj T) field.ErrorList) *Validator[T] {
+	v.client = c
+	v.validateStorage = validateStorage
+	return v
+}
+
+// ValidateCreate implements admission.Validator.
+func (v *Validator[T]) ValidateCreate(ctx context.Context, obj T) (admission.Warnings, error) {
+	return nil, v.validateObject(ctx, obj)
+}
+
+// ValidateUpdate implements admission.Validator.
+// Objects that are being deleted are not validated, so that finalizers can still be removed from invalid objects.
+func (v *Validator[T]) ValidateUpdate(ctx context.Context, _, newObj T) (admission.Warnings, error) {
+	if !newObj.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+	return nil, v.validateObject(ctx, newObj)
+}
+
+// ValidateDelete implements admission.Validator.
+func (v *Validator[T]) ValidateDelete(_ context.Context, _ T) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *Validator[T]) validateObject(ctx context.Context, obj T) error {
+	allErrs := v.validate(obj)
+	if v.validateStorage == nil || cfg.Config.HasGlobalRepository() {
+		return v.toError(obj, allErrs)
+	}
+	storageErrs := v.validateStorage(obj)
+	if len(storageErrs) == 0 {
+		return v.toError(obj, allErrs)
+	}
+	clusterRepository, err := job.FindClusterRepository(ctx, v.client, obj.GetNamespace())
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	if clusterRepository == nil || clusterRepository.Spec.Backend == nil {
+		allErrs = append(allErrs, storageErrs...)
+	}
+	return v.toError(obj, allErrs)
+}
+
+func (v *Validator[T]) toError(obj T, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(k8upv1.GroupVersion.WithKind(v.kind).GroupKind(), obj.GetName(), errs)
+}
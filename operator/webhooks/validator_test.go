@@ -0,0 +1,112 @@
+package webhooks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/cfg"
+)
+
+func newRestoreValidator() *Validator[*k8upv1.Restore] {
+	return NewValidator(k8upv1.RestoreKind, func(obj *k8upv1.Restore) field.ErrorList {
+		return obj.Spec.Validate(field.NewPath("spec"))
+	})
+}
+
+func TestValidator_ValidateCreate(t *testing.T) {
+	v := newRestoreValidator()
+
+	_, err := v.ValidateCreate(context.TODO(), &k8upv1.Restore{
+		ObjectMeta: metav1.ObjectMeta{Name: "restore"},
+		Spec:       k8upv1.RestoreSpec{RestoreMethod: &k8upv1.RestoreMethod{S3: &k8upv1.S3Spec{}}},
+	})
+	assert.NoError(t, err)
+
+	_, err = v.ValidateCreate(context.TODO(), &k8upv1.Restore{ObjectMeta: metav1.ObjectMeta{Name: "restore"}})
+	require.Error(t, err)
+	assert.True(t, apierrors.IsInvalid(err))
+	assert.Equal(t, `Restore.k8up.io "restore" is invalid: spec.restoreMethod: Required value: one of s3 or folder has to be configured`, err.Error())
+}
+
+func TestValidator_ValidateUpdate(t *testing.T) {
+	v := newRestoreValidator()
+	invalid := &k8upv1.Restore{ObjectMeta: metav1.ObjectMeta{Name: "restore"}}
+
+	_, err := v.ValidateUpdate(context.TODO(), invalid, invalid)
+	assert.True(t, apierrors.IsInvalid(err))
+
+	deleting := invalid.DeepCopy()
+	now := metav1.Now()
+	deleting.DeletionTimestamp = &now
+	_, err = v.ValidateUpdate(context.TODO(), invalid, deleting)
+	assert.NoError(t, err, "objects being deleted should not be validated")
+}
+
+func TestValidator_WithStorage(t *testing.T) {
+	tests := map[string]struct {
+		givenObjects       []client.Object
+		givenGlobalBucket  string
+		expectedErrMessage string
+	}{
+		"GivenNoClusterRepositoryAndNoGlobalRepository_ThenExpectRequiredError": {
+			expectedErrMessage: `Backup.k8up.io "backup" is invalid: spec.backend: Required value: exactly one of azure, b2, gcs, local, rest, s3, swift, sftp, rclone has to be configured, no ClusterRepository or global repository applies to the namespace`,
+		},
+		"GivenGlobalRepository_ThenExpectNoError": {
+			givenGlobalBucket: "backups",
+		},
+		"GivenClusterRepositorySelectingNamespace_ThenExpectNoError": {
+			givenObjects: []client.Object{&k8upv1.ClusterRepository{
+				ObjectMeta: metav1.ObjectMeta{Name: "default"},
+				Spec:       k8upv1.ClusterRepositorySpec{Backend: &k8upv1.Backend{S3: &k8upv1.S3Spec{Bucket: "backups"}}},
+			}},
+		},
+		"GivenClusterRepositoryNotSelectingNamespace_ThenExpectRequiredError": {
+			givenObjects: []client.Object{&k8upv1.ClusterRepository{
+				ObjectMeta: metav1.ObjectMeta{Name: "team-b"},
+				Spec: k8upv1.ClusterRepositorySpec{
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}},
+					Backend:           &k8upv1.Backend{S3: &k8upv1.S3Spec{Bucket: "backups"}},
+				},
+			}},
+			expectedErrMessage: `Backup.k8up.io "backup" is invalid: spec.backend: Required value: exactly one of azure, b2, gcs, local, rest, s3, swift, sftp, rclone has to be configured, no ClusterRepository or global repository applies to the namespace`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			bucket := cfg.Config.GlobalS3Bucket
+			defer func() { cfg.Config.GlobalS3Bucket = bucket }()
+			cfg.Config.GlobalS3Bucket = tt.givenGlobalBucket
+
+			scheme := runtime.NewScheme()
+			require.NoError(t, clientgoscheme.AddToScheme(scheme))
+			require.NoError(t, k8upv1.AddToScheme(scheme))
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app", Labels: map[string]string{"team": "a"}}}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(tt.givenObjects, ns)...).Build()
+
+			specPath := field.NewPath("spec")
+			v := NewValidator(k8upv1.BackupKind, func(obj *k8upv1.Backup) field.ErrorList { return obj.Spec.Validate(specPath) }).
+				WithStorage(c, func(obj *k8upv1.Backup) field.ErrorList { return obj.Spec.ValidateStorage(specPath) })
+
+			_, err := v.ValidateCreate(context.TODO(), &k8upv1.Backup{ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "app"}})
+			if tt.expectedErrMessage == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.True(t, apierrors.IsInvalid(err))
+			assert.Equal(t, tt.expectedErrMessage, err.Error())
+		})
+	}
+}
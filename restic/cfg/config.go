@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
 )
 
 const (
@@ -29,6 +31,13 @@ type Configuration struct {
 	DoPrune   bool
 	DoRestore bool
 	DoArchive bool
+	DoInit    bool
+	// DoRotateKey replaces the key of the repository password with a key of the password in NewPasswordFile.
+	DoRotateKey bool
+	// DoCopy copies the snapshots of the repository in RESTIC_FROM_REPOSITORY into the repository.
+	DoCopy bool
+	// Maintenance is the operation that repairs or upgrades the repository, e.g. 'repair-index'.
+	Maintenance string
 
 	BackupCommandAnnotation       string
 	BackupFileExtensionAnnotation string
@@ -37,6 +46,9 @@ type Configuration struct {
 
 	SkipPreBackup    bool
 	SkipSnapshotSync bool
+	FailOnReadErrors bool
+	BackupName       string
+	ScheduleName     string
 
 	PromURL     string
 	ClusterName string
@@ -45,6 +57,8 @@ type Configuration struct {
 	Hostname   string
 	KubeConfig string
 
+	TerminationMessagePath string
+
 	ResticBin        string
 	ResticRepository string
 	ResticOptions    string
@@ -91,18 +105,40 @@ type Configuration struct {
 	PruneKeepWithinWeekly  string
 	PruneKeepWithinMonthly string
 	PruneKeepWithinYearly  string
+	PruneGroupBy           string
 
 	Tags  []string
 	Paths []string
+	Hosts []string
 
 	TargetPods []string
 
 	SleepDuration time.Duration
+	SkipInit      bool
+
+	NewPasswordFile string
+
+	// ReadAllPacks reads all pack files when the index is rebuilt by a maintenance operation.
+	ReadAllPacks bool
+	// Forget removes the original snapshots once they're replaced by a maintenance operation.
+	Forget bool
+	// Packs are the IDs of the pack files that a maintenance operation repairs.
+	Packs []string
+
+	// ReadData reads all data of the repository when checking it.
+	ReadData bool
+	// ReadDataSubset is the subset of the data of the repository that is read when checking it, e.g. '1/7' or '10%'.
+	ReadDataSubset string
+	// WithCache checks the repository using the cache instead of a temporary one.
+	WithCache bool
 
 	VarDir                           string
 	CACert                           string
 	ClientCert                       string
 	ClientKey                        string
+	SFTPPrivateKey                   string
+	SFTPKnownHosts                   string
+	SFTPCommand                      string
 	InsecureAllowPodExecSPDYFallback bool
 }
 
@@ -114,6 +150,12 @@ func (c *Configuration) Validate() error {
 	if err := c.validatePrune(); err != nil {
 		return err
 	}
+	if c.DoRotateKey && c.NewPasswordFile == "" {
+		return fmt.Errorf("the new password file must be defined to rotate the repository key")
+	}
+	if c.DoCheck && c.ReadData && c.ReadDataSubset != "" {
+		return fmt.Errorf("the arguments readData and readDataSubset can't be used together")
+	}
 
 	return nil
 }
@@ -149,13 +191,16 @@ func (c *Configuration) validatePrune() error {
 		if val == "" {
 			continue
 		}
-		d, err := time.ParseDuration(val)
-		if err != nil {
-			return fmt.Errorf("the duration '%s' of the argument %s is not valid: %w", val, arg, err)
-		}
-		if d <= 0 {
+		if strings.HasPrefix(val, "-") {
 			return fmt.Errorf("the duration '%s' of the argument %s must not be negative", val, arg)
 		}
+		if !k8upv1.KeepWithinPattern.MatchString(val) {
+			return fmt.Errorf("the duration '%s' of the argument %s is not valid, it has to consist of years, months, days and hours like '2y5m7d3h'", val, arg)
+		}
+	}
+
+	if unknown := k8upv1.UnknownGroupByOptions(c.PruneGroupBy); len(unknown) > 0 {
+		return fmt.Errorf("the argument groupBy contains the unknown option '%s', it has to be a comma separated list of 'host', 'paths' and 'tags'", unknown[0])
 	}
 	return nil
 }
@@ -64,6 +64,33 @@ func TestValidatePrune_NegativeKeepWithinDuration(t *testing.T) {
 	assert.Contains(t, err.Error(), "must not be negative")
 }
 
+func TestValidatePrune_KeepWithinOfYearsMonthsAndDays(t *testing.T) {
+	c := &Configuration{
+		DoPrune:               true,
+		PruneKeepWithinDaily:  "7d",
+		PruneKeepWithinYearly: "2y5m7d3h",
+	}
+	assert.NoError(t, c.Validate())
+}
+
+func TestValidatePrune_ValidGroupBy(t *testing.T) {
+	c := &Configuration{
+		DoPrune:      true,
+		PruneGroupBy: "host,tags",
+	}
+	assert.NoError(t, c.Validate())
+}
+
+func TestValidatePrune_UnknownGroupBy(t *testing.T) {
+	c := &Configuration{
+		DoPrune:      true,
+		PruneGroupBy: "host,namespace",
+	}
+	err := c.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown option 'namespace'")
+}
+
 func TestValidatePrune_EmptyKeepWithinSkipped(t *testing.T) {
 	c := &Configuration{
 		DoPrune:         true,
@@ -163,3 +190,14 @@ func TestValidateRestore_TypeCaseInsensitive(t *testing.T) {
 	}
 	assert.NoError(t, c.Validate())
 }
+
+func TestValidateCheck_ReadDataAndSubset(t *testing.T) {
+	c := &Configuration{
+		DoCheck:        true,
+		ReadData:       true,
+		ReadDataSubset: "1/7",
+	}
+	err := c.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "readDataSubset")
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-logr/logr"
 
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
 	"github.com/k8up-io/k8up/v2/restic/cfg"
 	"github.com/k8up-io/k8up/v2/restic/kubernetes"
 	"github.com/k8up-io/k8up/v2/restic/logging"
@@ -79,7 +80,11 @@ func mixinBackupFlags(flags Flags) Flags {
 
 func (r *Restic) folderBackup(folder string, backuplogger logr.Logger, tags ArrayOpts) error {
 
-	outputWriter := r.newParseBackupOutput(backuplogger, folder)
+	status := k8upv1.VolumeBackupStatus{Name: path.Base(folder)}
+	outputWriter := r.newParseBackupOutput(backuplogger, folder, func(summary logging.BackupSummary, errorCount int, folder string, startTimestamp, endTimestamp int64) {
+		status.BackupRunStatus = newBackupRunStatus(summary, errorCount)
+		r.sendBackupStats(summary, errorCount, folder, startTimestamp, endTimestamp)
+	})
 
 	backuplogger.Info("starting backup for folder", "foldername", path.Base(folder))
 
@@ -97,17 +102,21 @@ func (r *Restic) folderBackup(folder string, backuplogger logr.Logger, tags Arra
 		StdErr: outputWriter,
 	}
 
-	return r.triggerBackup(backuplogger, tags, opts, nil)
+	err := r.triggerBackup(backuplogger, tags, opts, nil)
+	finishBackupRun(&status.BackupRunStatus, err)
+	r.backupResults.Volumes = append(r.backupResults.Volumes, status)
+	return ignoreIncomplete(err)
 }
 
-func (r *Restic) newParseBackupOutput(log logr.Logger, folder string) io.Writer {
+func (r *Restic) newParseBackupOutput(log logr.Logger, folder string, summaryFunc logging.SummaryFunc) io.Writer {
 
 	progressLogger := log.WithName("progress")
 
-	return logging.NewBackupOutputParser(progressLogger, folder, r.sendBackupStats)
+	return logging.NewBackupOutputParser(progressLogger, folder, summaryFunc)
 }
 
 func (r *Restic) sendBackupStats(summary logging.BackupSummary, errorCount int, folder string, _, _ int64) {
+	r.recordSnapshotStats(summary)
 
 	metrics := r.parseSummary(summary, errorCount, folder, 1, time.Now().Unix())
 
@@ -127,6 +136,23 @@ func (r *Restic) sendBackupStats(summary logging.BackupSummary, errorCount int,
 	}
 }
 
+// recordSnapshotStats keeps the statistics of the snapshot described by the given summary for the Snapshot resource.
+func (r *Restic) recordSnapshotStats(summary logging.BackupSummary) {
+	if summary.SnapshotID == "" {
+		return
+	}
+	if r.snapshotStats == nil {
+		r.snapshotStats = map[string]k8upv1.SnapshotStatus{}
+	}
+	r.snapshotStats[summary.SnapshotID] = k8upv1.SnapshotStatus{
+		FilesNew:     summary.FilesNew,
+		FilesChanged: summary.FilesChanged,
+		BytesAdded:   summary.DataAdded,
+		TotalFiles:   summary.TotalFilesProcessed,
+		TotalBytes:   int64(summary.TotalBytesProcessed),
+	}
+}
+
 // sendSnapshotList sends the current list of snapshots to a webhook and the k8s cluster
 func (r *Restic) sendSnapshotList() {
 	err := r.Snapshots(nil, nil)
@@ -149,7 +175,12 @@ func (r *Restic) sendSnapshotList() {
 		return
 	}
 
-	err = kubernetes.SyncSnapshotList(r.ctx, r.snapshots, cfg.Config.Hostname, cfg.Config.ResticRepository, r.logger)
+	origin := kubernetes.SnapshotOrigin{
+		BackupName:   cfg.Config.BackupName,
+		ScheduleName: cfg.Config.ScheduleName,
+		Statistics:   r.snapshotStats,
+	}
+	err = kubernetes.SyncSnapshotList(r.ctx, r.snapshots, origin, cfg.Config.Hostname, cfg.Config.ResticRepository, r.logger)
 	if err != nil {
 		r.logger.Error(err, "cannot sync snapshots to the cluster")
 	}
@@ -167,5 +198,8 @@ func (r *Restic) triggerBackup(logger logr.Logger, tags ArrayOpts, opts CommandO
 
 	cmd.Wait()
 
+	if cmd.FatalError == nil && cmd.Incomplete {
+		return ErrIncompleteBackup
+	}
 	return cmd.FatalError
 }
@@ -1,25 +1,248 @@
 package cli
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/restic/cfg"
 	"github.com/k8up-io/k8up/v2/restic/logging"
 )
 
-// Check will check the repository for errors
-func (r *Restic) Check() error {
+// maxReportedDamagedPacks limits the damaged packs in the results, as the termination message of a container is limited to 4096 bytes.
+const maxReportedDamagedPacks = 20
+
+var (
+	// damagedPackPattern matches the errors restic prints for pack files, e.g. 'pack 1a2b...: file not found' or 'pack 1a2b... contains 2 errors'.
+	damagedPackPattern = regexp.MustCompile(`^pack ([0-9a-f]+)(?::| contains)`)
+	// additionalPacksPattern matches the note restic prints about the pack files that no index refers to.
+	additionalPacksPattern = regexp.MustCompile(`^(\d+) additional files were found in the repo`)
+	// repairPacksPattern matches the command restic suggests to repair the damaged pack files.
+	repairPacksPattern = regexp.MustCompile(`^restic repair packs ([0-9a-f ]+)$`)
+	// authenticationErrors are parts of the errors of storage backends that refuse the credentials, in lower case.
+	authenticationErrors = []string{"wrong password", "access denied", "accessdenied", "invalidaccesskeyid", "signaturedoesnotmatch", "unauthorized", "forbidden"}
+)
+
+// CheckOptions define how thoroughly the repository is checked.
+// Without options, only the structure of the repository is checked.
+type CheckOptions struct {
+	// ReadData reads all data of the repository.
+	ReadData bool
+	// ReadDataSubset reads the given subset of the data, e.g. '1/7', '10%' or '500M'.
+	ReadDataSubset string
+	// WithCache uses the cache instead of a temporary one.
+	WithCache bool
+}
+
+// Check will check the repository for errors.
+// The output of restic is parsed into the results that are reported to the operator, and the number of errors is sent to Prometheus.
+func (r *Restic) Check(options CheckOptions) error {
 	checkLogger := r.logger.WithName("check")
 
-	checkLogger.Info("checking repository")
+	checkLogger.Info("checking repository", "readData", options.ReadData, "readDataSubset", options.ReadDataSubset)
 
 	resticCheckLogger := checkLogger.WithName("restic")
+	parser := &checkOutputParser{log: resticCheckLogger}
 	opts := CommandOptions{
 		Path:   r.resticPath,
-		Args:   r.globalFlags.ApplyToCommand("check"),
-		StdOut: logging.NewInfoWriter(resticCheckLogger),
-		StdErr: logging.NewErrorWriter(resticCheckLogger),
+		Args:   r.globalFlags.ApplyToCommand("check", options.args()...),
+		StdOut: logging.New(parser.out),
+		StdErr: logging.New(parser.err),
 	}
 
 	cmd := NewCommand(r.ctx, checkLogger, opts)
 	cmd.Run()
 
+	r.checkResults = parser.results(cmd.FatalError)
+	checkLogger.Info("checked repository", "successful", *r.checkResults.Successful, "errors", r.checkResults.ErrorCount, "reason", r.checkResults.FailureReason)
+
+	stats := &CheckStats{Errors: r.checkResults.ErrorCount, hostname: cfg.Config.Hostname}
+	if cmd.FatalError == nil {
+		stats.LastSuccess = time.Now()
+	}
+	if err := r.statsHandler.SendPrometheus(stats); err != nil {
+		r.logger.Error(err, "prometheus send failed")
+	}
+
 	return cmd.FatalError
 }
+
+// RecordCheckFailure records that the repository couldn't be checked, as opening it failed with the given error.
+func (r *Restic) RecordCheckFailure(err error) {
+	successful := false
+	r.checkResults = k8upv1.CheckResults{Successful: &successful, FailureReason: exitCodeReason(err)}
+}
+
+// WriteCheckResults writes the results of Check as JSON into the given file.
+// Kubernetes reads the termination message of the container from this file, the operator copies it into the Check's status.
+// If the file doesn't exist, the process doesn't run in Kubernetes and nothing is written.
+// Nothing is written either if the check didn't run.
+func (r *Restic) WriteCheckResults(path string) error {
+	if r.checkResults.Successful == nil {
+		return nil
+	}
+	if _, err := os.Stat(path); err != nil {
+		r.logger.V(1).Info("not writing check results, no termination message file", "path", path)
+		return nil
+	}
+	message, err := json.Marshal(r.checkResults)
+	if err != nil {
+		return fmt.Errorf("cannot encode check results: %w", err)
+	}
+	return os.WriteFile(path, message, 0644)
+}
+
+func (o CheckOptions) args() []string {
+	var args []string
+	if o.ReadData {
+		args = append(args, "--read-data")
+	}
+	if o.ReadDataSubset != "" {
+		args = append(args, "--read-data-subset", o.ReadDataSubset)
+	}
+	if o.WithCache {
+		args = append(args, "--with-cache")
+	}
+	return args
+}
+
+// exitCodeReason returns the reason of a failure that restic reported by its exit code, or an empty reason.
+func exitCodeReason(err error) k8upv1.ConditionReason {
+	exitErr := &ExitError{}
+	if !errors.As(err, &exitErr) {
+		return ""
+	}
+	switch exitErr.Code {
+	case ExitCodeLocked:
+		return k8upv1.ReasonRepositoryLocked
+	case ExitCodeWrongPassword:
+		return k8upv1.ReasonAuthenticationFailed
+	}
+	return ""
+}
+
+// checkOutputParser logs the output of 'restic check' and collects the errors restic reports.
+type checkOutputParser struct {
+	log               logr.Logger
+	errorCount        int
+	damagedPacks      []string
+	unreferencedPacks int
+	additionalPacks   int
+	locked            bool
+	authentication    bool
+}
+
+func (c *checkOutputParser) out(s string) {
+	c.parse(s)
+	c.log.WithName("stdout").Info(s)
+}
+
+func (c *checkOutputParser) err(s string) {
+	c.parse(s)
+	c.log.WithName("stderr").Info(s)
+}
+
+func (c *checkOutputParser) parse(s string) {
+	line := strings.TrimSpace(s)
+	lower := strings.ToLower(line)
+	switch {
+	case strings.HasSuffix(line, "not referenced in any index"):
+		c.unreferencedPacks++
+	case damagedPackPattern.MatchString(line):
+		c.errorCount++
+		c.addDamagedPacks(damagedPackPattern.FindStringSubmatch(line)[1])
+	case repairPacksPattern.MatchString(line):
+		c.addDamagedPacks(strings.Fields(repairPacksPattern.FindStringSubmatch(line)[1])...)
+	case additionalPacksPattern.MatchString(line):
+		c.additionalPacks, _ = strconv.Atoi(additionalPacksPattern.FindStringSubmatch(line)[1])
+	case strings.HasPrefix(lower, "error"):
+		c.errorCount++
+	case strings.HasPrefix(lower, "fatal:"):
+		c.locked = c.locked || strings.Contains(lower, "locked")
+		for _, authError := range authenticationErrors {
+			c.authentication = c.authentication || strings.Contains(lower, authError)
+		}
+	}
+}
+
+func (c *checkOutputParser) addDamagedPacks(ids ...string) {
+	for _, id := range ids {
+		if !slices.Contains(c.damagedPacks, id) {
+			c.damagedPacks = append(c.damagedPacks, id)
+		}
+	}
+}
+
+// results returns the results of the check that finished with the given error.
+// The reason of a failure is the exit code of restic, or otherwise the errors restic printed.
+func (c *checkOutputParser) results(err error) k8upv1.CheckResults {
+	successful := err == nil
+	results := k8upv1.CheckResults{
+		Successful:        &successful,
+		ErrorCount:        c.errorCount,
+		DamagedPacks:      c.damagedPacks,
+		UnreferencedPacks: max(c.unreferencedPacks, c.additionalPacks),
+	}
+	if len(results.DamagedPacks) > maxReportedDamagedPacks {
+		results.DamagedPacks = results.DamagedPacks[:maxReportedDamagedPacks]
+	}
+	if successful {
+		return results
+	}
+	results.FailureReason = exitCodeReason(err)
+	switch {
+	case results.FailureReason != "":
+	case c.errorCount > 0 || len(c.damagedPacks) > 0:
+		results.FailureReason = k8upv1.ReasonRepositoryDamaged
+	case c.locked:
+		results.FailureReason = k8upv1.ReasonRepositoryLocked
+	case c.authentication:
+		results.FailureReason = k8upv1.ReasonAuthenticationFailed
+	}
+	return results
+}
+
+// CheckStats are the metrics of a check that are sent to Prometheus.
+type CheckStats struct {
+	// Errors is the number of errors that restic reported.
+	Errors int
+	// LastSuccess is the time the check succeeded, it's zero if the check failed.
+	LastSuccess time.Time
+	hostname    string
+}
+
+// ToProm returns the metrics of the check.
+// The time of the last success is only sent for successful checks, so that the pushgateway keeps the previous time otherwise.
+func (c *CheckStats) ToProm() []prometheus.Collector {
+	labels := []string{"namespace"}
+	errorsGauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: prometheusNamespace,
+		Subsystem: "check",
+		Name:      "errors",
+		Help:      "How many errors the last check found in the repository",
+	}, labels)
+	errorsGauge.WithLabelValues(c.hostname).Set(float64(c.Errors))
+	collectors := []prometheus.Collector{errorsGauge}
+
+	if !c.LastSuccess.IsZero() {
+		lastSuccessGauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: prometheusNamespace,
+			Subsystem: "check",
+			Name:      "last_success_timestamp",
+			Help:      "Unix timestamp of the last check that found no errors in the repository",
+		}, labels)
+		lastSuccessGauge.WithLabelValues(c.hostname).Set(float64(c.LastSuccess.Unix()))
+		collectors = append(collectors, lastSuccessGauge)
+	}
+	return collectors
+}
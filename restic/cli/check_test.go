@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+)
+
+func TestCheckOptions_args(t *testing.T) {
+	tests := map[string]struct {
+		givenOptions CheckOptions
+		expectedArgs []string
+	}{
+		"GivenNoOptions_ThenExpectNoArgs": {},
+		"GivenReadData_ThenExpectReadDataFlag": {
+			givenOptions: CheckOptions{ReadData: true},
+			expectedArgs: []string{"--read-data"},
+		},
+		"GivenSubsetWithCache_ThenExpectSubsetAndCacheFlags": {
+			givenOptions: CheckOptions{ReadDataSubset: "2/7", WithCache: true},
+			expectedArgs: []string{"--read-data-subset", "2/7", "--with-cache"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedArgs, tt.givenOptions.args())
+		})
+	}
+}
+
+func TestCheckOutputParser_results(t *testing.T) {
+	tests := map[string]struct {
+		givenOutput          []string
+		givenError           error
+		expectedSuccessful   bool
+		expectedErrors       int
+		expectedPacks        []string
+		expectedUnreferenced int
+		expectedReason       k8upv1.ConditionReason
+	}{
+		"GivenNoErrors_ThenExpectSuccess": {
+			givenOutput:        []string{"using temporary cache in /tmp/restic-check-cache", "check snapshots, trees and blobs", "no errors were found"},
+			expectedSuccessful: true,
+		},
+		"GivenUnreferencedPacks_ThenExpectSuccessWithUnreferencedPacks": {
+			givenOutput: []string{
+				"pack 1a2b3c4d: not referenced in any index",
+				"pack 5e6f7a8b: not referenced in any index",
+				"2 additional files were found in the repo, which likely contain duplicate data.",
+				"no errors were found",
+			},
+			expectedSuccessful:   true,
+			expectedUnreferenced: 2,
+		},
+		"GivenDamagedPacks_ThenExpectRepositoryDamaged": {
+			givenOutput: []string{
+				"pack 1a2b3c4d: file not found",
+				"pack 5e6f7a8b contains 2 errors: [blob 9c0d: decrypting blob failed]",
+				"error: load <tree/3f2a1b0c>: file not found",
+				"restic repair packs 1a2b3c4d 5e6f7a8b 7b8c9d0e",
+				"Fatal: repository contains errors",
+			},
+			givenError:     &ExitError{Code: 1},
+			expectedErrors: 3,
+			expectedPacks:  []string{"1a2b3c4d", "5e6f7a8b", "7b8c9d0e"},
+			expectedReason: k8upv1.ReasonRepositoryDamaged,
+		},
+		"GivenLockedExitCode_ThenExpectRepositoryLocked": {
+			givenOutput:    []string{"repo already locked, waiting up to 0s for the lock", "unable to create lock in backend: repository is already locked by PID 42"},
+			givenError:     &ExitError{Code: ExitCodeLocked},
+			expectedReason: k8upv1.ReasonRepositoryLocked,
+		},
+		"GivenWrongPasswordExitCode_ThenExpectAuthenticationFailed": {
+			givenOutput:    []string{"Fatal: wrong password or no key found"},
+			givenError:     &ExitError{Code: ExitCodeWrongPassword},
+			expectedReason: k8upv1.ReasonAuthenticationFailed,
+		},
+		"GivenAccessDenied_ThenExpectAuthenticationFailed": {
+			givenOutput:    []string{"Fatal: unable to open config file: Stat: Access Denied."},
+			givenError:     &ExitError{Code: 1},
+			expectedReason: k8upv1.ReasonAuthenticationFailed,
+		},
+		"GivenUnknownFailure_ThenExpectNoReason": {
+			givenOutput: []string{"Fatal: unable to open repository: connection refused"},
+			givenError:  errors.New("cmd.Wait() err: 1"),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			parser := &checkOutputParser{log: logr.Discard()}
+			for _, line := range tt.givenOutput {
+				parser.err(line)
+			}
+
+			results := parser.results(tt.givenError)
+
+			require.NotNil(t, results.Successful)
+			assert.Equal(t, tt.expectedSuccessful, *results.Successful)
+			assert.Equal(t, tt.expectedErrors, results.ErrorCount)
+			assert.Equal(t, tt.expectedPacks, results.DamagedPacks)
+			assert.Equal(t, tt.expectedUnreferenced, results.UnreferencedPacks)
+			assert.Equal(t, tt.expectedReason, results.FailureReason)
+		})
+	}
+}
+
+func TestCheckOutputParser_results_LimitsDamagedPacks(t *testing.T) {
+	parser := &checkOutputParser{log: logr.Discard()}
+	for i := 0; i < 2*maxReportedDamagedPacks; i++ {
+		parser.err(fmt.Sprintf("pack %08x: file not found", i))
+	}
+
+	results := parser.results(&ExitError{Code: 1})
+
+	assert.Equal(t, 2*maxReportedDamagedPacks, results.ErrorCount)
+	assert.Len(t, results.DamagedPacks, maxReportedDamagedPacks, "the results fit into the termination message")
+}
+
+func TestWriteCheckResults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "termination-log")
+	require.NoError(t, os.WriteFile(path, nil, 0644))
+
+	r := &Restic{logger: logr.Discard()}
+	require.NoError(t, r.WriteCheckResults(path))
+	written, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Empty(t, written, "nothing is written if the check didn't run")
+
+	r.RecordCheckFailure(&ExitError{Code: ExitCodeWrongPassword})
+	require.NoError(t, r.WriteCheckResults(path))
+
+	written, err = os.ReadFile(path)
+	require.NoError(t, err)
+	results := k8upv1.CheckResults{}
+	require.NoError(t, json.Unmarshal(written, &results))
+	assert.Equal(t, r.checkResults, results)
+	assert.Equal(t, k8upv1.ReasonAuthenticationFailed, results.FailureReason)
+}
+
+func TestCheckStats_ToProm(t *testing.T) {
+	failed := &CheckStats{Errors: 3, hostname: "app"}
+	assert.Len(t, failed.ToProm(), 1, "the time of the last success is kept for failed checks")
+
+	succeeded := &CheckStats{LastSuccess: time.Now(), hostname: "app"}
+	assert.Len(t, succeeded.ToProm(), 2)
+}
@@ -20,11 +20,30 @@ type CommandOptions struct {
 	Args   []string
 }
 
+// Exit codes of restic, see https://restic.readthedocs.io/en/stable/075_scripting.html#exit-codes
+const (
+	// ExitCodeLocked is returned if restic couldn't lock the repository.
+	ExitCodeLocked = 11
+	// ExitCodeWrongPassword is returned if restic couldn't open the repository with the password.
+	ExitCodeWrongPassword = 12
+)
+
+// ExitError is the FatalError of a command that restic exited with an exit code that isn't handled otherwise.
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("cmd.Wait() err: %d", e.Code)
+}
+
 // Command can handle a given command.
 type Command struct {
 	options    CommandOptions
 	FatalError error
 	Errors     []error
+	// Incomplete is set if restic created the snapshot, but couldn't read some of the files.
+	Incomplete bool
 	cmdLogger  logr.Logger
 	ctx        context.Context
 	cmd        *exec.Cmd
@@ -118,11 +137,13 @@ func (c *Command) Wait() {
 		}
 		// ...as well as an exiterror
 		if exiterr, ok := err.(*exec.ExitError); ok {
-			// We ignore exit code 3 as this will be set if the snapshot was created but some files failed to read.
-			// This is handled by the backup summary parsing.
+			// Exit code 3 is set if the snapshot was created but some files failed to read.
+			// This isn't fatal, the unreadable files are reported by the backup summary parsing.
 			// See https://restic.readthedocs.io/en/stable/040_backup.html?highlight=exit%20code#exit-status-codes
-			if exiterr.ExitCode() != 3 {
-				c.FatalError = fmt.Errorf("cmd.Wait() err: %d", exiterr.ExitCode())
+			if exiterr.ExitCode() == 3 {
+				c.Incomplete = true
+			} else {
+				c.FatalError = &ExitError{Code: exiterr.ExitCode()}
 			}
 		} else { // if it's some other error we'd need to catch it, too
 			c.FatalError = fmt.Errorf("cmd.Wait() err: %w", err)
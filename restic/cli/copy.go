@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/go-logr/logr"
+
+	"github.com/k8up-io/k8up/v2/restic/logging"
+)
+
+// copiedSnapshotPattern matches the line restic prints for each snapshot it has copied.
+var copiedSnapshotPattern = regexp.MustCompile(`^snapshot [0-9a-f]+ saved`)
+
+// Copy copies the snapshots of the repository in RESTIC_FROM_REPOSITORY, that match the given filters, into the repository.
+// restic skips the snapshots that have been copied before.
+// The Snapshot resources of the repository are synchronized afterwards.
+func (r *Restic) Copy(tags, hosts, paths ArrayOpts) error {
+	copyLogger := r.logger.WithName("copy")
+
+	copyLogger.Info("copying snapshots", "tags", tags, "hosts", hosts, "paths", paths)
+
+	resticCopyLogger := copyLogger.WithName("restic")
+	parser := &copyOutputParser{log: resticCopyLogger}
+	opts := CommandOptions{
+		Path:   r.resticPath,
+		Args:   r.globalFlags.ApplyToCommand("copy"),
+		StdOut: logging.New(parser.out),
+		StdErr: logging.NewErrorWriter(resticCopyLogger),
+	}
+	opts.Args = append(opts.Args, tags.BuildArgs("--tag")...)
+	opts.Args = append(opts.Args, hosts.BuildArgs("--host")...)
+	opts.Args = append(opts.Args, paths.BuildArgs("--path")...)
+
+	cmd := NewCommand(r.ctx, copyLogger, opts)
+	cmd.Run()
+
+	// Snapshots that were copied before a failure stay in the repository, so they're reported in any case.
+	copied := parser.copied
+	r.copyResults.SnapshotsCopied = &copied
+	copyLogger.Info("copied snapshots", "count", copied)
+
+	if cmd.FatalError == nil {
+		r.sendSnapshotList()
+	}
+
+	return cmd.FatalError
+}
+
+// WriteCopyResults writes the results of Copy as JSON into the given file.
+// Kubernetes reads the termination message of the container from this file, the operator copies it into the Copy's status.
+// If the file doesn't exist, the process doesn't run in Kubernetes and nothing is written.
+func (r *Restic) WriteCopyResults(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		r.logger.V(1).Info("not writing copy results, no termination message file", "path", path)
+		return nil
+	}
+	message, err := json.Marshal(r.copyResults)
+	if err != nil {
+		return fmt.Errorf("cannot encode copy results: %w", err)
+	}
+	return os.WriteFile(path, message, 0644)
+}
+
+// copyOutputParser logs the output of 'restic copy' and counts the copied snapshots.
+type copyOutputParser struct {
+	log    logr.Logger
+	copied int
+}
+
+func (c *copyOutputParser) out(s string) {
+	if copiedSnapshotPattern.MatchString(s) {
+		c.copied++
+	}
+	c.log.WithName("stdout").Info(s)
+}
@@ -6,11 +6,14 @@ import (
 	"io"
 	"strings"
 
+	"github.com/k8up-io/k8up/v2/restic/cfg"
 	"github.com/k8up-io/k8up/v2/restic/logging"
 )
 
 // Init initialises a repository, checks if the repository exists and will
 // initialise it if not. It's safe to call this every time.
+// The repository of a copy gets the chunker parameters of the repository in RESTIC_FROM_REPOSITORY,
+// so that the copied data is deduplicated.
 func (r *Restic) Init() error {
 	if r.clientCert != (clientCert{}) {
 		if err := generatePemFile(r.clientCert.cert, r.clientCert.key, r.clientCert.pem); err != nil {
@@ -26,9 +29,13 @@ func (r *Restic) Init() error {
 		Writer: logging.NewErrorWriter(resticLogger),
 	}
 
+	var args []string
+	if cfg.Config.DoCopy {
+		args = append(args, "--copy-chunker-params")
+	}
 	opts := CommandOptions{
 		Path:   r.resticPath,
-		Args:   r.globalFlags.ApplyToCommand("init"),
+		Args:   r.globalFlags.ApplyToCommand("init", args...),
 		StdOut: logging.NewInfoWriter(resticLogger),
 		StdErr: initErrorCatcher,
 	}
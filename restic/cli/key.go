@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/go-logr/logr"
+
+	"github.com/k8up-io/k8up/v2/restic/logging"
+)
+
+// Key is a key of the repository as listed by 'restic key list'.
+type Key struct {
+	Current  bool   `json:"current"`
+	ID       string `json:"id"`
+	UserName string `json:"userName"`
+	HostName string `json:"hostName"`
+	Created  string `json:"created"`
+}
+
+// RotateKey replaces the key of the current password with a key of the password in the given file.
+// The key of the current password is only removed once the new password has been verified to open the repository.
+func (r *Restic) RotateKey(newPasswordFile string) error {
+	keyLogger := r.logger.WithName("key")
+
+	current, err := r.currentKey(keyLogger, "")
+	if err != nil {
+		return fmt.Errorf("cannot determine the key of the current password: %w", err)
+	}
+	keyLogger.Info("adding key for the new password", "currentKey", current.ID)
+	if err := r.runKeyCommand(keyLogger, "", "add", "--new-password-file", newPasswordFile); err != nil {
+		return fmt.Errorf("cannot add key for the new password: %w", err)
+	}
+
+	added, err := r.currentKey(keyLogger, newPasswordFile)
+	if err != nil {
+		return fmt.Errorf("cannot open the repository with the new password: %w", err)
+	}
+	if added.ID == current.ID {
+		return fmt.Errorf("the new password opens the key %q of the current password, it has to be different from the current password", current.ID)
+	}
+	r.keyResults.KeyID = added.ID
+
+	keyLogger.Info("removing key of the current password", "key", current.ID, "newKey", added.ID)
+	if err := r.runKeyCommand(keyLogger, newPasswordFile, "remove", current.ID); err != nil {
+		return fmt.Errorf("cannot remove the key %q of the current password: %w", current.ID, err)
+	}
+	r.keyResults.RemovedKeyID = current.ID
+	return nil
+}
+
+// ListKeys returns the keys of the repository.
+// If passwordFile is set, the repository is opened with the password in this file instead of the configured password.
+func (r *Restic) ListKeys(passwordFile string) ([]Key, error) {
+	return r.listKeys(r.logger.WithName("key"), passwordFile)
+}
+
+func (r *Restic) listKeys(log logr.Logger, passwordFile string) ([]Key, error) {
+	buf := &bytes.Buffer{}
+	opts := CommandOptions{
+		Path:   r.resticPath,
+		Args:   r.keyFlags(passwordFile).ApplyToCommand("key", "list", "--json"),
+		StdOut: buf,
+		StdErr: logging.NewErrorWriter(log.WithName("restic")),
+	}
+	cmd := NewCommand(r.ctx, log, opts)
+	cmd.Run()
+	if cmd.FatalError != nil {
+		return nil, cmd.FatalError
+	}
+
+	keys := []Key{}
+	if err := json.NewDecoder(buf).Decode(&keys); err != nil {
+		return nil, fmt.Errorf("cannot decode the list of keys: %w", err)
+	}
+	return keys, nil
+}
+
+// currentKey returns the key that the given password file opens, see ListKeys.
+func (r *Restic) currentKey(log logr.Logger, passwordFile string) (Key, error) {
+	keys, err := r.listKeys(log, passwordFile)
+	if err != nil {
+		return Key{}, err
+	}
+	for _, key := range keys {
+		if key.Current {
+			return key, nil
+		}
+	}
+	return Key{}, fmt.Errorf("restic didn't mark any of the %d keys as current", len(keys))
+}
+
+func (r *Restic) runKeyCommand(log logr.Logger, passwordFile string, args ...string) error {
+	opts := CommandOptions{
+		Path:   r.resticPath,
+		Args:   r.keyFlags(passwordFile).ApplyToCommand("key", args...),
+		StdOut: logging.NewInfoWriter(log.WithName("restic")),
+		StdErr: logging.NewErrorWriter(log.WithName("restic")),
+	}
+	cmd := NewCommand(r.ctx, log, opts)
+	cmd.Run()
+	return cmd.FatalError
+}
+
+// keyFlags returns the global flags, with the password read from the given file if it's set.
+// The password command of the environment is cleared then, restic refuses to combine it with a password file.
+func (r *Restic) keyFlags(passwordFile string) Flags {
+	if passwordFile == "" {
+		return r.globalFlags
+	}
+	return Combine(r.globalFlags, Flags{"--password-file": {passwordFile}, "--password-command": {""}})
+}
+
+// WriteRepositoryKeyResults writes the keys that were changed by RotateKey as JSON into the given file.
+// Kubernetes reads the termination message of the container from this file, the operator copies it into the RepositoryKey's status.
+// If the file doesn't exist, the process doesn't run in Kubernetes and nothing is written.
+func (r *Restic) WriteRepositoryKeyResults(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		r.logger.V(1).Info("not writing repository key results, no termination message file", "path", path)
+		return nil
+	}
+	message, err := json.Marshal(r.keyResults)
+	if err != nil {
+		return fmt.Errorf("cannot encode repository key results: %w", err)
+	}
+	return os.WriteFile(path, message, 0644)
+}
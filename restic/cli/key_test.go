@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+)
+
+func TestWriteRepositoryKeyResults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "termination-log")
+	require.NoError(t, os.WriteFile(path, nil, 0644))
+
+	r := &Restic{logger: logr.Discard()}
+	r.keyResults = k8upv1.RepositoryKeyResults{KeyID: "4a1b2c3d", RemovedKeyID: "5e6f7a8b"}
+	require.NoError(t, r.WriteRepositoryKeyResults(path))
+
+	written, err := os.ReadFile(path)
+	require.NoError(t, err)
+	results := k8upv1.RepositoryKeyResults{}
+	require.NoError(t, json.Unmarshal(written, &results))
+	assert.Equal(t, r.keyResults, results)
+
+	missing := filepath.Join(t.TempDir(), "missing")
+	require.NoError(t, r.WriteRepositoryKeyResults(missing))
+	assert.NoFileExists(t, missing, "nothing is written outside of Kubernetes")
+}
+
+func TestRestic_keyFlags(t *testing.T) {
+	r := &Restic{globalFlags: Flags{"--option": {"s3.storage-class=STANDARD"}}}
+
+	assert.Equal(t, []string{"key", "--option", "s3.storage-class=STANDARD", "list"}, r.keyFlags("").ApplyToCommand("key", "list"))
+
+	flags := r.keyFlags("/etc/k8up/new-password/password")
+	assert.Equal(t, []string{"/etc/k8up/new-password/password"}, flags["--password-file"])
+	assert.Equal(t, []string{""}, flags["--password-command"], "the password command of the environment is cleared")
+	assert.NotContains(t, r.globalFlags, "--password-file", "the global flags are left as is")
+}
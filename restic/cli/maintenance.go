@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-logr/logr"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/restic/logging"
+)
+
+const (
+	// maintenanceSummaryLines is the number of lines at the end of the output of restic that summarize a maintenance operation.
+	maintenanceSummaryLines = 10
+	// maintenanceSummaryLength limits the summary, as the termination message of a container is limited to 4096 bytes.
+	maintenanceSummaryLength = 2048
+)
+
+// MaintenanceOptions are the options of a maintenance operation, each of them only applies to some of the operations.
+type MaintenanceOptions struct {
+	// ReadAllPacks reads all pack files to rebuild the index.
+	ReadAllPacks bool
+	// Forget removes the original snapshots once they're replaced by repaired snapshots.
+	Forget bool
+	// Packs are the IDs of the pack files to repair.
+	Packs []string
+}
+
+// Maintenance runs the given operation that repairs or upgrades the repository, see k8upv1.MaintenanceOperation.
+// The last lines of the output of restic are reported as the summary of the operation, also if it fails.
+// The Snapshot resources are synchronized after the snapshots have been repaired.
+func (r *Restic) Maintenance(operation string, options MaintenanceOptions) error {
+	maintenanceLogger := r.logger.WithName("maintenance")
+
+	args, err := maintenanceArgs(k8upv1.MaintenanceOperation(operation), options)
+	if err != nil {
+		return err
+	}
+	maintenanceLogger.Info("running maintenance operation", "operation", operation, "command", args)
+
+	resticMaintenanceLogger := maintenanceLogger.WithName("restic")
+	parser := &summaryOutputParser{log: resticMaintenanceLogger}
+	opts := CommandOptions{
+		Path:   r.resticPath,
+		Args:   append(args, r.globalFlags.ApplyToCommand("")...),
+		StdOut: logging.New(parser.out),
+		StdErr: logging.New(parser.err),
+	}
+
+	cmd := NewCommand(r.ctx, maintenanceLogger, opts)
+	cmd.Run()
+
+	r.maintenanceResults.Summary = parser.summary()
+
+	if cmd.FatalError == nil && k8upv1.MaintenanceOperation(operation) == k8upv1.MaintenanceRepairSnapshots {
+		r.sendSnapshotList()
+	}
+
+	return cmd.FatalError
+}
+
+// maintenanceArgs returns the restic command of the given operation.
+// The global flags are appended by the caller, as some of the commands consist of two words.
+// rebuild-index is the former name of 'repair index', which restic has deprecated.
+func maintenanceArgs(operation k8upv1.MaintenanceOperation, options MaintenanceOptions) ([]string, error) {
+	switch operation {
+	case k8upv1.MaintenanceRebuildIndex, k8upv1.MaintenanceRepairIndex:
+		args := []string{"repair", "index"}
+		if options.ReadAllPacks {
+			args = append(args, "--read-all-packs")
+		}
+		return args, nil
+	case k8upv1.MaintenanceRepairSnapshots:
+		args := []string{"repair", "snapshots"}
+		if options.Forget {
+			args = append(args, "--forget")
+		}
+		return args, nil
+	case k8upv1.MaintenanceRepairPacks:
+		if len(options.Packs) == 0 {
+			return nil, fmt.Errorf("the IDs of the packs have to be given to repair packs")
+		}
+		return append([]string{"repair", "packs"}, options.Packs...), nil
+	case k8upv1.MaintenanceUpgradeRepoV2:
+		return []string{"migrate", "upgrade_repo_v2"}, nil
+	case k8upv1.MaintenanceUnlockRemoveAll:
+		return []string{"unlock", "--remove-all"}, nil
+	}
+	return nil, fmt.Errorf("unknown maintenance operation %q", operation)
+}
+
+// WriteMaintenanceResults writes the results of Maintenance as JSON into the given file.
+// Kubernetes reads the termination message of the container from this file, the operator copies it into the Maintenance's status.
+// If the file doesn't exist, the process doesn't run in Kubernetes and nothing is written.
+func (r *Restic) WriteMaintenanceResults(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		r.logger.V(1).Info("not writing maintenance results, no termination message file", "path", path)
+		return nil
+	}
+	message, err := json.Marshal(r.maintenanceResults)
+	if err != nil {
+		return fmt.Errorf("cannot encode maintenance results: %w", err)
+	}
+	return os.WriteFile(path, message, 0644)
+}
+
+// summaryOutputParser logs the output of restic and keeps its last lines as the summary.
+// Warnings are part of the summary, as they tell what couldn't be repaired.
+type summaryOutputParser struct {
+	log   logr.Logger
+	lines []string
+}
+
+func (s *summaryOutputParser) out(line string) {
+	s.log.WithName("stdout").Info(line)
+	s.keep(line)
+}
+
+func (s *summaryOutputParser) err(line string) {
+	s.log.WithName("stderr").Info(line)
+	s.keep(line)
+}
+
+func (s *summaryOutputParser) keep(line string) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return
+	}
+	s.lines = append(s.lines, line)
+	if len(s.lines) > maintenanceSummaryLines {
+		s.lines = s.lines[1:]
+	}
+}
+
+// summary returns the kept lines, shortened from the start if they're too long.
+func (s *summaryOutputParser) summary() string {
+	summary := strings.Join(s.lines, "\n")
+	if len(summary) > maintenanceSummaryLength {
+		summary = summary[len(summary)-maintenanceSummaryLength:]
+	}
+	return summary
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+)
+
+func TestMaintenanceArgs(t *testing.T) {
+	pack := strings.Repeat("a", 64)
+	tests := map[string]struct {
+		givenOperation k8upv1.MaintenanceOperation
+		givenOptions   MaintenanceOptions
+		expectedArgs   []string
+		expectedError  string
+	}{
+		"GivenRebuildIndex_ThenExpectRepairIndex": {
+			givenOperation: k8upv1.MaintenanceRebuildIndex,
+			expectedArgs:   []string{"repair", "index"},
+		},
+		"GivenRepairIndexReadingAllPacks_ThenExpectReadAllPacksFlag": {
+			givenOperation: k8upv1.MaintenanceRepairIndex,
+			givenOptions:   MaintenanceOptions{ReadAllPacks: true},
+			expectedArgs:   []string{"repair", "index", "--read-all-packs"},
+		},
+		"GivenRepairSnapshotsWithForget_ThenExpectForgetFlag": {
+			givenOperation: k8upv1.MaintenanceRepairSnapshots,
+			givenOptions:   MaintenanceOptions{Forget: true},
+			expectedArgs:   []string{"repair", "snapshots", "--forget"},
+		},
+		"GivenRepairPacks_ThenExpectPackIDs": {
+			givenOperation: k8upv1.MaintenanceRepairPacks,
+			givenOptions:   MaintenanceOptions{Packs: []string{pack}},
+			expectedArgs:   []string{"repair", "packs", pack},
+		},
+		"GivenRepairPacksWithoutIDs_ThenExpectError": {
+			givenOperation: k8upv1.MaintenanceRepairPacks,
+			expectedError:  "the IDs of the packs have to be given to repair packs",
+		},
+		"GivenUpgradeRepoV2_ThenExpectMigrate": {
+			givenOperation: k8upv1.MaintenanceUpgradeRepoV2,
+			expectedArgs:   []string{"migrate", "upgrade_repo_v2"},
+		},
+		"GivenUnlockRemoveAll_ThenExpectRemoveAllFlag": {
+			givenOperation: k8upv1.MaintenanceUnlockRemoveAll,
+			expectedArgs:   []string{"unlock", "--remove-all"},
+		},
+		"GivenUnknownOperation_ThenExpectError": {
+			givenOperation: "prune",
+			expectedError:  `unknown maintenance operation "prune"`,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			args, err := maintenanceArgs(tc.givenOperation, tc.givenOptions)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedArgs, args)
+		})
+	}
+}
+
+func TestSummaryOutputParser(t *testing.T) {
+	parser := &summaryOutputParser{log: logr.Discard()}
+	for i := 0; i < maintenanceSummaryLines; i++ {
+		parser.out("loading pack files")
+	}
+	parser.out("")
+	parser.err("pack 1a2b3c4d: not readable")
+	parser.out("done")
+
+	lines := strings.Split(parser.summary(), "\n")
+	require.Len(t, lines, maintenanceSummaryLines)
+	assert.Equal(t, []string{"pack 1a2b3c4d: not readable", "done"}, lines[len(lines)-2:])
+
+	parser.out(strings.Repeat("x", 2*maintenanceSummaryLength))
+	assert.Len(t, parser.summary(), maintenanceSummaryLength, "the summary fits into the termination message")
+}
+
+func TestWriteMaintenanceResults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "termination-log")
+	require.NoError(t, os.WriteFile(path, nil, 0644))
+
+	r := &Restic{logger: logr.Discard()}
+	r.maintenanceResults = k8upv1.MaintenanceResults{Summary: "adding pack file to index 3f2a1b0c\nsaving new index files"}
+	require.NoError(t, r.WriteMaintenanceResults(path))
+
+	written, err := os.ReadFile(path)
+	require.NoError(t, err)
+	results := k8upv1.MaintenanceResults{}
+	require.NoError(t, json.Unmarshal(written, &results))
+	assert.Equal(t, r.maintenanceResults, results)
+
+	missing := filepath.Join(t.TempDir(), "missing")
+	require.NoError(t, r.WriteMaintenanceResults(missing))
+	assert.NoFileExists(t, missing, "nothing is written outside of Kubernetes")
+}
@@ -8,11 +8,36 @@ import (
 )
 
 // Prune will enforce the retention policy onto the repository
-func (r *Restic) Prune(tags ArrayOpts) error {
+func (r *Restic) Prune(tags, hosts ArrayOpts) error {
 	prunelogger := r.logger.WithName("prune")
 
 	prunelogger.Info("pruning repository")
 
+	resticPruneLogger := prunelogger.WithName("restic")
+	opts := CommandOptions{
+		Path:   r.resticPath,
+		Args:   r.globalFlags.ApplyToCommand("forget", pruneArgs(hosts)...),
+		StdOut: logging.NewInfoWriter(resticPruneLogger),
+		StdErr: logging.NewErrorWriter(resticPruneLogger),
+	}
+
+	if len(tags) > 0 {
+		opts.Args = append(opts.Args, tags.BuildArgs("--tag")...)
+	}
+
+	cmd := NewCommand(r.ctx, prunelogger, opts)
+	cmd.Run()
+
+	if cmd.FatalError == nil {
+		r.sendSnapshotList()
+	}
+
+	return cmd.FatalError
+}
+
+// pruneArgs returns the arguments of 'restic forget' that apply the retention policy.
+// The policy applies to the snapshots of the given hosts, or to those of the own hostname if no hosts are given.
+func pruneArgs(hosts ArrayOpts) []string {
 	args := []string{"--prune"}
 	keepN := map[string]int{
 		"--keep-last":    cfg.Config.PruneKeepLast,
@@ -45,28 +70,13 @@ func (r *Restic) Prune(tags ArrayOpts) error {
 	if cfg.Config.PruneKeepTags {
 		args = append(args, "--keep-tag")
 	}
-	if cfg.Config.Hostname != "" {
+	if len(hosts) > 0 {
+		args = append(args, hosts.BuildArgs("--host")...)
+	} else if cfg.Config.Hostname != "" {
 		args = append(args, "--host="+cfg.Config.Hostname)
 	}
-
-	resticPruneLogger := prunelogger.WithName("restic")
-	opts := CommandOptions{
-		Path:   r.resticPath,
-		Args:   r.globalFlags.ApplyToCommand("forget", args...),
-		StdOut: logging.NewInfoWriter(resticPruneLogger),
-		StdErr: logging.NewErrorWriter(resticPruneLogger),
-	}
-
-	if len(tags) > 0 {
-		opts.Args = append(opts.Args, tags.BuildArgs("--tag")...)
-	}
-
-	cmd := NewCommand(r.ctx, prunelogger, opts)
-	cmd.Run()
-
-	if cmd.FatalError == nil {
-		r.sendSnapshotList()
+	if cfg.Config.PruneGroupBy != "" {
+		args = append(args, "--group-by", cfg.Config.PruneGroupBy)
 	}
-
-	return cmd.FatalError
+	return args
 }
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/k8up-io/k8up/v2/restic/cfg"
+)
+
+func TestPruneArgs(t *testing.T) {
+	tests := map[string]struct {
+		givenConfig  cfg.Configuration
+		givenHosts   ArrayOpts
+		expectedArgs []string
+	}{
+		"GivenNoHosts_ThenExpectOwnHostname": {
+			givenConfig:  cfg.Configuration{Hostname: "app", PruneKeepDaily: 14},
+			expectedArgs: []string{"--prune", "--keep-daily", "14", "--host=app"},
+		},
+		"GivenHosts_ThenExpectHostsInsteadOfOwnHostname": {
+			givenConfig:  cfg.Configuration{Hostname: "app", PruneKeepWithin: "7d"},
+			givenHosts:   ArrayOpts{"app", "db"},
+			expectedArgs: []string{"--prune", "--keep-within", "7d", "--host", "app", "--host", "db"},
+		},
+		"GivenGroupBy_ThenExpectGroupByFlag": {
+			givenConfig:  cfg.Configuration{PruneKeepWithinDaily: "1m", PruneGroupBy: "host,tags"},
+			expectedArgs: []string{"--prune", "--keep-within-daily", "1m", "--group-by", "host,tags"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func(config cfg.Configuration) { *cfg.Config = config }(*cfg.Config)
+			*cfg.Config = tt.givenConfig
+
+			assert.Equal(t, tt.expectedArgs, pruneArgs(tt.givenHosts))
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-logr/logr"
 
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
 	"github.com/k8up-io/k8up/v2/operator/utils"
 	"github.com/k8up-io/k8up/v2/restic/cfg"
 	"github.com/k8up-io/k8up/v2/restic/dto"
@@ -39,12 +40,28 @@ type Restic struct {
 	ctx        context.Context
 	bucket     string
 
+	// snapshotStats contains the statistics of the snapshots taken by this process, by snapshot ID
+	snapshotStats map[string]k8upv1.SnapshotStatus
+	// backupResults contains the results of the backups of this process, they're reported to the operator
+	backupResults k8upv1.BackupResults
+	// keyResults contains the keys that were changed by RotateKey, they're reported to the operator
+	keyResults k8upv1.RepositoryKeyResults
+	// copyResults contains the results of Copy, they're reported to the operator
+	copyResults k8upv1.CopyResults
+	// maintenanceResults contains the results of Maintenance, they're reported to the operator
+	maintenanceResults k8upv1.MaintenanceResults
+	// checkResults contains the results of Check, they're reported to the operator
+	checkResults k8upv1.CheckResults
+
 	// globalFlags are applied to all invocations of restic
 	globalFlags  Flags
 	statsHandler StatsHandler
 
 	caCert     string
 	clientCert clientCert
+
+	// sftpKey is the path of the private SSH key passed to ssh, see PrepareSFTPKey
+	sftpKey string
 }
 
 type clientCert struct {
@@ -63,6 +80,11 @@ func New(ctx context.Context, logger logr.Logger, statsHandler StatsHandler) *Re
 		globalFlags.AddFlag("--option", options...)
 	}
 
+	sftpKey := sftpKeyPath()
+	if option := sftpOption(cfg.Config.SFTPCommand, sftpKey, cfg.Config.SFTPKnownHosts); option != "" {
+		globalFlags.AddFlag("--option", option)
+	}
+
 	var caCert string
 	if cfg.Config.CACert != "" {
 		caCert = cfg.Config.CACert
@@ -88,6 +110,7 @@ func New(ctx context.Context, logger logr.Logger, statsHandler StatsHandler) *Re
 		bucket:       path.Base(cfg.Config.ResticRepository),
 		caCert:       caCert,
 		clientCert:   cc,
+		sftpKey:      sftpKey,
 		globalFlags:  globalFlags,
 		statsHandler: statsHandler,
 	}
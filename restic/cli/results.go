@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/restic/kubernetes"
+	"github.com/k8up-io/k8up/v2/restic/logging"
+)
+
+// maxTerminationMessageSize is the size limit Kubernetes applies to the termination message of a container.
+const maxTerminationMessageSize = 4096
+
+// ErrIncompleteBackup is returned if restic created the snapshot, but couldn't read some of the files.
+var ErrIncompleteBackup = errors.New("snapshot created, but some files could not be read")
+
+// newBackupRunStatus converts the summary restic reported for a backup into the status reported to the operator.
+func newBackupRunStatus(summary logging.BackupSummary, errorCount int) k8upv1.BackupRunStatus {
+	return k8upv1.BackupRunStatus{
+		SnapshotID:   summary.SnapshotID,
+		FilesNew:     summary.FilesNew,
+		FilesChanged: summary.FilesChanged,
+		BytesAdded:   summary.DataAdded,
+		Duration:     &metav1.Duration{Duration: time.Duration(summary.TotalDuration * float64(time.Second))},
+		ErrorCount:   errorCount,
+		FailedPaths:  summary.FailedPaths,
+	}
+}
+
+// finishBackupRun sets the result of the given backup run according to the error restic finished with.
+func finishBackupRun(run *k8upv1.BackupRunStatus, err error) {
+	if errors.Is(err, ErrIncompleteBackup) {
+		run.Result = k8upv1.BackupResultPartiallySucceeded
+		run.Message = err.Error()
+		return
+	}
+	if err != nil {
+		run.Result = k8upv1.BackupResultFailed
+		run.Message = err.Error()
+		return
+	}
+	run.Result = k8upv1.BackupResultSucceeded
+}
+
+// ignoreIncomplete returns nil for ErrIncompleteBackup, so that the remaining backups are done.
+// Whether incomplete backups fail the job is decided once all backups are done, see HasReadErrors.
+func ignoreIncomplete(err error) error {
+	if errors.Is(err, ErrIncompleteBackup) {
+		return nil
+	}
+	return err
+}
+
+// HasReadErrors returns true if any of the backups of this process couldn't read some of the files.
+func (r *Restic) HasReadErrors() bool {
+	for _, volume := range r.backupResults.Volumes {
+		if volume.Result == k8upv1.BackupResultPartiallySucceeded {
+			return true
+		}
+	}
+	for _, stdinBackup := range r.backupResults.StdinBackups {
+		if stdinBackup.Result == k8upv1.BackupResultPartiallySucceeded {
+			return true
+		}
+	}
+	return false
+}
+
+// RecordStdinBackupFailure adds a failed result for the backup command of the given Pod,
+// for failures that happen before restic is started.
+func (r *Restic) RecordStdinBackupFailure(pod kubernetes.BackupPod, filename string, err error) {
+	status := k8upv1.StdinBackupStatus{PodName: pod.PodName, ContainerName: pod.ContainerName, FileName: stdinFileName(filename, pod.FileExtension)}
+	finishBackupRun(&status.BackupRunStatus, err)
+	r.backupResults.StdinBackups = append(r.backupResults.StdinBackups, status)
+}
+
+// WriteBackupResults writes the results of the backups of this process as JSON into the given file.
+// Kubernetes reads the termination message of the container from this file, the operator copies it into the Backup's status.
+// If the file doesn't exist, the process doesn't run in Kubernetes and nothing is written.
+// Results that exceed the size limit of termination messages are left out.
+func (r *Restic) WriteBackupResults(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		r.logger.V(1).Info("not writing backup results, no termination message file", "path", path)
+		return nil
+	}
+	results := r.backupResults
+	for {
+		message, err := json.Marshal(results)
+		if err != nil {
+			return fmt.Errorf("cannot encode backup results: %w", err)
+		}
+		if len(message) <= maxTerminationMessageSize {
+			return os.WriteFile(path, message, 0644)
+		}
+		// Drop the stdin backups first, then the volumes, from the end.
+		if n := len(results.StdinBackups); n > 0 {
+			results.StdinBackups = results.StdinBackups[:n-1]
+		} else {
+			results.Volumes = results.Volumes[:len(results.Volumes)-1]
+		}
+		r.logger.Info("backup results exceed the size of a termination message, leaving out the last result")
+	}
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/restic/kubernetes"
+)
+
+func TestWriteBackupResults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "termination-log")
+	require.NoError(t, os.WriteFile(path, nil, 0644))
+
+	r := &Restic{logger: logr.Discard()}
+	volume := k8upv1.VolumeBackupStatus{Name: "data"}
+	finishBackupRun(&volume.BackupRunStatus, nil)
+	failed := k8upv1.VolumeBackupStatus{Name: "broken"}
+	finishBackupRun(&failed.BackupRunStatus, errors.New("exit status 1"))
+	r.backupResults.Volumes = []k8upv1.VolumeBackupStatus{volume, failed}
+
+	require.NoError(t, r.WriteBackupResults(path))
+
+	written, err := os.ReadFile(path)
+	require.NoError(t, err)
+	results := k8upv1.BackupResults{}
+	require.NoError(t, json.Unmarshal(written, &results))
+	require.Len(t, results.Volumes, 2)
+	assert.Equal(t, k8upv1.BackupResultSucceeded, results.Volumes[0].Result)
+	assert.Equal(t, k8upv1.BackupResultFailed, results.Volumes[1].Result)
+	assert.Equal(t, "exit status 1", results.Volumes[1].Message)
+}
+
+func TestWriteBackupResults_GivenTooManyResults_ThenExpectTruncatedMessage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "termination-log")
+	require.NoError(t, os.WriteFile(path, nil, 0644))
+
+	r := &Restic{logger: logr.Discard()}
+	for i := 0; i < 100; i++ {
+		r.backupResults.Volumes = append(r.backupResults.Volumes, k8upv1.VolumeBackupStatus{Name: strings.Repeat("v", 60)})
+	}
+
+	require.NoError(t, r.WriteBackupResults(path))
+
+	written, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(written), maxTerminationMessageSize)
+	results := k8upv1.BackupResults{}
+	require.NoError(t, json.Unmarshal(written, &results))
+	assert.NotEmpty(t, results.Volumes)
+}
+
+func TestWriteBackupResults_GivenNoTerminationMessageFile_ThenExpectNoError(t *testing.T) {
+	r := &Restic{logger: logr.Discard()}
+	assert.NoError(t, r.WriteBackupResults(filepath.Join(t.TempDir(), "missing")))
+}
+
+func TestFinishBackupRun(t *testing.T) {
+	tests := map[string]struct {
+		givenErr        error
+		expectedResult  k8upv1.BackupResult
+		expectedMessage string
+	}{
+		"GivenNoError_ThenExpectSucceeded": {
+			expectedResult: k8upv1.BackupResultSucceeded,
+		},
+		"GivenIncompleteBackup_ThenExpectPartiallySucceeded": {
+			givenErr:        ErrIncompleteBackup,
+			expectedResult:  k8upv1.BackupResultPartiallySucceeded,
+			expectedMessage: ErrIncompleteBackup.Error(),
+		},
+		"GivenError_ThenExpectFailed": {
+			givenErr:        errors.New("cmd.Wait() err: 1"),
+			expectedResult:  k8upv1.BackupResultFailed,
+			expectedMessage: "cmd.Wait() err: 1",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			run := k8upv1.BackupRunStatus{}
+			finishBackupRun(&run, tt.givenErr)
+			assert.Equal(t, tt.expectedResult, run.Result)
+			assert.Equal(t, tt.expectedMessage, run.Message)
+		})
+	}
+}
+
+func TestHasReadErrors(t *testing.T) {
+	r := &Restic{}
+	assert.False(t, r.HasReadErrors())
+
+	r.RecordStdinBackupFailure(kubernetes.BackupPod{PodName: "mariadb"}, "/app-mariadb", errors.New("exec failed"))
+	assert.False(t, r.HasReadErrors(), "failures aren't read errors")
+
+	r.backupResults.Volumes = append(r.backupResults.Volumes, k8upv1.VolumeBackupStatus{Name: "data", BackupRunStatus: k8upv1.BackupRunStatus{Result: k8upv1.BackupResultPartiallySucceeded}})
+	assert.True(t, r.HasReadErrors())
+	assert.Nil(t, ignoreIncomplete(ErrIncompleteBackup))
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/k8up-io/k8up/v2/restic/cfg"
+)
+
+// sftpKeyFileName is the name of the copy of the private SSH key in the var directory.
+const sftpKeyFileName = "sftp_id"
+
+// sftpOption returns the restic option that tells ssh which key and known_hosts file to use for SFTP repositories.
+// A custom sftp command replaces the arguments, as restic doesn't allow both.
+// It returns an empty string if there's nothing to configure.
+func sftpOption(command, privateKey, knownHosts string) string {
+	if command != "" {
+		return "sftp.command=" + command
+	}
+	var args []string
+	if privateKey != "" {
+		args = append(args, "-i", privateKey, "-o", "IdentitiesOnly=yes")
+	}
+	if knownHosts != "" {
+		args = append(args, "-o", "UserKnownHostsFile="+knownHosts)
+	}
+	if len(args) == 0 {
+		return ""
+	}
+	return "sftp.args=" + strings.Join(args, " ")
+}
+
+// sftpKeyPath returns the path of the copy of the private SSH key that is passed to ssh.
+func sftpKeyPath() string {
+	if cfg.Config.SFTPPrivateKey == "" {
+		return ""
+	}
+	return filepath.Join(cfg.Config.VarDir, sftpKeyFileName)
+}
+
+// PrepareSFTPKey copies the private SSH key into a file that only the current user can read.
+// The key is mounted from a Secret with permissions that ssh refuses.
+// It does nothing if no private SSH key is configured.
+func (r *Restic) PrepareSFTPKey() error {
+	if r.sftpKey == "" {
+		return nil
+	}
+	key, err := os.ReadFile(cfg.Config.SFTPPrivateKey)
+	if err != nil {
+		return fmt.Errorf("cannot read private SSH key: %w", err)
+	}
+	if err := os.WriteFile(r.sftpKey, key, 0600); err != nil {
+		return fmt.Errorf("cannot write private SSH key: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/k8up-io/k8up/v2/restic/cfg"
+)
+
+func TestSFTPOption(t *testing.T) {
+	tests := map[string]struct {
+		givenCommand    string
+		givenPrivateKey string
+		givenKnownHosts string
+		expectedOption  string
+	}{
+		"GivenNothing_ThenExpectNoOption": {},
+		"GivenKeyAndKnownHosts_ThenExpectArgs": {
+			givenPrivateKey: "/k8up/sftp_id",
+			givenKnownHosts: "/etc/k8up/sftp/known_hosts",
+			expectedOption:  "sftp.args=-i /k8up/sftp_id -o IdentitiesOnly=yes -o UserKnownHostsFile=/etc/k8up/sftp/known_hosts",
+		},
+		"GivenOnlyKnownHosts_ThenExpectArgs": {
+			givenKnownHosts: "/etc/k8up/sftp/known_hosts",
+			expectedOption:  "sftp.args=-o UserKnownHostsFile=/etc/k8up/sftp/known_hosts",
+		},
+		"GivenCommand_ThenExpectCommandOnly": {
+			givenCommand:    "ssh -p 2222 backup@example.com -s sftp",
+			givenPrivateKey: "/k8up/sftp_id",
+			expectedOption:  "sftp.command=ssh -p 2222 backup@example.com -s sftp",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedOption, sftpOption(tt.givenCommand, tt.givenPrivateKey, tt.givenKnownHosts))
+		})
+	}
+}
+
+func TestRestic_PrepareSFTPKey(t *testing.T) {
+	dir := t.TempDir()
+	key := filepath.Join(dir, "id")
+	require.NoError(t, os.WriteFile(key, []byte("private key"), 0644))
+	cfg.Config = &cfg.Configuration{VarDir: dir, SFTPPrivateKey: key}
+
+	r := &Restic{sftpKey: sftpKeyPath()}
+	require.NoError(t, r.PrepareSFTPKey())
+
+	info, err := os.Stat(filepath.Join(dir, sftpKeyFileName))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	contents, err := os.ReadFile(filepath.Join(dir, sftpKeyFileName))
+	require.NoError(t, err)
+	assert.Equal(t, "private key", string(contents))
+}
@@ -43,6 +43,9 @@ func (r *Restic) listSnapshots(tags ArrayOpts, paths ArrayOpts, last bool) error
 
 	cmd := NewCommand(r.ctx, snaplogger, opts)
 	cmd.Run()
+	if cmd.FatalError != nil {
+		return cmd.FatalError
+	}
 
 	snaps := []dto.Snapshot{}
 
@@ -55,6 +58,5 @@ func (r *Restic) listSnapshots(tags ArrayOpts, paths ArrayOpts, last bool) error
 
 	r.snapshots = snaps
 
-	return cmd.FatalError
-
+	return nil
 }
@@ -2,20 +2,28 @@ package cli
 
 import (
 	"fmt"
+	"strings"
 
+	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
 	"github.com/k8up-io/k8up/v2/restic/cfg"
 	"github.com/k8up-io/k8up/v2/restic/kubernetes"
 	"github.com/k8up-io/k8up/v2/restic/logging"
 )
 
 // StdinBackup create a snapshot with the data contained in the given reader.
-func (r *Restic) StdinBackup(data *kubernetes.ExecData, filename, fileExt string, tags ArrayOpts) error {
+// The data is the output of the backup command of the given Pod.
+func (r *Restic) StdinBackup(data *kubernetes.ExecData, pod kubernetes.BackupPod, filename string, tags ArrayOpts) error {
 
+	fileExt := pod.FileExtension
 	stdinlogger := r.logger.WithName("stdinBackup")
 
 	stdinlogger.Info("starting stdin backup", "filename", filename, "extension", fileExt)
 
-	outputWriter := logging.NewStdinBackupOutputParser(stdinlogger.WithName("progress"), filename+fileExt, r.sendBackupStats)
+	status := k8upv1.StdinBackupStatus{PodName: pod.PodName, ContainerName: pod.ContainerName, FileName: stdinFileName(filename, fileExt)}
+	outputWriter := logging.NewStdinBackupOutputParser(stdinlogger.WithName("progress"), filename+fileExt, func(summary logging.BackupSummary, errorCount int, folder string, startTimestamp, endTimestamp int64) {
+		status.BackupRunStatus = newBackupRunStatus(summary, errorCount)
+		r.sendBackupStats(summary, errorCount, folder, startTimestamp, endTimestamp)
+	})
 
 	flags := Combine(r.globalFlags, Flags{
 		"--host":           {cfg.Config.Hostname},
@@ -32,5 +40,13 @@ func (r *Restic) StdinBackup(data *kubernetes.ExecData, filename, fileExt string
 		StdIn:  data.Reader,
 	}
 
-	return r.triggerBackup(stdinlogger, tags, opts, data)
+	err := r.triggerBackup(stdinlogger, tags, opts, data)
+	finishBackupRun(&status.BackupRunStatus, err)
+	r.backupResults.StdinBackups = append(r.backupResults.StdinBackups, status)
+	return ignoreIncomplete(err)
+}
+
+// stdinFileName returns the name of the file the output of a backup command is stored as in the snapshot.
+func stdinFileName(filename, fileExt string) string {
+	return strings.TrimPrefix(filename+fileExt, "/")
 }
@@ -2,19 +2,48 @@ package kubernetes
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
 
 	"github.com/go-logr/logr"
 	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/job"
 	"github.com/k8up-io/k8up/v2/restic/dto"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// SnapshotOrigin describes the Backup that runs the current restic process and the snapshots it has taken.
+type SnapshotOrigin struct {
+	BackupName   string
+	ScheduleName string
+	// Statistics contains the statistics restic reported for the snapshots taken by the current process, by snapshot ID.
+	// The IDs may be abbreviated.
+	Statistics map[string]k8upv1.SnapshotStatus
+}
+
+// statisticsOf returns the statistics of the snapshot with the given ID, if it has been taken by the current process.
+func (o SnapshotOrigin) statisticsOf(id string) (k8upv1.SnapshotStatus, bool) {
+	for shortID, status := range o.Statistics {
+		if shortID != "" && strings.HasPrefix(id, shortID) {
+			return status, true
+		}
+	}
+	return k8upv1.SnapshotStatus{}, false
+}
+
 // SyncSnapshotList will take a k8upv1.SnapshotList and apply them to the k8s cluster.
-// It will remove any snapshots on the cluster that are not present in the list.
-func SyncSnapshotList(ctx context.Context, list []dto.Snapshot, namespace, repository string, l logr.Logger) error {
+// It will remove any snapshots of the repository on the cluster that are not present in the list.
+// Snapshots that have been taken by the given origin get its names and statistics.
+// Errors of single snapshots don't abort the synchronization, they're returned together at the end.
+func SyncSnapshotList(ctx context.Context, list []dto.Snapshot, origin SnapshotOrigin, namespace, repository string, l logr.Logger) error {
 
-	newList := filterAndConvert(list, namespace, repository)
+	newList := filterAndConvert(list, origin, namespace, repository)
 	oldList := &k8upv1.SnapshotList{}
 
 	kube, err := NewTypedClient(l)
@@ -22,53 +51,85 @@ func SyncSnapshotList(ctx context.Context, list []dto.Snapshot, namespace, repos
 		return err
 	}
 
-	err = kube.List(ctx, oldList, &client.ListOptions{Namespace: namespace})
+	err = kube.List(ctx, oldList, client.InNamespace(namespace), client.MatchingLabels{k8upv1.LabelRepositoryHash: job.Sha256Hash(repository)})
 	if err != nil {
 		return err
 	}
 
-	oldList = filterByRepo(oldList, repository)
-
-	err = createNewSnapshots(ctx, newList, oldList, kube)
+	legacyList, err := listLegacySnapshots(ctx, kube, namespace, repository)
 	if err != nil {
 		return err
 	}
 
-	return deleteOldSnapshots(ctx, newList, oldList, kube)
+	return errors.Join(
+		createNewSnapshots(ctx, newList, oldList, kube),
+		deleteOldSnapshots(ctx, newList, oldList, kube),
+		// The legacy snapshots have been replaced by the new ones, which have different names.
+		deleteOldSnapshots(ctx, &k8upv1.SnapshotList{}, legacyList, kube),
+	)
+}
+
+// listLegacySnapshots returns the snapshots of the repository that have been created by older versions of K8up.
+// These are named after the snapshot ID only and don't have the repository hash label.
+func listLegacySnapshots(ctx context.Context, kube client.Client, namespace, repository string) (*k8upv1.SnapshotList, error) {
+	withoutHash, err := labels.NewRequirement(k8upv1.LabelRepositoryHash, selection.DoesNotExist, nil)
+	if err != nil {
+		return nil, err
+	}
+	list := &k8upv1.SnapshotList{}
+	err = kube.List(ctx, list, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: labels.NewSelector().Add(*withoutHash)})
+	if err != nil {
+		return nil, err
+	}
+	return filterByRepo(list, repository), nil
 }
 
 // createNewSnapshots is a wrapper for the diff function to correctly pass the right order and function
 func createNewSnapshots(ctx context.Context, newList, oldList *k8upv1.SnapshotList, kube client.Client) error {
 	return diff(newList, oldList, func(snap k8upv1.Snapshot) error {
-		return kube.Create(ctx, &snap)
+		status := snap.Status
+		if err := kube.Create(ctx, &snap); err != nil {
+			return fmt.Errorf("cannot create snapshot %q: %w", snap.Name, err)
+		}
+		if status == (k8upv1.SnapshotStatus{}) {
+			return nil
+		}
+		// The status is a subresource, it's ignored on creation.
+		snap.Status = status
+		if err := kube.Status().Update(ctx, &snap); err != nil {
+			return fmt.Errorf("cannot update status of snapshot %q: %w", snap.Name, err)
+		}
+		return nil
 	})
 }
 
 // deleteOldSnapshots is a wrapper for the diff function to correctly pass the right order and function
 func deleteOldSnapshots(ctx context.Context, newList, oldList *k8upv1.SnapshotList, kube client.Client) error {
 	return diff(oldList, newList, func(snap k8upv1.Snapshot) error {
-		return kube.Delete(ctx, &snap)
+		if err := kube.Delete(ctx, &snap); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("cannot delete snapshot %q: %w", snap.Name, err)
+		}
+		return nil
 	})
 }
 
 // diff will execute a given function for each element that is in a but not b
-// it assumes that both lists belong to the same namespace and repository
+// it assumes that both lists belong to the same namespace and repository.
+// The errors of the function don't stop the iteration, they're returned together.
 func diff(a, b *k8upv1.SnapshotList, diffFunc func(snap k8upv1.Snapshot) error) error {
 
 	snapMap := listToMap(b)
 
+	var errs []error
 	for _, snapshot := range a.Items {
 		// Avoid pointer bug
 		snapshot := &snapshot
 		if _, ok := snapMap[*snapshot.Spec.ID]; !ok {
-			err := diffFunc(*snapshot)
-			if err != nil {
-				return err
-			}
+			errs = append(errs, diffFunc(*snapshot))
 		}
 	}
 
-	return nil
+	return errors.Join(errs...)
 
 }
 
@@ -85,7 +146,7 @@ func listToMap(list *k8upv1.SnapshotList) map[string]bool {
 
 // filterAndConvert removes snapshots that don't belong to the same namespace
 // and it converts them to the k8up snapshot CR.
-func filterAndConvert(list []dto.Snapshot, namespace, repository string) *k8upv1.SnapshotList {
+func filterAndConvert(list []dto.Snapshot, origin SnapshotOrigin, namespace, repository string) *k8upv1.SnapshotList {
 
 	finalList := &k8upv1.SnapshotList{Items: []k8upv1.Snapshot{}}
 
@@ -98,30 +159,74 @@ func filterAndConvert(list []dto.Snapshot, namespace, repository string) *k8upv1
 			continue
 		}
 
-		finalList.Items = append(finalList.Items, k8upv1.Snapshot{
+		converted := k8upv1.Snapshot{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      snapshot.ID[:8],
+				Name:      SnapshotName(repository, snapshot.ID),
 				Namespace: namespace,
+				Labels:    map[string]string{k8upv1.LabelRepositoryHash: job.Sha256Hash(repository)},
 			},
 			Spec: k8upv1.SnapshotSpec{
 				ID:         &snapshot.ID,
 				Date:       &metav1.Time{Time: snapshot.Time},
 				Paths:      &snapshot.Paths,
 				Repository: &repository,
+				Tags:       snapshot.Tags,
+				Hostname:   snapshot.Hostname,
+				Username:   snapshot.Username,
+				Tree:       snapshot.Tree,
+				Source:     sourceOf(snapshot.Paths),
 			},
-		})
+		}
+		if status, ok := origin.statisticsOf(snapshot.ID); ok {
+			converted.Spec.BackupName = origin.BackupName
+			converted.Spec.ScheduleName = origin.ScheduleName
+			converted.Status = status
+		}
+		setLabel(converted.Labels, k8upv1.LabelSnapshotSource, converted.Spec.Source)
+		setLabel(converted.Labels, k8upv1.LabelSnapshotHostname, converted.Spec.Hostname)
+		setLabel(converted.Labels, k8upv1.LabelBackupName, converted.Spec.BackupName)
+		setLabel(converted.Labels, k8upv1.LabelK8upScheduleName, converted.Spec.ScheduleName)
+
+		finalList.Items = append(finalList.Items, converted)
 	}
 
 	return finalList
 }
 
+// SnapshotName returns the name of the Snapshot resource of the snapshot with the given ID in the given repository.
+// The name contains a part of the repository hash, so that snapshots of different repositories in the same namespace don't collide.
+func SnapshotName(repository, id string) string {
+	hash := job.Sha256Hash(repository)
+	if hash == "" {
+		return id
+	}
+	return fmt.Sprintf("%s-%s", hash[:16], id)
+}
+
+// sourceOf returns the PVC name or the file name of a backup command that the given snapshot paths were taken from.
+// K8up backs up each PVC as '/data/<pvc>', and the output of backup commands as '/<filename>'.
+func sourceOf(paths []string) string {
+	if len(paths) == 0 {
+		return ""
+	}
+	return path.Base(paths[0])
+}
+
+// setLabel sets the label if the value is a valid label value, as e.g. file names may not be.
+func setLabel(labels map[string]string, key, value string) {
+	if value == "" || len(validation.IsValidLabelValue(value)) > 0 {
+		return
+	}
+	labels[key] = value
+}
+
 // filterByRepo will filter the list according to the given repository.
 func filterByRepo(list *k8upv1.SnapshotList, repo string) *k8upv1.SnapshotList {
 	filteredList := &k8upv1.SnapshotList{Items: []k8upv1.Snapshot{}}
 
 	for _, snap := range list.Items {
 		snap := snap
-		if *snap.Spec.Repository == repo {
+		if snap.Spec.Repository != nil && *snap.Spec.Repository == repo {
 			filteredList.Items = append(filteredList.Items, snap)
 		}
 	}
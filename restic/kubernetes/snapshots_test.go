@@ -1,11 +1,16 @@
 package kubernetes
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	k8upv1 "github.com/k8up-io/k8up/v2/api/v1"
+	"github.com/k8up-io/k8up/v2/operator/job"
+	"github.com/k8up-io/k8up/v2/restic/dto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"k8s.io/utils/ptr"
 )
 
@@ -218,3 +223,67 @@ func Test_filterByRepo(t *testing.T) {
 		})
 	}
 }
+
+func Test_filterAndConvert(t *testing.T) {
+	date := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	list := []dto.Snapshot{
+		{ID: "1234567890abcdef", Time: date, Tree: "tree", Paths: []string{"/data/pvc-a"}, Hostname: "app", Username: "root", Tags: []string{"daily"}},
+		{ID: "abcdef1234567890", Time: date, Paths: []string{"/app-mariadb.sql"}, Hostname: "app"},
+		{ID: "fedcba0987654321", Time: date, Paths: []string{"/data/pvc-b"}, Hostname: "other"},
+	}
+	origin := SnapshotOrigin{
+		BackupName:   "backup",
+		ScheduleName: "schedule",
+		Statistics:   map[string]k8upv1.SnapshotStatus{"12345678": {FilesNew: 1, BytesAdded: 10, TotalBytes: 100}},
+	}
+
+	result := filterAndConvert(list, origin, "app", "s3:repo")
+	require.Len(t, result.Items, 2, "snapshots of other hosts are filtered")
+
+	taken := result.Items[0]
+	assert.Equal(t, SnapshotName("s3:repo", "1234567890abcdef"), taken.Name)
+	assert.Equal(t, []string{"daily"}, taken.Spec.Tags)
+	assert.Equal(t, "app", taken.Spec.Hostname)
+	assert.Equal(t, "root", taken.Spec.Username)
+	assert.Equal(t, "tree", taken.Spec.Tree)
+	assert.Equal(t, "pvc-a", taken.Spec.Source)
+	assert.Equal(t, "backup", taken.Spec.BackupName)
+	assert.Equal(t, "schedule", taken.Spec.ScheduleName)
+	assert.Equal(t, k8upv1.SnapshotStatus{FilesNew: 1, BytesAdded: 10, TotalBytes: 100}, taken.Status)
+	assert.Equal(t, map[string]string{
+		k8upv1.LabelRepositoryHash:   job.Sha256Hash("s3:repo"),
+		k8upv1.LabelSnapshotSource:   "pvc-a",
+		k8upv1.LabelSnapshotHostname: "app",
+		k8upv1.LabelBackupName:       "backup",
+		k8upv1.LabelK8upScheduleName: "schedule",
+	}, taken.Labels)
+
+	other := result.Items[1]
+	assert.Equal(t, "app-mariadb.sql", other.Spec.Source)
+	assert.Empty(t, other.Spec.BackupName, "only snapshots taken by the origin get its names")
+	assert.Equal(t, k8upv1.SnapshotStatus{}, other.Status)
+	assert.NotContains(t, other.Labels, k8upv1.LabelBackupName)
+}
+
+func TestSnapshotName(t *testing.T) {
+	id := "162e7a85acbc14de93dad31a3699331cb32187ff0d7bd2227b7c4362a1d13a42"
+
+	name := SnapshotName("s3:http://minio:9000/backup", id)
+	assert.Equal(t, job.Sha256Hash("s3:http://minio:9000/backup")[:16]+"-"+id, name)
+	assert.NotEqual(t, name, SnapshotName("s3:http://minio:9000/other", id), "the same snapshot in another repository has another name")
+}
+
+func Test_diff_GivenErrors_ThenExpectAllCollected(t *testing.T) {
+	a := &k8upv1.SnapshotList{Items: []k8upv1.Snapshot{
+		{Spec: k8upv1.SnapshotSpec{ID: ptr.To("1")}},
+		{Spec: k8upv1.SnapshotSpec{ID: ptr.To("2")}},
+	}}
+	counter := 0
+	err := diff(a, &k8upv1.SnapshotList{}, func(snap k8upv1.Snapshot) error {
+		counter++
+		return fmt.Errorf("snapshot %s failed", *snap.Spec.ID)
+	})
+
+	assert.Equal(t, 2, counter, "an error doesn't abort the iteration")
+	assert.EqualError(t, err, "snapshot 1 failed\nsnapshot 2 failed")
+}
@@ -26,6 +26,8 @@ type BackupSummary struct {
 	TotalBytesProcessed int     `json:"total_bytes_processed"`
 	TotalDuration       float64 `json:"total_duration"`
 	SnapshotID          string  `json:"snapshot_id"`
+	// FailedPaths contains the first paths restic couldn't read, they're collected by the BackupOutputParser.
+	FailedPaths []string `json:"-"`
 }
 
 type BackupEnvelope struct {
@@ -52,9 +54,13 @@ type SummaryFunc func(summary BackupSummary, errorCount int, folder string, star
 // PercentageFunc should format and print the given float.
 type PercentageFunc func(logr.Logger, float64)
 
+// maxFailedPaths is the number of unreadable paths the BackupOutputParser keeps.
+const maxFailedPaths = 10
+
 type BackupOutputParser struct {
 	log            logr.Logger
 	errorCount     int
+	failedPaths    []string
 	summaryFunc    SummaryFunc
 	percentageFunc PercentageFunc
 	folder         string
@@ -153,12 +159,16 @@ func (b *BackupOutputParser) out(s string) {
 	switch envelope.MessageType {
 	case "error":
 		b.errorCount++
+		if envelope.Item != "" && len(b.failedPaths) < maxFailedPaths {
+			b.failedPaths = append(b.failedPaths, envelope.Item)
+		}
 		b.log.Error(fmt.Errorf("error occurred during backup"), envelope.Item+" during "+envelope.During+" "+envelope.Error.Op)
 	case "status":
 		b.percentageFunc(b.log, envelope.PercentDone)
 	case "summary":
 		b.log.Info("backup finished", "new files", envelope.FilesNew, "changed files", envelope.FilesChanged, "errors", b.errorCount)
 		b.log.Info("stats", "time", envelope.TotalDuration, "bytes added", envelope.DataAdded, "bytes processed", envelope.TotalBytesProcessed)
+		envelope.BackupSummary.FailedPaths = b.failedPaths
 		b.summaryFunc(envelope.BackupSummary, b.errorCount, b.folder, 1, time.Now().Unix())
 	}
 }